@@ -33,8 +33,9 @@ const (
 	// Its reason captures the lifecycle stage and is surfaced as the Status print
 	// column: AcceptedReason while external-dns has taken the endpoint into its
 	// plan but not yet programmed it (Ready=False), ProgrammedReason once the
-	// provider has applied it (Ready=True), or FailedReason when the provider
-	// rejected the batch it belonged to (Ready=False).
+	// provider has applied it (Ready=True), FailedReason when the provider
+	// rejected the batch it belonged to (Ready=False), or MissingReason when a
+	// verification found the record removed from the provider (Ready=False).
 	ReadyCondition string = "Ready"
 
 	// Reasons for the Ready condition. They double as the human-readable value of
@@ -42,6 +43,18 @@ const (
 	AcceptedReason   string = "Accepted"
 	ProgrammedReason string = "Programmed"
 	FailedReason     string = "Failed"
+	MissingReason    string = "Missing"
+
+	// InSyncCondition reports whether the record observed in the DNS provider
+	// matches the spec. It is set when the record is read back from the provider:
+	// InSyncReason when the targets and the TTL match (InSync=True), DriftedReason
+	// when they were changed out-of-band (InSync=False), or MissingReason when the
+	// record is gone (InSync=False).
+	InSyncCondition string = "InSync"
+
+	// Reasons for the InSync condition, along with MissingReason.
+	InSyncReason  string = "InSync"
+	DriftedReason string = "Drifted"
 )
 
 // DNSRecordSpec defines the desired state of DNSRecord
@@ -51,16 +64,21 @@ type DNSRecordSpec struct {
 }
 
 // MergeProviderLabels overwrites the record's endpoint labels with the labels
-// the provider stored, preserving the external-dns owner and resource labels.
+// the provider stored, preserving the external-dns owner, resource and pending-deletion labels.
 // Some providers (e.g. coredns) rewrite labels on apply, so the provider copy
-// is authoritative for everything except ownership/resource identity.
+// is authoritative for everything except ownership/resource identity and
+// deletion state tracked by the registry.
 func (r *DNSRecord) MergeProviderLabels(providerLabels endpoint.Labels, ownerID string) {
 	resource := r.Spec.Endpoint.Labels[endpoint.ResourceLabelKey]
+	pendingDeletion := r.Spec.Endpoint.Labels[endpoint.PendingDeletionLabelKey]
 	r.Spec.Endpoint.Labels = providerLabels
 	r.Spec.Endpoint.WithLabel(endpoint.OwnerLabelKey, ownerID)
 	if resource != "" {
 		r.Spec.Endpoint.WithLabel(endpoint.ResourceLabelKey, resource)
 	}
+	if pendingDeletion != "" {
+		r.Spec.Endpoint.WithLabel(endpoint.PendingDeletionLabelKey, pendingDeletion)
+	}
 }
 
 // DNSRecordStatus defines the observed state of DNSRecord
@@ -71,6 +89,26 @@ type DNSRecordStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status was last set for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedTargets are the targets of the record last observed in the DNS provider.
+	// +optional
+	ObservedTargets endpoint.Targets `json:"observedTargets,omitempty"`
+
+	// ObservedTTL is the TTL of the record last observed in the DNS provider.
+	// +optional
+	ObservedTTL endpoint.TTL `json:"observedTTL,omitempty"`
+
+	// LastAppliedTime is the last time the endpoint was applied to the DNS provider.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// LastVerifiedTime is the last time the record was read back from the DNS provider.
+	// +optional
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
 }
 
 // +genclient
@@ -88,6 +126,8 @@ type DNSRecordStatus struct {
 // +kubebuilder:printcolumn:name="Set ID",type=string,JSONPath=`.spec.endpoint.setIdentifier`
 // +kubebuilder:printcolumn:name="Targets",type=string,JSONPath=`.spec.endpoint.targets`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Sync",type=string,JSONPath=`.status.conditions[?(@.type=="InSync")].reason`,priority=1
+// +kubebuilder:printcolumn:name="Verified",type=date,JSONPath=`.status.lastVerifiedTime`,priority=1
 // +versionName=v1alpha1
 
 type DNSRecord struct {
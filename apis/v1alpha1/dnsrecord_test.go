@@ -46,6 +46,13 @@ func TestMergeProviderLabels(t *testing.T) {
 			ownerID:        "me",
 			want:           endpoint.Labels{"foo": "bar", endpoint.OwnerLabelKey: "me", endpoint.ResourceLabelKey: "ingress/default/web"},
 		},
+		{
+			name:           "pending-deletion label is preserved across the merge",
+			current:        endpoint.Labels{endpoint.PendingDeletionLabelKey: "2026-01-02T03:04:05Z", endpoint.OwnerLabelKey: "me"},
+			providerLabels: endpoint.Labels{"foo": "bar"},
+			ownerID:        "me",
+			want:           endpoint.Labels{"foo": "bar", endpoint.OwnerLabelKey: "me", endpoint.PendingDeletionLabelKey: "2026-01-02T03:04:05Z"},
+		},
 		{
 			name:           "nil provider labels still yields an owner-labeled map",
 			current:        endpoint.Labels{endpoint.OwnerLabelKey: "me"},
@@ -195,6 +195,19 @@ func (in *DNSRecordStatus) DeepCopyInto(out *DNSRecordStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ObservedTargets != nil {
+		in, out := &in.ObservedTargets, &out.ObservedTargets
+		*out = make(endpoint.Targets, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastVerifiedTime != nil {
+		in, out := &in.LastVerifiedTime, &out.LastVerifiedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordStatus.
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// AuditReport is the result of the last audit, served as JSON by the Auditor.
+type AuditReport struct {
+	GeneratedAt time.Time                `json:"generatedAt"`
+	OwnerID     string                   `json:"ownerId"`
+	Summary     map[plan.AuditStatus]int `json:"summary"`
+	Records     []AuditedRecord          `json:"records"`
+}
+
+// AuditedRecord is the classification of a record in the zone it belongs to.
+type AuditedRecord struct {
+	Zone string `json:"zone"`
+	plan.AuditRecord
+}
+
+// Auditor classifies the records instead of applying changes, and serves the last report over HTTP.
+type Auditor struct {
+	// ZoneLister, when set, lists the zones records are reported per
+	ZoneLister provider.ZoneLister
+
+	mutex  sync.RWMutex
+	report *AuditReport
+}
+
+// audit classifies the records of the plan and publishes the report and the audit metrics.
+func (a *Auditor) audit(ctx context.Context, p *plan.Plan) error {
+	var zones provider.ZoneIDName
+	if a.ZoneLister != nil {
+		var err error
+		if zones, err = a.ZoneLister.ZoneIDNames(ctx); err != nil {
+			return fmt.Errorf("listing zones: %w", err)
+		}
+	}
+
+	report := &AuditReport{
+		GeneratedAt: time.Now().UTC(),
+		OwnerID:     p.OwnerID,
+		Summary:     map[plan.AuditStatus]int{},
+		Records:     []AuditedRecord{},
+	}
+	perZone := map[string]map[plan.AuditStatus]int{}
+	for _, r := range p.Audit() {
+		_, zone := zones.FindZone(strings.TrimSuffix(r.DNSName, "."))
+		report.Records = append(report.Records, AuditedRecord{Zone: zone, AuditRecord: r})
+		report.Summary[r.Status]++
+		if perZone[zone] == nil {
+			perZone[zone] = map[plan.AuditStatus]int{}
+		}
+		perZone[zone][r.Status]++
+	}
+
+	auditRecords.Reset()
+	for zone, counts := range perZone {
+		for _, status := range plan.AuditStatuses {
+			auditRecords.SetWithLabels(float64(counts[status]), zone, string(status))
+		}
+	}
+
+	a.mutex.Lock()
+	a.report = report
+	a.mutex.Unlock()
+
+	log.Infof("Audited %d records: %d in sync, %d drifted, %d colliding, %d orphaned", len(report.Records),
+		report.Summary[plan.AuditInSync], report.Summary[plan.AuditDrifted], report.Summary[plan.AuditColliding], report.Summary[plan.AuditOrphaned])
+	return nil
+}
+
+// ServeHTTP writes the last report as JSON, or 503 Service Unavailable before the first audit.
+func (a *Auditor) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	a.mutex.RLock()
+	report := a.report
+	a.mutex.RUnlock()
+
+	if report == nil {
+		http.Error(w, "audit has not run yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Errorf("Failed to write audit report: %v", err)
+	}
+}
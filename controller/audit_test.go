@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+// TestRunOnceAudit tests that an audit reports the records per zone without applying any change.
+func TestRunOnceAudit(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil)
+	mockProvider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+	cfg := getTestConfig()
+	r, err := registryfactory.Select(cfg, mockProvider)
+	require.NoError(t, err)
+
+	auditor := &Auditor{ZoneLister: domainZones{"example.com", "example.org"}}
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		Auditor:            auditor,
+	}
+
+	rec := httptest.NewRecorder()
+	auditor.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Empty(t, mockProvider.ApplyChangesCalls)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, auditRecords.Gauge, map[string]string{"zone": "example.com", "status": "drifted"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, auditRecords.Gauge, map[string]string{"zone": "example.com", "status": "in-sync"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, auditRecords.Gauge, map[string]string{"zone": "example.org", "status": "in-sync"})
+
+	rec = httptest.NewRecorder()
+	auditor.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report AuditReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, map[plan.AuditStatus]int{plan.AuditDrifted: 1, plan.AuditInSync: 1}, report.Summary)
+	assert.Equal(t, []AuditedRecord{
+		{Zone: "example.org", AuditRecord: plan.AuditRecord{DNSName: "api.example.org", RecordType: "A", Status: plan.AuditInSync}},
+		{Zone: "example.com", AuditRecord: plan.AuditRecord{DNSName: "web.example.com", RecordType: "A", Status: plan.AuditDrifted}},
+	}, report.Records)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// backoff computes exponentially growing delays between retries of failed runs.
+type backoff struct {
+	// initial is the delay after the first failure, doubled for every consecutive failure
+	initial time.Duration
+	// max caps the delay
+	max time.Duration
+	// jitter adds a random delay of up to this fraction of the delay
+	jitter float64
+
+	failures int
+}
+
+// newBackoff returns a backoff starting at initial, or nil when initial is zero.
+func newBackoff(initial, maxDelay time.Duration, jitter float64) *backoff {
+	if initial <= 0 {
+		return nil
+	}
+	return &backoff{initial: initial, max: maxDelay, jitter: jitter}
+}
+
+// next records a failure and returns the delay before the next retry.
+func (b *backoff) next() time.Duration {
+	b.failures++
+	delay := b.initial
+	for i := 1; i < b.failures && (b.max <= 0 || delay < b.max); i++ {
+		delay *= 2
+	}
+	if b.max > 0 && delay > b.max {
+		delay = b.max
+	}
+	if b.jitter > 0 {
+		delay = wait.Jitter(delay, b.jitter)
+	}
+	return delay
+}
+
+// reset forgets all failures after a successful run.
+func (b *backoff) reset() {
+	if b != nil {
+		b.failures = 0
+	}
+}
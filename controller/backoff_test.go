@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry/noop"
+)
+
+func TestNewBackoffDisabled(t *testing.T) {
+	assert.Nil(t, newBackoff(0, time.Minute, 0.1))
+
+	// reset is safe on a disabled backoff
+	var b *backoff
+	b.reset()
+}
+
+func TestBackoffNext(t *testing.T) {
+	b := newBackoff(time.Second, 5*time.Second, 0)
+
+	assert.Equal(t, time.Second, b.next())
+	assert.Equal(t, 2*time.Second, b.next())
+	assert.Equal(t, 4*time.Second, b.next())
+	assert.Equal(t, 5*time.Second, b.next())
+	assert.Equal(t, 5*time.Second, b.next())
+
+	b.reset()
+	assert.Equal(t, time.Second, b.next())
+}
+
+func TestBackoffNextWithJitter(t *testing.T) {
+	b := newBackoff(time.Second, time.Minute, 0.5)
+
+	for i := range 10 {
+		delay := b.next()
+		base := min(time.Second<<i, time.Minute)
+		assert.GreaterOrEqual(t, delay, base)
+		assert.LessOrEqual(t, delay, base+base/2)
+	}
+}
+
+func TestScheduleRunOnceDuringBackoff(t *testing.T) {
+	ctrl := &Controller{Interval: 10 * time.Minute, MinEventSyncInterval: 5 * time.Second}
+	now := time.Now()
+
+	require.True(t, ctrl.ShouldRunOnce(now))
+	ctrl.lastRunAt = now
+	ctrl.backOff(now, time.Minute)
+	assert.Equal(t, now.Add(time.Minute), ctrl.nextRunAt)
+
+	// Events do not trigger a run before the backoff has elapsed
+	ctrl.ScheduleRunOnce(now.Add(10 * time.Second))
+	assert.False(t, ctrl.ShouldRunOnce(now.Add(30*time.Second)))
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(time.Minute)))
+
+	// Once the backoff is reset, events trigger runs after MinEventSyncInterval again
+	ctrl.lastRunAt = now.Add(time.Minute)
+	ctrl.backOff(time.Time{}, 0)
+	ctrl.ScheduleRunOnce(now.Add(time.Minute))
+	assert.True(t, ctrl.ShouldRunOnce(now.Add(time.Minute+5*time.Second)))
+}
+
+// failingRegistry fails to list records with a hard error.
+type failingRegistry struct {
+	noop.NoopRegistry
+
+	mutex sync.Mutex
+	calls int
+}
+
+func (r *failingRegistry) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.calls++
+	return nil, errors.New("provider unavailable")
+}
+
+func TestRunRetriesFailedRunsWithinBudget(t *testing.T) {
+	r := &failingRegistry{}
+	ctrl := &Controller{
+		Source:                  getTestSource(),
+		Registry:                r,
+		Policy:                  &plan.SyncPolicy{},
+		ManagedRecordTypes:      getTestConfig().ManagedDNSRecordTypes,
+		Interval:                time.Minute,
+		ReconcileBackoffInitial: 10 * time.Millisecond,
+		ReconcileBackoffMax:     time.Second,
+		ReconcileRetryBudget:    1,
+	}
+	ctrl.nextRunAt = time.Now().Add(-time.Millisecond)
+
+	err := ctrl.Run(t.Context())
+	require.ErrorContains(t, err, "provider unavailable")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	assert.Equal(t, 2, r.calls, "the failed run is retried once before exiting")
+}
+
+func TestRunWithoutBackoffExitsOnFailure(t *testing.T) {
+	r := &failingRegistry{}
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+		Interval:           time.Minute,
+	}
+	ctrl.nextRunAt = time.Now().Add(-time.Millisecond)
+
+	require.Error(t, ctrl.Run(t.Context()))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	assert.Equal(t, 1, r.calls)
+}
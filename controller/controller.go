@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -44,6 +45,10 @@ type Controller struct {
 	Registry registry.Registry
 	// The policy that defines which change to DNS records is allowed
 	Policy plan.Policy
+	// AdditionalPolicies are applied after Policy, e.g. a plan.DeletionThresholdPolicy
+	AdditionalPolicies []plan.Policy
+	// The ConflictResolver decides which resource gets a DNS name claimed by several resources
+	ConflictResolver plan.ConflictResolver
 	// The interval between individual synchronizations
 	Interval time.Duration
 	// The DomainFilter defines which DNS records to keep or exclude
@@ -53,16 +58,42 @@ type Controller struct {
 	// The runAtMutex is for atomic updating of nextRunAt and lastRunAt
 	runAtMutex sync.Mutex
 	// The lastRunAt used for throttling and batching reconciliation
-	lastRunAt    time.Time
+	lastRunAt time.Time
+	// The backoffUntil is the time before which events do not trigger a reconciliation after a failed run
+	backoffUntil time.Time
 	EventEmitter events.EventEmitter
+	// EventObject is the object events that are not about a single resource are reported on
+	EventObject *events.ObjectReference
 	// MangedRecordTypes are DNS record types that will be considered for management.
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// ReconcileBackoffInitial, when set, retries failed runs after this delay, doubled for every consecutive failure
+	ReconcileBackoffInitial time.Duration
+	// ReconcileBackoffMax caps the delay between retries of failed runs
+	ReconcileBackoffMax time.Duration
+	// ReconcileBackoffJitter adds a random delay of up to this fraction to every retry
+	ReconcileBackoffJitter float64
+	// ReconcileRetryBudget is the number of consecutive failed runs retried before the controller exits
+	ReconcileRetryBudget int
+	// SoftErrorRetryInitial, when set, retries runs failing with a soft error after this delay instead of the next Interval
+	SoftErrorRetryInitial time.Duration
 	// Old txt-owner value we need to migrate from
 	TXTOwnerOld string
+	// ZoneLister, when set, lists the zones changes are applied per, see ZoneApplyConcurrency
+	ZoneLister provider.ZoneLister
+	// ZoneApplyConcurrency is the number of zones applied in parallel, 0 applies all changes at once
+	ZoneApplyConcurrency int
+	// PlanOutput, when set, receives a preview of the calculated changes instead of them being applied
+	PlanOutput io.Writer
+	// PlanOutputFormat is the format of the preview written to PlanOutput (json or diff)
+	PlanOutputFormat string
+	// Auditor, when set, classifies the records instead of applying changes
+	Auditor *Auditor
+	// DryRun skips the cleanup of the registry, see registry.Cleaner
+	DryRun bool
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
@@ -105,7 +136,7 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	registryFilter := c.Registry.GetDomainFilter()
 
 	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
+		Policies:       append([]plan.Policy{c.Policy}, c.AdditionalPolicies...),
 		Current:        regRecords,
 		Desired:        endpoints,
 		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
@@ -113,11 +144,31 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 		ExcludeRecords: c.ExcludeRecordTypes,
 		OwnerID:        c.Registry.OwnerID(),
 		OldOwnerID:     c.TXTOwnerOld,
+		Resolver:       c.ConflictResolver,
+	}
+
+	if c.Auditor != nil {
+		return c.Auditor.audit(ctx, plan)
 	}
 
 	plan = plan.Calculate()
 
-	if plan.Changes.HasChanges() {
+	emitConflictEvents(c.EventEmitter, plan.Conflicts)
+
+	if plan.Blocked != nil {
+		emitDeletionBlockedEvent(c.EventEmitter, c.EventObject, plan.Blocked)
+		return provider.NewSoftError(plan.Blocked)
+	}
+
+	if c.PlanOutput != nil {
+		return c.writePlanPreview(plan.Changes)
+	}
+
+	if plan.Changes.HasChanges() && c.ZoneApplyConcurrency > 0 && c.ZoneLister != nil {
+		if err := c.applyChangesPerZone(ctx, plan.Changes); err != nil {
+			return err
+		}
+	} else if plan.Changes.HasChanges() {
 		err = c.Registry.ApplyChanges(ctx, plan.Changes)
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
@@ -131,8 +182,75 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 		log.Info("All records are already up to date")
 	}
 
+	if err := c.cleanupRegistry(ctx); err != nil {
+		return err
+	}
+
+	lastSyncTimestamp.Gauge.SetToCurrentTime()
+
+	return nil
+}
+
+// cleanupRegistry cleans up the registry when it is a registry.Cleaner, on every run
+// whether or not there were changes to apply.
+func (c *Controller) cleanupRegistry(ctx context.Context) error {
+	cleaner, ok := c.Registry.(registry.Cleaner)
+	if !ok || c.DryRun {
+		return nil
+	}
+	if err := cleaner.Cleanup(ctx); err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return fmt.Errorf("cleaning up the registry: %w", err)
+	}
+	return nil
+}
+
+// ApplyPlan applies previously calculated changes, e.g. from a reviewed plan preview.
+// The registry records are read first and nothing is applied if any record to update or
+// delete no longer matches, so that no decision is taken between review and apply.
+func (c *Controller) ApplyPlan(ctx context.Context, changes *plan.Changes) error {
+	regRecords, err := c.Registry.Records(ctx)
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return err
+	}
+
+	if stale := changes.StaleEndpoints(regRecords); len(stale) > 0 {
+		for _, ep := range stale {
+			log.Errorf("Plan is stale: %s no longer matches the current records", ep.Describe())
+		}
+		return fmt.Errorf("refusing to apply plan: %d records changed since the plan was calculated", len(stale))
+	}
+
+	if !changes.HasChanges() {
+		controllerNoChangesTotal.Counter.Inc()
+		log.Info("Plan contains no changes")
+		return nil
+	}
+
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	if err := c.Registry.ApplyChanges(ctx, changes); err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return err
+	}
+	log.Infof("Applied plan with %d creates, %d updates and %d deletes", len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
 	lastSyncTimestamp.Gauge.SetToCurrentTime()
+	return nil
+}
 
+// writePlanPreview writes the calculated changes to PlanOutput without applying them.
+func (c *Controller) writePlanPreview(changes *plan.Changes) error {
+	format := c.PlanOutputFormat
+	if format == "" {
+		format = plan.PreviewFormatJSON
+	}
+	if err := plan.NewPreview(changes, c.Registry.OwnerID()).Write(c.PlanOutput, format); err != nil {
+		return fmt.Errorf("writing plan preview: %w", err)
+	}
+	log.Infof("Wrote plan preview with %d creates, %d updates and %d deletes", len(changes.Create), len(changes.UpdateNew), len(changes.Delete))
 	return nil
 }
 
@@ -160,6 +278,7 @@ func (c *Controller) ScheduleRunOnce(now time.Time) {
 	defer c.runAtMutex.Unlock()
 	c.nextRunAt = latest(
 		c.lastRunAt.Add(c.MinEventSyncInterval),
+		c.backoffUntil,
 		earliest(
 			now.Add(5*time.Second),
 			c.nextRunAt,
@@ -181,7 +300,9 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 func (c *Controller) Run(ctx context.Context) error {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
-	var softErrorCount int
+	softErrorBackoff := newBackoff(c.SoftErrorRetryInitial, c.Interval, c.ReconcileBackoffJitter)
+	failureBackoff := newBackoff(c.ReconcileBackoffInitial, c.ReconcileBackoffMax, c.ReconcileBackoffJitter)
+	var softErrorCount, failedRunCount int
 	for {
 		if c.ShouldRunOnce(time.Now()) {
 			if err := c.RunOnce(ctx); err != nil {
@@ -189,6 +310,14 @@ func (c *Controller) Run(ctx context.Context) error {
 					softErrorCount++
 					consecutiveSoftErrors.Gauge.Set(float64(softErrorCount))
 					log.Errorf("Failed to do run once: %v (consecutive soft errors: %d)", err, softErrorCount)
+					if softErrorBackoff != nil {
+						c.backOff(time.Now(), softErrorBackoff.next())
+					}
+				} else if failureBackoff != nil && failedRunCount < c.ReconcileRetryBudget {
+					failedRunCount++
+					consecutiveFailedRuns.Gauge.Set(float64(failedRunCount))
+					log.Errorf("Failed to do run once: %v (consecutive failed runs: %d of %d)", err, failedRunCount, c.ReconcileRetryBudget)
+					c.backOff(time.Now(), failureBackoff.next())
 				} else {
 					return fmt.Errorf("failed to do run once: %w", err)
 				}
@@ -196,8 +325,15 @@ func (c *Controller) Run(ctx context.Context) error {
 				if softErrorCount > 0 {
 					log.Infof("Reconciliation succeeded after %d consecutive soft errors", softErrorCount)
 				}
-				softErrorCount = 0
+				if failedRunCount > 0 {
+					log.Infof("Reconciliation succeeded after %d consecutive failed runs", failedRunCount)
+				}
+				softErrorCount, failedRunCount = 0, 0
+				softErrorBackoff.reset()
+				failureBackoff.reset()
+				c.backOff(time.Time{}, 0)
 				consecutiveSoftErrors.Gauge.Set(0)
+				consecutiveFailedRuns.Gauge.Set(0)
 			}
 		}
 		select {
@@ -208,3 +344,18 @@ func (c *Controller) Run(ctx context.Context) error {
 		}
 	}
 }
+
+// backOff delays the next run by delay, including runs triggered by events.
+// A zero delay stops backing off.
+func (c *Controller) backOff(now time.Time, delay time.Duration) {
+	reconcileBackoffSeconds.Gauge.Set(delay.Seconds())
+	c.runAtMutex.Lock()
+	defer c.runAtMutex.Unlock()
+	if delay <= 0 {
+		c.backoffUntil = time.Time{}
+		return
+	}
+	log.Infof("Backing off for %s before the next reconciliation", delay.Round(time.Millisecond))
+	c.backoffUntil = now.Add(delay)
+	c.nextRunAt = c.backoffUntil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"reflect"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/provider/fakes"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 	registryfactory "sigs.k8s.io/external-dns/registry/factory"
 	"sigs.k8s.io/external-dns/registry/noop"
 
@@ -240,6 +242,212 @@ func TestRunOnce(t *testing.T) {
 	emitter.AssertNumberOfCalls(t, "Add", 6)
 }
 
+// TestRunOnceRefusedConflict tests that a refused conflict leaves the DNS name untouched and emits warnings.
+func TestRunOnceRefusedConflict(t *testing.T) {
+	refObj := &events.ObjectReference{}
+	teamA := endpoint.NewEndpoint("shared-record", endpoint.RecordTypeA, "1.2.3.4").WithRefObject(refObj)
+	teamA.Labels[endpoint.ResourceLabelKey] = "ingress/team-a/shared"
+	teamB := endpoint.NewEndpoint("shared-record", endpoint.RecordTypeA, "5.6.7.8").WithRefObject(refObj)
+	teamB.Labels[endpoint.ResourceLabelKey] = "ingress/team-b/shared"
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{teamA, teamB}, nil)
+	cfg := getTestConfig()
+	provider := &filteredMockProvider{}
+	emitter := fake.NewFakeEventEmitter()
+
+	r, err := registryfactory.Select(cfg, provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ConflictResolver:   plan.RefuseConflicts{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		EventEmitter:       emitter,
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	assert.Empty(t, provider.ApplyChangesCalls)
+	emitter.AssertCalled(t, "Add", mock.MatchedBy(func(e events.Event) bool {
+		return e.EventType() == events.EventTypeWarning && e.Reason() == events.RecordConflict
+	}))
+	emitter.AssertNumberOfCalls(t, "Add", 2)
+}
+
+// TestRunOnceDeletionThresholdExceeded tests that RunOnce applies nothing and returns a soft error
+// when the changes exceed the deletion threshold.
+func TestRunOnceDeletionThresholdExceeded(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	cfg := getTestConfig()
+	mockProvider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+	}
+	emitter := fake.NewFakeEventEmitter()
+
+	r, err := registryfactory.Select(cfg, mockProvider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		AdditionalPolicies: []plan.Policy{&plan.DeletionThresholdPolicy{MaxDeletes: 1}},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		EventEmitter:       emitter,
+		EventObject:        events.NewObjectReferenceFromParts("Pod", "v1", "default", "external-dns", "", "controller"),
+	}
+
+	err = ctrl.RunOnce(t.Context())
+	require.ErrorIs(t, err, provider.SoftError)
+	require.ErrorIs(t, err, plan.ErrDeletionThresholdExceeded)
+	assert.Empty(t, mockProvider.ApplyChangesCalls)
+	emitter.AssertCalled(t, "Add", mock.MatchedBy(func(e events.Event) bool {
+		return e.EventType() == events.EventTypeWarning && e.Reason() == events.RecordDeletionBlocked
+	}))
+
+	ctrl.AdditionalPolicies = []plan.Policy{&plan.DeletionThresholdPolicy{MaxDeletes: 1, Override: true}}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.Len(t, mockProvider.ApplyChangesCalls, 1)
+	assert.Len(t, mockProvider.ApplyChangesCalls[0].Delete, 2)
+}
+
+// TestRunOnceWithPlanOutput tests that RunOnce writes the plan instead of applying it.
+func TestRunOnceWithPlanOutput(t *testing.T) {
+	source := getTestSource()
+	cfg := getTestConfig()
+	provider := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "8.8.8.8"),
+		},
+	}
+
+	r, err := registryfactory.Select(cfg, provider)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		PlanOutput:         &out,
+		PlanOutputFormat:   plan.PreviewFormatDiff,
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	assert.Empty(t, provider.ApplyChangesCalls)
+	assert.Contains(t, out.String(), "@@ create create-record A @@\n+create-record 0 IN A 1.2.3.4\n")
+	assert.Contains(t, out.String(), "-update-record 0 IN A 8.8.8.8\n+update-record 0 IN A 8.8.4.4\n")
+	assert.Contains(t, out.String(), "# 3 to create, 1 to update, 0 to delete\n")
+}
+
+// TestRunOnceCleansUpRegistry tests that the registry is cleaned up on every run, even when
+// the plan has no changes, and not in dry-run.
+func TestRunOnceCleansUpRegistry(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+
+	cfg := getTestConfig()
+	cfg.Registry = externaldns.RegistryTXT
+	cfg.TXTOrphanCleanup = externaldns.TXTOrphanCleanupDelete
+	cfg.TXTOrphanMinAge = 0
+	cfg.TXTOwnerID = "default"
+	r, err := registryfactory.Select(cfg, p)
+	require.NoError(t, err)
+
+	www := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	gone := endpoint.NewEndpoint("gone.example.org", endpoint.RecordTypeA, "5.6.7.8")
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{www.DeepCopy(), gone.DeepCopy()}}))
+	// the record is deleted by hand, leaving its TXT record behind
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{gone.DeepCopy()}}))
+
+	txtNames := func() []string {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		var names []string
+		for _, ep := range records {
+			if ep.RecordType == endpoint.RecordTypeTXT {
+				names = append(names, ep.DNSName)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	require.Equal(t, []string{"a-gone.example.org", "a-www.example.org"}, txtNames())
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{www.DeepCopy()}, nil)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		DryRun:             true,
+	}
+
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, []string{"a-gone.example.org", "a-www.example.org"}, txtNames(), "nothing is deleted in dry-run")
+
+	ctrl.DryRun = false
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, []string{"a-www.example.org"}, txtNames())
+}
+
+// TestApplyPlan tests that a saved plan is applied only while it matches the current records.
+func TestApplyPlan(t *testing.T) {
+	current := endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "8.8.8.8")
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "8.8.8.8")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "8.8.4.4")},
+	}
+
+	t.Run("fresh plan is applied", func(t *testing.T) {
+		provider := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{current}}
+		r, err := registryfactory.Select(getTestConfig(), provider)
+		require.NoError(t, err)
+		ctrl := &Controller{Registry: r}
+
+		require.NoError(t, ctrl.ApplyPlan(t.Context(), changes))
+		require.Len(t, provider.ApplyChangesCalls, 1)
+		assert.Equal(t, changes, provider.ApplyChangesCalls[0])
+	})
+
+	t.Run("stale plan is refused", func(t *testing.T) {
+		provider := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update-record", endpoint.RecordTypeA, "9.9.9.9"),
+		}}
+		r, err := registryfactory.Select(getTestConfig(), provider)
+		require.NoError(t, err)
+		ctrl := &Controller{Registry: r}
+
+		require.EqualError(t, ctrl.ApplyPlan(t.Context(), changes), "refusing to apply plan: 1 records changed since the plan was calculated")
+		assert.Empty(t, provider.ApplyChangesCalls)
+	})
+
+	t.Run("empty plan is not applied", func(t *testing.T) {
+		provider := &filteredMockProvider{}
+		r, err := registryfactory.Select(getTestConfig(), provider)
+		require.NoError(t, err)
+		ctrl := &Controller{Registry: r}
+
+		require.NoError(t, ctrl.ApplyPlan(t.Context(), &plan.Changes{}))
+		assert.Empty(t, provider.ApplyChangesCalls)
+	})
+}
+
 // TestRun tests that Run correctly starts and stops
 func TestRun(t *testing.T) {
 	source := getTestSource()
@@ -17,12 +17,15 @@ limitations under the License.
 package controller
 
 import (
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 )
 
 // emitChangeEvent emits a Kubernetes event for each DNS record change.
-// Deletes use RecordDeleted on success and RecordError on failure.
+// Deletes use RecordDeleted on success and RecordError on failure,
+// updates of adopted records use RecordAdopted on success.
 func emitChangeEvent(e events.EventEmitter, ch *plan.Changes, reason events.Reason) {
 	if e == nil {
 		return
@@ -30,8 +33,18 @@ func emitChangeEvent(e events.EventEmitter, ch *plan.Changes, reason events.Reas
 	for _, ep := range ch.Create {
 		e.Add(events.NewEventFromEndpoint(ep, events.ActionCreate, reason))
 	}
+	adopted := sets.New[endpoint.EndpointKey]()
+	for _, ep := range ch.UpdateOld {
+		if ep.IsAdopted() {
+			adopted.Insert(ep.Key())
+		}
+	}
 	for _, ep := range ch.UpdateNew {
-		e.Add(events.NewEventFromEndpoint(ep, events.ActionUpdate, reason))
+		updateReason := reason
+		if reason == events.RecordReady && adopted.Has(ep.Key()) {
+			updateReason = events.RecordAdopted
+		}
+		e.Add(events.NewEventFromEndpoint(ep, events.ActionUpdate, updateReason))
 	}
 	deleteReason := events.RecordDeleted
 	if reason == events.RecordError {
@@ -41,3 +54,24 @@ func emitChangeEvent(e events.EventEmitter, ch *plan.Changes, reason events.Reas
 		e.Add(events.NewEventFromEndpoint(ep, events.ActionDelete, deleteReason))
 	}
 }
+
+// emitConflictEvents emits a Warning event for every resource claiming a DNS name
+// the conflict resolver refused to assign.
+func emitConflictEvents(e events.EventEmitter, conflicts []plan.Conflict) {
+	if e == nil {
+		return
+	}
+	for _, conflict := range conflicts {
+		for _, ep := range conflict.Candidates {
+			e.Add(events.NewWarningEventFromEndpoint(ep, events.ActionFailed, events.RecordConflict))
+		}
+	}
+}
+
+// emitDeletionBlockedEvent emits a Warning event on obj for a run blocked by a deletion threshold.
+func emitDeletionBlockedEvent(e events.EventEmitter, obj *events.ObjectReference, blocked error) {
+	if e == nil || obj == nil {
+		return
+	}
+	e.Add(events.NewWarningEvent(obj, blocked.Error(), events.ActionFailed, events.RecordDeletionBlocked))
+}
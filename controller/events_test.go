@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,6 +65,24 @@ func TestEmit_RecordReady(t *testing.T) {
 				em.AssertNumberOfCalls(t, "Add", 5)
 			},
 		},
+		{
+			name: "adopted endpoints",
+			changes: plan.Changes{
+				UpdateOld: []*endpoint.Endpoint{
+					endpoint.NewEndpoint("one.example.com", endpoint.RecordTypeA, "10.10.10.0").WithLabel(endpoint.AdoptedLabelKey, "true"),
+					endpoint.NewEndpoint("two.example.com", endpoint.RecordTypeA, "10.10.10.1"),
+				},
+				UpdateNew: []*endpoint.Endpoint{
+					endpoint.NewEndpoint("one.example.com", endpoint.RecordTypeA, "10.10.10.0").WithRefObject(refObj),
+					endpoint.NewEndpoint("two.example.com", endpoint.RecordTypeA, "10.10.10.2").WithRefObject(refObj),
+				},
+			},
+			asserts: func(em *fake.EventEmitter, ch plan.Changes) {
+				em.AssertCalled(t, "Add", events.NewEventFromEndpoint(ch.UpdateNew[0], events.ActionUpdate, events.RecordAdopted))
+				em.AssertCalled(t, "Add", events.NewEventFromEndpoint(ch.UpdateNew[1], events.ActionUpdate, events.RecordReady))
+				em.AssertNumberOfCalls(t, "Add", 2)
+			},
+		},
 		{
 			name: "delete endpoints",
 			changes: plan.Changes{
@@ -164,3 +183,39 @@ func TestEmit_RecordError(t *testing.T) {
 		})
 	}
 }
+
+func TestEmit_RecordConflict(t *testing.T) {
+	refObj := &events.ObjectReference{}
+	fooA := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "10.10.10.0").WithRefObject(refObj)
+	fooB := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "10.10.10.1").WithRefObject(refObj)
+
+	em := fake.NewFakeEventEmitter()
+	emitConflictEvents(em, []plan.Conflict{{Candidates: []*endpoint.Endpoint{fooA, fooB}}})
+
+	em.AssertCalled(t, "Add", events.NewWarningEventFromEndpoint(fooA, events.ActionFailed, events.RecordConflict))
+	em.AssertCalled(t, "Add", events.NewWarningEventFromEndpoint(fooB, events.ActionFailed, events.RecordConflict))
+	em.AssertNotCalled(t, "Add", mock.MatchedBy(func(e events.Event) bool {
+		return e.EventType() == events.EventTypeNormal
+	}))
+	em.AssertNumberOfCalls(t, "Add", 2)
+
+	assert.NotPanics(t, func() {
+		emitConflictEvents(nil, []plan.Conflict{{Candidates: []*endpoint.Endpoint{fooA}}})
+	})
+}
+
+func TestEmit_RecordDeletionBlocked(t *testing.T) {
+	obj := events.NewObjectReferenceFromParts("Pod", "v1", "external-dns", "external-dns-abc", "", "controller")
+	blocked := errors.New("deletion threshold exceeded")
+
+	em := fake.NewFakeEventEmitter()
+	emitDeletionBlockedEvent(em, obj, blocked)
+	em.AssertCalled(t, "Add", events.NewWarningEvent(obj, blocked.Error(), events.ActionFailed, events.RecordDeletionBlocked))
+	em.AssertNumberOfCalls(t, "Add", 1)
+
+	assert.NotPanics(t, func() {
+		emitDeletionBlockedEvent(nil, obj, blocked)
+		emitDeletionBlockedEvent(em, nil, blocked)
+	})
+	em.AssertNumberOfCalls(t, "Add", 1)
+}
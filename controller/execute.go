@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	kubeclient "sigs.k8s.io/external-dns/pkg/client"
 	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
@@ -89,12 +90,25 @@ func execute(ctx context.Context) {
 
 	log.Info(externaldns.Banner())
 
-	go serveMetrics(cfg.MetricsAddress)
-
 	sCfg, err := source.NewSourceConfig(cfg)
 	if err != nil {
 		log.Fatal(err) // nolint: gocritic // exitAfterDefer
 	}
+
+	var le *leaderElection
+	if cfg.EnableLeaderElection {
+		kubeClient, err := sCfg.ClientGenerator().KubeClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		le, err = newLeaderElection(cfg, kubeClient)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	go serveMetrics(cfg.MetricsAddress, le)
+
 	endpointsSource, err := wrappers.Build(ctx, sCfg)
 	if err != nil {
 		log.Fatal(err) // nolint: gocritic // exitAfterDefer
@@ -107,6 +121,11 @@ func execute(ctx context.Context) {
 		endpoint.WithRegexDomainExclude(cfg.RegexDomainExclude),
 	)
 
+	if cfg.ProvidersConfig != "" {
+		executeGroup(ctx, cfg, sCfg, le, endpointsSource)
+		return
+	}
+
 	prvdr, err := providerfactory.Select(ctx, cfg, domainFilter)
 	if err != nil {
 		log.Fatal(err)
@@ -117,13 +136,47 @@ func execute(ctx context.Context) {
 		os.Exit(0)
 	}
 
+	if cfg.MigrateRegistryTo != "" {
+		err := runMaybeWithLeaderElection(ctx, le, func(ctx context.Context) error {
+			return migrateRegistry(ctx, cfg, prvdr, os.Stdout)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
 	ctrl, err := buildController(ctx, cfg, sCfg, endpointsSource, prvdr, domainFilter)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if ctrl.Auditor != nil {
+		http.Handle("/audit", ctrl.Auditor)
+	}
 
 	if cfg.Once {
-		err := ctrl.RunOnce(ctx)
+		if cfg.PlanOutput != "" {
+			if err := runOncePlanOutput(ctx, ctrl, cfg.PlanOutput, cfg.PlanOutputFormat); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
+
+		if cfg.PlanApply != "" {
+			changes, err := readPlan(cfg.PlanApply)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = runMaybeWithLeaderElection(ctx, le, func(ctx context.Context) error {
+				return ctrl.ApplyPlan(ctx, changes)
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
+
+		err := runMaybeWithLeaderElection(ctx, le, ctrl.RunOnce)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -139,9 +192,75 @@ func execute(ctx context.Context) {
 	}
 
 	ctrl.ScheduleRunOnce(time.Now())
-	if err := ctrl.Run(ctx); err != nil {
+	if err := runMaybeWithLeaderElection(ctx, le, ctrl.Run); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// executeGroup runs a controller for every provider of the multi-provider configuration.
+func executeGroup(ctx context.Context, cfg *externaldns.Config, sCfg *source.Config, le *leaderElection, src source.Source) {
+	group, err := buildControllerGroup(ctx, cfg, sCfg, src)
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	if cfg.Once {
+		if err := runMaybeWithLeaderElection(ctx, le, group.RunOnce); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.UpdateEvents {
+		src.AddEventHandler(ctx, func() { group.ScheduleRunOnce(time.Now()) })
+	}
+
+	group.ScheduleRunOnce(time.Now())
+	if err := runMaybeWithLeaderElection(ctx, le, group.Run); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runOncePlanOutput calculates the changes once and writes them to path ("-" for stdout) instead of applying them.
+// Leader election is not needed as nothing is written to the DNS provider.
+func runOncePlanOutput(ctx context.Context, ctrl *Controller, path, format string) error {
+	ctrl.PlanOutputFormat = format
+	if path == "-" {
+		ctrl.PlanOutput = os.Stdout
+		return ctrl.RunOnce(ctx)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating plan output: %w", err)
+	}
+	ctrl.PlanOutput = f
+	if err := ctrl.RunOnce(ctx); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readPlan reads the changes of a saved plan from path ("-" for stdin).
+func readPlan(path string) (*plan.Changes, error) {
+	if path == "-" {
+		return plan.ReadChanges(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening plan: %w", err)
+	}
+	defer f.Close()
+	return plan.ReadChanges(f)
+}
+
+// runMaybeWithLeaderElection runs fn directly, or only while holding the leader lease when
+// leader election is enabled.
+func runMaybeWithLeaderElection(ctx context.Context, le *leaderElection, fn func(context.Context) error) error {
+	if le == nil {
+		return fn(ctx)
+	}
+	return le.Run(ctx, fn)
 }
 
 func buildController(
@@ -156,6 +275,25 @@ func buildController(
 	if !ok {
 		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
 	}
+	var resolver plan.ConflictResolver = plan.PerResource{}
+	if cfg.ConflictResolver != "" {
+		newResolver, ok := plan.ConflictResolvers[cfg.ConflictResolver]
+		if !ok {
+			return nil, fmt.Errorf("unknown conflict resolver: %s", cfg.ConflictResolver)
+		}
+		resolver = newResolver(plan.ConflictResolverConfig{NamespacePriority: cfg.ConflictResolverNamespacePriority})
+	}
+	var additionalPolicies []plan.Policy
+	if cfg.DeletionGracePeriod > 0 {
+		additionalPolicies = append(additionalPolicies, &plan.DeletionGracePeriodPolicy{GracePeriod: cfg.DeletionGracePeriod})
+	}
+	if cfg.DeletionThresholdCount > 0 || cfg.DeletionThresholdPercentage > 0 {
+		additionalPolicies = append(additionalPolicies, &plan.DeletionThresholdPolicy{
+			MaxDeletes:          cfg.DeletionThresholdCount,
+			MaxDeletePercentage: cfg.DeletionThresholdPercentage,
+			Override:            cfg.DeletionThresholdOverride,
+		})
+	}
 	reg, err := registryfactory.Select(cfg, p)
 	if err != nil {
 		return nil, err
@@ -177,20 +315,84 @@ func buildController(
 		eventEmitter = eventCtrl
 	}
 
+	var zoneLister provider.ZoneLister
+	if cfg.ZoneApplyConcurrency > 0 || cfg.Audit {
+		zoneLister = newZoneLister(p, cfg.DomainFilter)
+	}
+	var auditor *Auditor
+	if cfg.Audit {
+		log.Info("Running in audit mode. No changes to DNS records will be made.")
+		auditor = &Auditor{ZoneLister: zoneLister}
+	}
+
 	return &Controller{
-		Source:               src,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         filter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
-		TXTOwnerOld:          cfg.TXTOwnerOld,
-		EventEmitter:         eventEmitter,
+		Source:                  src,
+		Registry:                reg,
+		Policy:                  policy,
+		AdditionalPolicies:      additionalPolicies,
+		ConflictResolver:        resolver,
+		Interval:                cfg.Interval,
+		DomainFilter:            filter,
+		ManagedRecordTypes:      cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:      cfg.ExcludeDNSRecordTypes,
+		MinEventSyncInterval:    cfg.MinEventSyncInterval,
+		ReconcileBackoffInitial: cfg.ReconcileBackoffInitial,
+		ReconcileBackoffMax:     cfg.ReconcileBackoffMax,
+		ReconcileBackoffJitter:  cfg.ReconcileBackoffJitter,
+		ReconcileRetryBudget:    cfg.ReconcileRetryBudget,
+		SoftErrorRetryInitial:   cfg.SoftErrorRetryInitial,
+		TXTOwnerOld:             cfg.TXTOwnerOld,
+		EventEmitter:            eventEmitter,
+		EventObject:             podObjectReference(),
+		ZoneLister:              zoneLister,
+		ZoneApplyConcurrency:    cfg.ZoneApplyConcurrency,
+		Auditor:                 auditor,
+		DryRun:                  cfg.DryRun,
 	}, nil
 }
 
+// buildControllerGroup builds a controller for every provider of the multi-provider configuration,
+// each with its own registry and receiving the endpoints routed to it from the shared source.
+func buildControllerGroup(
+	ctx context.Context,
+	cfg *externaldns.Config,
+	sCfg *source.Config,
+	src source.Source,
+) (*Group, error) {
+	specs, err := providerfactory.LoadProviderSpecs(cfg.ProvidersConfig)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := providerfactory.SelectAll(ctx, cfg, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &Group{}
+	filters := make([]endpoint.DomainFilterInterface, 0, len(instances))
+	for _, inst := range instances {
+		ctrl, err := buildController(ctx, inst.Config, sCfg, newRoutedSource(src, filters), inst.Provider, inst.DomainFilter)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", inst.Name, err)
+		}
+		log.Infof("Routing %v to provider %s (%s)", inst.Config.DomainFilter, inst.Name, inst.Config.Provider)
+		group.Add(inst.Name, ctrl)
+		filters = append(filters, inst.DomainFilter)
+	}
+	return group, nil
+}
+
+// podObjectReference returns a reference to the Pod external-dns runs in, which controller
+// events that are not about a single resource are reported on. The hostname of a Pod is its name.
+func podObjectReference() *events.ObjectReference {
+	name, err := os.Hostname()
+	if err != nil {
+		log.Warnf("Could not determine the Pod name for controller events: %v", err)
+		return nil
+	}
+	return events.NewObjectReferenceFromParts("Pod", "v1", kubeclient.InClusterNamespace(""), name, "", "controller")
+}
+
 // This function configures the logger format and level based on the provided configuration.
 func configureLogger(cfg *externaldns.Config) error {
 	if cfg.LogFormat == "json" {
@@ -227,11 +429,8 @@ func contextWithSigtermHandler(ctx context.Context) (context.Context, func()) {
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
 // The /metrics endpoint serves Prometheus metrics.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+func serveMetrics(address string, le *leaderElection) {
+	http.HandleFunc("/healthz", healthzHandler(le))
 
 	log.Debugf("serving 'healthz' on '%s/healthz'", address)
 	log.Debugf("serving 'metrics' on '%s/metrics'", address)
@@ -241,3 +440,19 @@ func serveMetrics(address string) {
 
 	log.Fatal(http.ListenAndServe(address, nil))
 }
+
+// healthzHandler returns a 200 OK status. When leader election is enabled, the body reports
+// whether this replica is the leader or on standby; standby replicas are healthy too.
+func healthzHandler(le *leaderElection) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case le == nil:
+			_, _ = w.Write([]byte("OK"))
+		case le.IsLeader():
+			_, _ = w.Write([]byte("OK: leader"))
+		default:
+			_, _ = w.Write([]byte("OK: standby"))
+		}
+	}
+}
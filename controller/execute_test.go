@@ -20,10 +20,12 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -35,6 +37,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
 	provider "sigs.k8s.io/external-dns/provider/factory"
 	"sigs.k8s.io/external-dns/source"
 	"sigs.k8s.io/external-dns/source/wrappers"
@@ -324,6 +327,53 @@ func TestControllerRunCancelContextStopsLoop(t *testing.T) {
 	}
 }
 
+// runOncePlanOutput writes the calculated plan to the given file.
+func TestRunOncePlanOutputWritesFile(t *testing.T) {
+	cfg := &externaldns.Config{
+		Sources:    []string{"fake"},
+		Provider:   "inmemory",
+		LogLevel:   "error",
+		LogFormat:  "text",
+		Policy:     "sync",
+		Registry:   "txt",
+		TXTOwnerID: "test-owner",
+	}
+	sCfg, err := source.NewSourceConfig(cfg)
+	require.NoError(t, err)
+	src, err := wrappers.Build(t.Context(), sCfg)
+	require.NoError(t, err)
+	domainFilter := endpoint.NewDomainFilter(nil)
+	p, err := provider.Select(t.Context(), cfg, domainFilter)
+	require.NoError(t, err)
+	ctrl, err := buildController(t.Context(), cfg, sCfg, src, p, domainFilter)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, runOncePlanOutput(t.Context(), ctrl, path, plan.PreviewFormatJSON))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var preview plan.Preview
+	require.NoError(t, json.Unmarshal(data, &preview))
+	assert.Equal(t, "test-owner", preview.OwnerID)
+	assert.NotNil(t, preview.Changes)
+
+	require.Error(t, runOncePlanOutput(t.Context(), ctrl, filepath.Join(t.TempDir(), "missing", "plan.json"), plan.PreviewFormatJSON))
+}
+
+func TestReadPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"changes":{"delete":[{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}]}}`), 0o600))
+
+	changes, err := readPlan(path)
+	require.NoError(t, err)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "foo.example.com", changes.Delete[0].DNSName)
+
+	_, err = readPlan(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
 // TestContextWithSigtermHandlerHelper is a helper process that sets up the SIGTERM handler
 // and waits for it to be triggered.
 func TestContextWithSigtermHandlerHelper(t *testing.T) {
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// Group runs a Controller per provider of a multi-provider configuration.
+// All controllers share a Source, so that informers are only started once,
+// and plan and apply their changes independently.
+type Group struct {
+	names       []string
+	controllers []*Controller
+}
+
+// Add adds a controller for the named provider. Its Source must only return the endpoints routed to it,
+// see newRoutedSource.
+func (g *Group) Add(name string, ctrl *Controller) {
+	g.names = append(g.names, name)
+	g.controllers = append(g.controllers, ctrl)
+}
+
+// RunOnce runs a single loop of every controller. A failing controller does not prevent the others from running.
+func (g *Group) RunOnce(ctx context.Context) error {
+	var errs []error
+	for i, ctrl := range g.controllers {
+		if err := ctrl.RunOnce(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", g.names[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ScheduleRunOnce schedules a run of every controller.
+func (g *Group) ScheduleRunOnce(now time.Time) {
+	for _, ctrl := range g.controllers {
+		ctrl.ScheduleRunOnce(now)
+	}
+}
+
+// Run runs every controller until the context is canceled or a controller fails,
+// which stops all controllers.
+func (g *Group) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, ctrl := range g.controllers {
+		eg.Go(func() error {
+			if err := ctrl.Run(ctx); err != nil {
+				return fmt.Errorf("provider %s: %w", g.names[i], err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// routedSource returns the endpoints of a shared Source that are routed to one provider.
+// An endpoint is routed to the first provider whose domain filter matches its DNS name,
+// so it is left out when a preceding provider matches.
+type routedSource struct {
+	source.Source
+	preceding []endpoint.DomainFilterInterface
+}
+
+// newRoutedSource returns a Source leaving out the endpoints matched by any of the preceding
+// domain filters. Event handlers are not added to the shared source, see Group.ScheduleRunOnce.
+func newRoutedSource(src source.Source, preceding []endpoint.DomainFilterInterface) source.Source {
+	return &routedSource{Source: src, preceding: preceding}
+}
+
+func (s *routedSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.Source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routed := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !s.routedElsewhere(ep.DNSName) {
+			routed = append(routed, ep)
+		}
+	}
+	return routed, nil
+}
+
+// AddEventHandler is a no-op, as the handlers are added once to the shared source.
+func (s *routedSource) AddEventHandler(_ context.Context, _ func()) {}
+
+func (s *routedSource) routedElsewhere(dnsName string) bool {
+	for _, filter := range s.preceding {
+		if filter.Match(dnsName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+	"sigs.k8s.io/external-dns/source"
+)
+
+func TestRoutedSource(t *testing.T) {
+	public := endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	internal := endpoint.NewEndpoint("db.internal.example.com", endpoint.RecordTypeA, "10.0.0.1")
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{public, internal}, nil)
+
+	internalFilter := endpoint.NewDomainFilter([]string{"internal.example.com"})
+
+	endpoints, err := newRoutedSource(src, nil).Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{public, internal}, endpoints)
+
+	endpoints, err = newRoutedSource(src, []endpoint.DomainFilterInterface{internalFilter}).Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{public}, endpoints)
+}
+
+func newGroupTestController(t *testing.T, src source.Source, domains []string, preceding []endpoint.DomainFilterInterface) (*Controller, *filteredMockProvider) {
+	t.Helper()
+	cfg := getTestConfig()
+	filter := endpoint.NewDomainFilter(domains)
+	p := &filteredMockProvider{domainFilter: filter}
+	r, err := registryfactory.Select(cfg, p)
+	require.NoError(t, err)
+	return &Controller{
+		Source:             newRoutedSource(src, preceding),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: cfg.ManagedDNSRecordTypes,
+		DomainFilter:       filter,
+		Interval:           time.Minute,
+	}, p
+}
+
+// TestGroupRunOnce tests that every endpoint is applied by the first provider whose domain filter matches.
+func TestGroupRunOnce(t *testing.T) {
+	public := endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	internal := endpoint.NewEndpoint("db.internal.example.com", endpoint.RecordTypeA, "10.0.0.1")
+	unrouted := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{public, internal, unrouted}, nil)
+
+	internalCtrl, internalProvider := newGroupTestController(t, src, []string{"internal.example.com"}, nil)
+	publicCtrl, publicProvider := newGroupTestController(t, src, []string{"example.com"}, []endpoint.DomainFilterInterface{internalCtrl.DomainFilter})
+
+	group := &Group{}
+	group.Add("internal", internalCtrl)
+	group.Add("public", publicCtrl)
+	require.NoError(t, group.RunOnce(t.Context()))
+
+	require.Len(t, internalProvider.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{internal}, internalProvider.ApplyChangesCalls[0].Create)
+	require.Len(t, publicProvider.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{public}, publicProvider.ApplyChangesCalls[0].Create)
+}
+
+func TestGroupRunOnceContinuesAfterFailure(t *testing.T) {
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	failing := &Controller{
+		Source:   newRoutedSource(src, nil),
+		Registry: &failingRegistry{},
+		Policy:   &plan.SyncPolicy{},
+	}
+	ctrl, p := newGroupTestController(t, src, []string{"example.com"}, nil)
+
+	group := &Group{}
+	group.Add("failing", failing)
+	group.Add("public", ctrl)
+	require.EqualError(t, group.RunOnce(t.Context()), "provider failing: provider unavailable")
+	assert.Len(t, p.ApplyChangesCalls, 1)
+}
+
+func TestGroupScheduleRunOnce(t *testing.T) {
+	first := &Controller{Interval: time.Minute, MinEventSyncInterval: 5 * time.Second}
+	second := &Controller{Interval: time.Minute, MinEventSyncInterval: 5 * time.Second}
+	group := &Group{}
+	group.Add("first", first)
+	group.Add("second", second)
+
+	now := time.Now()
+	group.ScheduleRunOnce(now)
+	assert.True(t, first.ShouldRunOnce(now.Add(5*time.Second)))
+	assert.True(t, second.ShouldRunOnce(now.Add(5*time.Second)))
+}
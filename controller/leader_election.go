@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	kubeclient "sigs.k8s.io/external-dns/pkg/client"
+)
+
+// leaderElection wraps the client-go leader elector backed by a coordination.k8s.io Lease.
+// Replicas that do not hold the lease stay on standby with their informers running,
+// and only the leader runs the reconciliation loop.
+type leaderElection struct {
+	client        kubernetes.Interface
+	namespace     string
+	name          string
+	identity      string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+
+	leading atomic.Bool
+}
+
+// newLeaderElection creates a leaderElection from the given configuration.
+func newLeaderElection(cfg *externaldns.Config, client kubernetes.Interface) (*leaderElection, error) {
+	if client == nil {
+		return nil, errors.New("leader election requires a Kubernetes client")
+	}
+	identity, err := leaderElectionIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return &leaderElection{
+		client:        client,
+		namespace:     kubeclient.InClusterNamespace(cfg.LeaderElectionNamespace),
+		name:          cfg.LeaderElectionLeaseName,
+		identity:      identity,
+		leaseDuration: cfg.LeaderElectionLeaseDuration,
+		renewDeadline: cfg.LeaderElectionRenewDeadline,
+		retryPeriod:   cfg.LeaderElectionRetryPeriod,
+	}, nil
+}
+
+// IsLeader reports whether this replica currently holds the lease.
+func (le *leaderElection) IsLeader() bool {
+	return le != nil && le.leading.Load()
+}
+
+// Run blocks until ctx is canceled or fn returns, calling fn once this replica acquires the lease.
+// The context passed to fn is canceled when the lease is lost. An error is returned when
+// fn fails or when the lease is lost while ctx is still active, so that the process can
+// restart rather than risk two replicas applying changes concurrently.
+func (le *leaderElection) Run(ctx context.Context, fn func(context.Context) error) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// client-go calls OnStartedLeading in a goroutine, which may only run once elector.Run has
+	// returned: fn is started under mu unless Run has stopped, and Run waits for it to return.
+	var (
+		mu      sync.Mutex
+		stopped bool
+		started bool
+		running sync.WaitGroup
+		fnErr   error
+	)
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      le.name,
+				Namespace: le.namespace,
+			},
+			Client:     le.client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: le.identity},
+		},
+		Name:            le.name,
+		LeaseDuration:   le.leaseDuration,
+		RenewDeadline:   le.renewDeadline,
+		RetryPeriod:     le.retryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				mu.Lock()
+				if stopped {
+					mu.Unlock()
+					return
+				}
+				started = true
+				running.Add(1)
+				mu.Unlock()
+				defer running.Done()
+
+				le.setLeading(true)
+				log.Infof("Acquired leader lease %s/%s as %s", le.namespace, le.name, le.identity)
+				err := fn(leaderCtx)
+				if err == nil && leaderCtx.Err() != nil && ctx.Err() == nil {
+					err = fmt.Errorf("lost leader lease %s/%s", le.namespace, le.name)
+				}
+				fnErr = err
+				cancel()
+			},
+			OnStoppedLeading: func() {
+				if le.leading.Load() {
+					log.Infof("Released leader lease %s/%s", le.namespace, le.name)
+				}
+				le.setLeading(false)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != le.identity {
+					log.Infof("Leader lease %s/%s is held by %s, waiting on standby", le.namespace, le.name, identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating leader elector: %w", err)
+	}
+
+	log.Infof("Starting leader election on lease %s/%s as %s", le.namespace, le.name, le.identity)
+	elector.Run(runCtx)
+
+	mu.Lock()
+	stopped = true
+	mu.Unlock()
+	running.Wait()
+
+	switch {
+	case started:
+		return fnErr
+	case ctx.Err() == nil:
+		// the lease was acquired, and lost before fn could start
+		return fmt.Errorf("lost leader lease %s/%s", le.namespace, le.name)
+	default:
+		return nil
+	}
+}
+
+func (le *leaderElection) setLeading(leading bool) {
+	le.leading.Store(leading)
+	if leading {
+		leaderElectionIsLeader.Gauge.Set(1)
+	} else {
+		leaderElectionIsLeader.Gauge.Set(0)
+	}
+}
+
+// leaderElectionIdentity returns a unique identity for this replica based on its hostname.
+func leaderElectionIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("determining leader election identity: %w", err)
+	}
+	return hostname + "_" + string(uuid.NewUUID()), nil
+}
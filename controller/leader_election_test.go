@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func newTestLeaderElection(t *testing.T, client *fake.Clientset) *leaderElection {
+	t.Helper()
+	le, err := newLeaderElection(&externaldns.Config{
+		LeaderElectionNamespace:     "external-dns",
+		LeaderElectionLeaseName:     "external-dns-test",
+		LeaderElectionLeaseDuration: 2 * time.Second,
+		LeaderElectionRenewDeadline: time.Second,
+		LeaderElectionRetryPeriod:   100 * time.Millisecond,
+	}, client)
+	require.NoError(t, err)
+	return le
+}
+
+func TestNewLeaderElectionRequiresClient(t *testing.T) {
+	_, err := newLeaderElection(&externaldns.Config{}, nil)
+	require.Error(t, err)
+}
+
+func TestLeaderElectionRunsWhileLeading(t *testing.T) {
+	client := fake.NewClientset()
+	le := newTestLeaderElection(t, client)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- le.Run(ctx, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("replica did not acquire the lease")
+	}
+	assert.True(t, le.IsLeader())
+
+	lease, err := client.CoordinationV1().Leases("external-dns").Get(t.Context(), "external-dns-test", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, lease.Spec.HolderIdentity)
+	assert.Equal(t, le.identity, *lease.Spec.HolderIdentity)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("leader election did not stop after context cancellation")
+	}
+	assert.False(t, le.IsLeader())
+}
+
+func TestLeaderElectionReturnsRunError(t *testing.T) {
+	le := newTestLeaderElection(t, fake.NewClientset())
+
+	err := le.Run(t.Context(), func(_ context.Context) error {
+		return errors.New("run failed")
+	})
+	require.EqualError(t, err, "run failed")
+	assert.False(t, le.IsLeader())
+}
+
+func TestLeaderElectionReturnsWhenRunCompletes(t *testing.T) {
+	le := newTestLeaderElection(t, fake.NewClientset())
+
+	var called bool
+	err := le.Run(t.Context(), func(_ context.Context) error {
+		called = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+// When the lease is lost, Run waits for fn to return before returning the error.
+func TestLeaderElectionWaitsForRunWhenLeaseLost(t *testing.T) {
+	client := fake.NewClientset()
+	var failing atomic.Bool
+	client.PrependReactor("update", "leases", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		if failing.Load() {
+			return true, nil, errors.New("api server unavailable")
+		}
+		return false, nil, nil
+	})
+	le := newTestLeaderElection(t, client)
+
+	var returned atomic.Bool
+	err := le.Run(t.Context(), func(ctx context.Context) error {
+		failing.Store(true)
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		returned.Store(true)
+		return nil
+	})
+	require.EqualError(t, err, "lost leader lease external-dns/external-dns-test")
+	assert.True(t, returned.Load(), "Run returned before fn")
+	assert.False(t, le.IsLeader())
+}
+
+func TestLeaderElectionStandbyWhileLeaseHeld(t *testing.T) {
+	now := metav1.NewMicroTime(time.Now())
+	client := fake.NewClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-dns-test", Namespace: "external-dns"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       new("other-replica"),
+			LeaseDurationSeconds: new(int32(60)),
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		},
+	})
+	le := newTestLeaderElection(t, client)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+	defer cancel()
+
+	err := le.Run(ctx, func(_ context.Context) error {
+		t.Error("standby replica must not run the controller")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, le.IsLeader())
+}
+
+func TestHealthzHandler(t *testing.T) {
+	leader := &leaderElection{}
+	leader.leading.Store(true)
+
+	for _, tt := range []struct {
+		name string
+		le   *leaderElection
+		want string
+	}{
+		{name: "leader election disabled", le: nil, want: "OK"},
+		{name: "leader", le: leader, want: "OK: leader"},
+		{name: "standby", le: &leaderElection{}, want: "OK: standby"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			healthzHandler(tt.le)(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, rec.Body.String())
+		})
+	}
+}
@@ -116,6 +116,15 @@ var (
 		[]string{"record_type"},
 	)
 
+	auditRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "audit",
+			Name:      "records",
+			Help:      "Number of records in the last audit partitioned by zone and status (vector).",
+		},
+		[]string{"zone", "status"},
+	)
+
 	consecutiveSoftErrors = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Subsystem: "controller",
@@ -123,6 +132,28 @@ var (
 			Help:      "Number of consecutive soft errors in reconciliation loop.",
 		},
 	)
+	consecutiveFailedRuns = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "consecutive_failed_runs",
+			Help:      "Number of consecutive failed reconciliations retried with backoff.",
+		},
+	)
+	reconcileBackoffSeconds = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "reconcile_backoff_seconds",
+			Help:      "Delay before the next reconciliation after a failed run, or 0 when not backing off.",
+		},
+	)
+
+	leaderElectionIsLeader = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "leader",
+			Help:      "Whether this instance holds the leader election lease (1) or is on standby (0).",
+		},
+	)
 )
 
 func init() {
@@ -140,7 +171,11 @@ func init() {
 	metrics.RegisterMetric.MustRegister(sourceRecords)
 	metrics.RegisterMetric.MustRegister(verifiedRecords)
 
+	metrics.RegisterMetric.MustRegister(auditRecords)
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
+	metrics.RegisterMetric.MustRegister(consecutiveFailedRuns)
+	metrics.RegisterMetric.MustRegister(reconcileBackoffSeconds)
+	metrics.RegisterMetric.MustRegister(leaderElectionIsLeader)
 }
 
 type dnsKey struct {
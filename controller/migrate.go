@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+// MigrationStatus is the outcome of the migration of the ownership of a record.
+type MigrationStatus string
+
+const (
+	// MigrationMigrated is a record whose ownership was written to the target registry,
+	// or would be in dry-run mode.
+	MigrationMigrated MigrationStatus = "migrated"
+	// MigrationConflict is a record owned by someone else in the target registry, which is left untouched.
+	MigrationConflict MigrationStatus = "conflict"
+)
+
+// MigrationReport is the result of a registry migration, written as JSON.
+type MigrationReport struct {
+	From    string                  `json:"from"`
+	To      string                  `json:"to"`
+	OwnerID string                  `json:"ownerId"`
+	DryRun  bool                    `json:"dryRun"`
+	Summary map[MigrationStatus]int `json:"summary"`
+	Records []MigratedRecord        `json:"records"`
+	// SourceRecordsDeleted is the number of records whose ownership was deleted from the source registry
+	SourceRecordsDeleted int `json:"sourceRecordsDeleted"`
+}
+
+// MigratedRecord is the outcome of the migration of the ownership of a record.
+type MigratedRecord struct {
+	DNSName       string          `json:"dnsName"`
+	RecordType    string          `json:"recordType"`
+	SetIdentifier string          `json:"setIdentifier,omitempty"`
+	Resource      string          `json:"resource,omitempty"`
+	Status        MigrationStatus `json:"status"`
+	// Owner is the owner of the record in the target registry, for conflicts
+	Owner string `json:"owner,omitempty"`
+}
+
+// registryMigration copies the ownership of the records owned by ownerID from one registry to another.
+type registryMigration struct {
+	from, to     string
+	ownerID      string
+	dryRun       bool
+	deleteSource bool
+	provider     provider.Provider
+	// newRegistry creates the named registry on top of the given provider
+	newRegistry func(name string, p provider.Provider) (registry.Registry, error)
+}
+
+// migrateRegistry migrates the ownership of the records owned by --txt-owner-id from --registry
+// to --migrate-registry-to, and writes the report to w.
+func migrateRegistry(ctx context.Context, cfg *externaldns.Config, p provider.Provider, w io.Writer) error {
+	m := &registryMigration{
+		from:         cfg.Registry,
+		to:           cfg.MigrateRegistryTo,
+		ownerID:      cfg.TXTOwnerID,
+		dryRun:       cfg.DryRun,
+		deleteSource: cfg.MigrateRegistryDeleteSource,
+		provider:     p,
+		newRegistry: func(name string, p provider.Provider) (registry.Registry, error) {
+			c := *cfg
+			c.Registry = name
+			return registryfactory.Select(&c, p)
+		},
+	}
+	report, err := m.run(ctx)
+	if report != nil {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			return errors.Join(err, fmt.Errorf("writing migration report: %w", encodeErr))
+		}
+	}
+	return err
+}
+
+// run migrates the ownership and returns the report, which is also returned when the verification fails.
+//
+// The records themselves already exist, so the registries write the ownership through a provider
+// dropping the changes of the migrated records, see ownershipOnlyProvider. The ownership is then
+// read back with a new target registry, and deleted from the source registry only when it matches.
+func (m *registryMigration) run(ctx context.Context) (*MigrationReport, error) {
+	p := &ownershipOnlyProvider{Provider: m.provider}
+
+	from, err := m.newRegistry(m.from, p)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s registry: %w", m.from, err)
+	}
+	to, err := m.newRegistry(m.to, p)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s registry: %w", m.to, err)
+	}
+
+	sourceRecords, err := from.Records(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s registry: %w", m.from, err)
+	}
+	targetRecords, err := to.Records(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s registry: %w", m.to, err)
+	}
+	targetOwners := map[endpoint.EndpointKey]string{}
+	for _, ep := range targetRecords {
+		targetOwners[ep.Key()] = ep.Labels[endpoint.OwnerLabelKey]
+	}
+
+	report := &MigrationReport{
+		From:    m.from,
+		To:      m.to,
+		OwnerID: m.ownerID,
+		DryRun:  m.dryRun,
+		Summary: map[MigrationStatus]int{},
+		Records: []MigratedRecord{},
+	}
+	var migrated []*endpoint.Endpoint
+	for _, ep := range sourceRecords {
+		if !ep.IsOwnedBy(m.ownerID) {
+			continue
+		}
+		record := MigratedRecord{
+			DNSName:       ep.DNSName,
+			RecordType:    ep.RecordType,
+			SetIdentifier: ep.SetIdentifier,
+			Resource:      ep.Labels[endpoint.ResourceLabelKey],
+			Status:        MigrationMigrated,
+		}
+		if owner := targetOwners[ep.Key()]; owner != "" && owner != m.ownerID {
+			record.Status = MigrationConflict
+			record.Owner = owner
+			log.Warnf("Not migrating %s %s: owned by %q in the %s registry", ep.RecordType, ep.DNSName, owner, m.to)
+		} else {
+			migrated = append(migrated, ep)
+		}
+		report.Records = append(report.Records, record)
+		report.Summary[record.Status]++
+	}
+	slices.SortFunc(report.Records, func(a, b MigratedRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+
+	if m.dryRun || len(migrated) == 0 {
+		log.Infof("Found %d records to migrate from the %s to the %s registry, %d conflicting",
+			len(migrated), m.from, m.to, report.Summary[MigrationConflict])
+		return report, nil
+	}
+
+	p.records = sets.New[endpoint.EndpointKey]()
+	for _, ep := range migrated {
+		p.records.Insert(ep.Key())
+	}
+	if err := to.ApplyChanges(ctx, &plan.Changes{Create: deepCopyEndpoints(migrated)}); err != nil {
+		return report, fmt.Errorf("writing ownership to the %s registry: %w", m.to, err)
+	}
+	log.Infof("Migrated the ownership of %d records from the %s to the %s registry", len(migrated), m.from, m.to)
+
+	if err := m.verify(ctx, migrated); err != nil {
+		return report, err
+	}
+
+	if m.deleteSource {
+		if err := from.ApplyChanges(ctx, &plan.Changes{Delete: deepCopyEndpoints(migrated)}); err != nil {
+			return report, fmt.Errorf("deleting ownership from the %s registry: %w", m.from, err)
+		}
+		report.SourceRecordsDeleted = len(migrated)
+		log.Infof("Deleted the ownership of %d records from the %s registry", len(migrated), m.from)
+	}
+	return report, nil
+}
+
+// verify reads the target registry back and checks that the owner and resource of the migrated records are preserved.
+func (m *registryMigration) verify(ctx context.Context, migrated []*endpoint.Endpoint) error {
+	to, err := m.newRegistry(m.to, m.provider)
+	if err != nil {
+		return fmt.Errorf("creating %s registry: %w", m.to, err)
+	}
+	records, err := to.Records(ctx)
+	if err != nil {
+		return fmt.Errorf("reading %s registry: %w", m.to, err)
+	}
+	labels := map[endpoint.EndpointKey]endpoint.Labels{}
+	for _, ep := range records {
+		labels[ep.Key()] = ep.Labels
+	}
+
+	var mismatches []string
+	for _, ep := range migrated {
+		actual := labels[ep.Key()]
+		for _, key := range []string{endpoint.OwnerLabelKey, endpoint.ResourceLabelKey} {
+			if actual[key] != ep.Labels[key] {
+				mismatches = append(mismatches, fmt.Sprintf("%s %s: %s is %q instead of %q", ep.RecordType, ep.DNSName, key, actual[key], ep.Labels[key]))
+			}
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("verifying the %s registry: %s", m.to, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+func deepCopyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	copied := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		copied = append(copied, ep.DeepCopy())
+	}
+	return copied
+}
+
+// ownershipOnlyProvider applies the changes of a registry except those of the migrated records,
+// which already exist, so that only ownership records, such as TXT records, are written.
+type ownershipOnlyProvider struct {
+	provider.Provider
+	records sets.Set[endpoint.EndpointKey]
+}
+
+func (p *ownershipOnlyProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	filtered := &plan.Changes{
+		Create:    p.filter(changes.Create),
+		UpdateOld: p.filter(changes.UpdateOld),
+		UpdateNew: p.filter(changes.UpdateNew),
+		Delete:    p.filter(changes.Delete),
+	}
+	if !filtered.HasChanges() {
+		return nil
+	}
+	return p.Provider.ApplyChanges(ctx, filtered)
+}
+
+func (p *ownershipOnlyProvider) filter(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var filtered []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if !p.records.Has(ep.Key()) {
+			filtered = append(filtered, ep)
+		}
+	}
+	return filtered
+}
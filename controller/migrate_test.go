@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/registry/txt"
+)
+
+// labelRegistry keeps the labels of the records in a map shared by all its instances.
+type labelRegistry struct {
+	provider provider.Provider
+	labels   map[endpoint.EndpointKey]endpoint.Labels
+	// dropLabel is not stored, to fail the verification
+	dropLabel string
+}
+
+func (r *labelRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := r.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range records {
+		if labels, ok := r.labels[ep.Key()]; ok {
+			ep.Labels = maps.Clone(labels)
+		}
+	}
+	return records, nil
+}
+
+func (r *labelRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for _, ep := range changes.Create {
+		labels := maps.Clone(ep.Labels)
+		delete(labels, r.dropLabel)
+		r.labels[ep.Key()] = labels
+	}
+	return r.provider.ApplyChanges(ctx, changes)
+}
+
+func (r *labelRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return endpoints, nil
+}
+
+func (r *labelRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return r.provider.GetDomainFilter()
+}
+
+func (r *labelRegistry) OwnerID() string {
+	return "owner"
+}
+
+// newTestMigration returns a migration from the TXT registry, owning a.example.org and b.example.org,
+// to a labelRegistry in which b.example.org is owned by someone else.
+func newTestMigration(t *testing.T) (*registryMigration, *inmemory.InMemoryProvider, map[endpoint.EndpointKey]endpoint.Labels) {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+
+	cfg := externaldns.NewConfig()
+	cfg.TXTOwnerID = "owner"
+	newTXTRegistry := func(p provider.Provider) (registry.Registry, error) {
+		return txt.New(cfg, p)
+	}
+	r, err := newTXTRegistry(p)
+	require.NoError(t, err)
+	_, err = r.Records(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, "service/default/a"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "a.example.org").
+				WithLabel(endpoint.ResourceLabelKey, "ingress/default/b"),
+		},
+	}))
+
+	labels := map[endpoint.EndpointKey]endpoint.Labels{
+		{DNSName: "b.example.org", RecordType: endpoint.RecordTypeCNAME}: {endpoint.OwnerLabelKey: "other"},
+	}
+	m := &registryMigration{
+		from:     externaldns.RegistryTXT,
+		to:       "labels",
+		ownerID:  "owner",
+		provider: p,
+		newRegistry: func(name string, p provider.Provider) (registry.Registry, error) {
+			if name == externaldns.RegistryTXT {
+				return newTXTRegistry(p)
+			}
+			return &labelRegistry{provider: p, labels: labels}, nil
+		},
+	}
+	return m, p, labels
+}
+
+func countTXTRecords(t *testing.T, p provider.Provider) int {
+	t.Helper()
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	count := 0
+	for _, ep := range records {
+		if ep.RecordType == endpoint.RecordTypeTXT {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRegistryMigrationDryRun(t *testing.T) {
+	m, p, labels := newTestMigration(t)
+	m.dryRun = true
+	m.deleteSource = true
+
+	report, err := m.run(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []MigratedRecord{
+		{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA, Resource: "service/default/a", Status: MigrationMigrated},
+		{DNSName: "b.example.org", RecordType: endpoint.RecordTypeCNAME, Resource: "ingress/default/b", Status: MigrationConflict, Owner: "other"},
+	}, report.Records)
+	assert.Equal(t, map[MigrationStatus]int{MigrationMigrated: 1, MigrationConflict: 1}, report.Summary)
+	assert.True(t, report.DryRun)
+	assert.Zero(t, report.SourceRecordsDeleted)
+	assert.Len(t, labels, 1)
+	assert.Equal(t, 2, countTXTRecords(t, p))
+}
+
+func TestRegistryMigrationDeletesSource(t *testing.T) {
+	m, p, labels := newTestMigration(t)
+	m.deleteSource = true
+
+	report, err := m.run(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[MigrationStatus]int{MigrationMigrated: 1, MigrationConflict: 1}, report.Summary)
+	assert.Equal(t, 1, report.SourceRecordsDeleted)
+	assert.Equal(t, endpoint.Labels{
+		endpoint.OwnerLabelKey:    "owner",
+		endpoint.ResourceLabelKey: "service/default/a",
+	}, labels[endpoint.EndpointKey{DNSName: "a.example.org", RecordType: endpoint.RecordTypeA}])
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "other"},
+		labels[endpoint.EndpointKey{DNSName: "b.example.org", RecordType: endpoint.RecordTypeCNAME}])
+
+	// the records are kept, only the TXT record of the conflicting record is
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, 1, countTXTRecords(t, p))
+}
+
+func TestRegistryMigrationKeepsSourceWithoutDeleteSource(t *testing.T) {
+	m, p, _ := newTestMigration(t)
+
+	report, err := m.run(t.Context())
+	require.NoError(t, err)
+
+	assert.Zero(t, report.SourceRecordsDeleted)
+	assert.Equal(t, 2, countTXTRecords(t, p))
+}
+
+func TestRegistryMigrationVerificationFailure(t *testing.T) {
+	m, p, labels := newTestMigration(t)
+	m.deleteSource = true
+	newRegistry := m.newRegistry
+	m.newRegistry = func(name string, p provider.Provider) (registry.Registry, error) {
+		if name == externaldns.RegistryTXT {
+			return newRegistry(name, p)
+		}
+		return &labelRegistry{provider: p, labels: labels, dropLabel: endpoint.ResourceLabelKey}, nil
+	}
+
+	report, err := m.run(t.Context())
+	require.EqualError(t, err, `verifying the labels registry: A a.example.org: resource is "" instead of "service/default/a"`)
+	require.NotNil(t, report)
+	assert.Zero(t, report.SourceRecordsDeleted)
+	assert.Equal(t, 2, countTXTRecords(t, p))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// zoneResult is the outcome of applying the changes of a single zone.
+type zoneResult struct {
+	zone    string
+	changes *plan.Changes
+	err     error
+}
+
+// applyChangesPerZone applies the changes of every zone separately, with up to ZoneApplyConcurrency
+// zones at once. A failing zone does not prevent the others from being applied: their changes get
+// RecordReady events, while only the changes of failed zones get RecordError events and are retried
+// on the next run. The error is soft when at least one zone was applied.
+func (c *Controller) applyChangesPerZone(ctx context.Context, changes *plan.Changes) error {
+	zones, err := c.ZoneLister.ZoneIDNames(ctx)
+	if err != nil {
+		return fmt.Errorf("listing zones: %w", err)
+	}
+	perZone := splitChangesByZone(changes, zones)
+
+	results := make([]zoneResult, 0, len(perZone))
+	for _, zone := range slices.Sorted(maps.Keys(perZone)) {
+		results = append(results, zoneResult{zone: zone, changes: perZone[zone]})
+	}
+
+	var g errgroup.Group
+	g.SetLimit(c.ZoneApplyConcurrency)
+	for i := range results {
+		g.Go(func() error {
+			results[i].err = c.Registry.ApplyChanges(ctx, results[i].changes)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			log.Errorf("Failed to apply changes for zone %s: %v", zoneDisplayName(r.zone), r.err)
+			errs = append(errs, fmt.Errorf("zone %s: %w", zoneDisplayName(r.zone), r.err))
+			emitChangeEvent(c.EventEmitter, r.changes, events.RecordError)
+			continue
+		}
+		log.Debugf("Applied changes for zone %s", zoneDisplayName(r.zone))
+		emitChangeEvent(c.EventEmitter, r.changes, events.RecordReady)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	registryErrorsTotal.Counter.Inc()
+	deprecatedRegistryErrors.Counter.Inc()
+	err = fmt.Errorf("failed to apply changes for %d of %d zones: %w", len(errs), len(results), errors.Join(errs...))
+	if len(errs) < len(results) {
+		return provider.NewSoftError(err)
+	}
+	return err
+}
+
+// splitChangesByZone groups changes by the name of the zone their DNS name belongs to.
+// Changes outside of every zone are grouped under the empty name. The order of the changes
+// is kept within a zone, so that UpdateOld and UpdateNew stay paired by index.
+func splitChangesByZone(changes *plan.Changes, zones provider.ZoneIDName) map[string]*plan.Changes {
+	perZone := map[string]*plan.Changes{}
+	zoneChanges := func(ep *endpoint.Endpoint) *plan.Changes {
+		_, zone := zones.FindZone(strings.TrimSuffix(ep.DNSName, "."))
+		if _, ok := perZone[zone]; !ok {
+			perZone[zone] = &plan.Changes{}
+		}
+		return perZone[zone]
+	}
+
+	for _, ep := range changes.Create {
+		zc := zoneChanges(ep)
+		zc.Create = append(zc.Create, ep)
+	}
+	for _, ep := range changes.UpdateOld {
+		zc := zoneChanges(ep)
+		zc.UpdateOld = append(zc.UpdateOld, ep)
+	}
+	for _, ep := range changes.UpdateNew {
+		zc := zoneChanges(ep)
+		zc.UpdateNew = append(zc.UpdateNew, ep)
+	}
+	for _, ep := range changes.Delete {
+		zc := zoneChanges(ep)
+		zc.Delete = append(zc.Delete, ep)
+	}
+	return perZone
+}
+
+func zoneDisplayName(zone string) string {
+	if zone == "" {
+		return "<none>"
+	}
+	return zone
+}
+
+// newZoneLister returns p when it can list its zones, and otherwise a ZoneLister treating
+// every domain as a zone.
+func newZoneLister(p provider.Provider, domains []string) provider.ZoneLister {
+	if cached, ok := p.(*provider.CachedProvider); ok {
+		p = cached.Provider
+	}
+	if zl, ok := p.(provider.ZoneLister); ok {
+		return zl
+	}
+	log.Info("Provider cannot list its zones, applying changes per domain filter instead")
+	return domainZones(domains)
+}
+
+// domainZones is a ZoneLister treating every domain as a zone.
+type domainZones []string
+
+// ZoneIDNames returns the domains as zones named and identified by the domain.
+func (d domainZones) ZoneIDNames(_ context.Context) (provider.ZoneIDName, error) {
+	zones := provider.ZoneIDName{}
+	for _, domain := range d {
+		domain = strings.TrimSuffix(strings.TrimPrefix(domain, "."), ".")
+		if domain != "" {
+			zones.Add(domain, domain)
+		}
+	}
+	return zones, nil
+}
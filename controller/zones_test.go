@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/pkg/events/fake"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	registryfactory "sigs.k8s.io/external-dns/registry/factory"
+)
+
+// zoneFailingProvider fails to apply changes touching a DNS name below failingZone.
+type zoneFailingProvider struct {
+	provider.BaseProvider
+	failingZone string
+
+	mutex             sync.Mutex
+	ApplyChangesCalls []*plan.Changes
+}
+
+func (p *zoneFailingProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (p *zoneFailingProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+	p.mutex.Lock()
+	p.ApplyChangesCalls = append(p.ApplyChangesCalls, changes)
+	p.mutex.Unlock()
+
+	for _, ep := range changes.Create {
+		if strings.HasSuffix(ep.DNSName, p.failingZone) {
+			return errors.New("zone unavailable")
+		}
+	}
+	return nil
+}
+
+func TestSplitChangesByZone(t *testing.T) {
+	zones := provider.ZoneIDName{}
+	zones.Add("zone-1", "example.com")
+	zones.Add("zone-2", "sub.example.com")
+	zones.Add("zone-3", "example.org")
+
+	apex := endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.1.1.1")
+	sub := endpoint.NewEndpoint("foo.sub.example.com.", endpoint.RecordTypeA, "2.2.2.2")
+	oldOrg := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "3.3.3.3")
+	newOrg := endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "4.4.4.4")
+	other := endpoint.NewEndpoint("foo.example.net", endpoint.RecordTypeA, "5.5.5.5")
+
+	perZone := splitChangesByZone(&plan.Changes{
+		Create:    []*endpoint.Endpoint{apex, sub},
+		UpdateOld: []*endpoint.Endpoint{oldOrg},
+		UpdateNew: []*endpoint.Endpoint{newOrg},
+		Delete:    []*endpoint.Endpoint{other},
+	}, zones)
+
+	assert.Equal(t, map[string]*plan.Changes{
+		"example.com":     {Create: []*endpoint.Endpoint{apex}},
+		"sub.example.com": {Create: []*endpoint.Endpoint{sub}},
+		"example.org":     {UpdateOld: []*endpoint.Endpoint{oldOrg}, UpdateNew: []*endpoint.Endpoint{newOrg}},
+		"":                {Delete: []*endpoint.Endpoint{other}},
+	}, perZone)
+}
+
+func TestNewZoneLister(t *testing.T) {
+	zl := newZoneLister(&filteredMockProvider{}, []string{"example.com.", ".example.org", ""})
+
+	zones, err := zl.ZoneIDNames(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"example.com": "example.com", "example.org": "example.org"}, map[string]string(zones))
+
+	lister := &zoneListingProvider{}
+	assert.Same(t, lister, newZoneLister(lister, nil))
+	assert.Same(t, lister, newZoneLister(provider.NewCachedProvider(lister, time.Minute), nil))
+}
+
+type zoneListingProvider struct {
+	filteredMockProvider
+}
+
+func (p *zoneListingProvider) ZoneIDNames(_ context.Context) (provider.ZoneIDName, error) {
+	return provider.ZoneIDName{}, nil
+}
+
+// TestRunOnceAppliesZonesIndependently tests that a failing zone does not prevent the other zones
+// from being applied, and that events report the outcome per zone.
+func TestRunOnceAppliesZonesIndependently(t *testing.T) {
+	refObj := events.NewObjectReferenceFromParts("Service", "v1", "default", "web", "", "service")
+	good := endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").WithRefObject(refObj)
+	bad := endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4").WithRefObject(refObj)
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{good, bad}, nil)
+	cfg := getTestConfig()
+	mockProvider := &zoneFailingProvider{failingZone: "example.org"}
+	emitter := fake.NewFakeEventEmitter()
+
+	r, err := registryfactory.Select(cfg, mockProvider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:               source,
+		Registry:             r,
+		Policy:               &plan.SyncPolicy{},
+		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
+		EventEmitter:         emitter,
+		ZoneLister:           domainZones{"example.com", "example.org"},
+		ZoneApplyConcurrency: 2,
+	}
+
+	err = ctrl.RunOnce(t.Context())
+	require.ErrorIs(t, err, provider.SoftError, "the error is soft as a zone was applied")
+	require.ErrorContains(t, err, "failed to apply changes for 1 of 2 zones: zone example.org: zone unavailable")
+
+	assert.Len(t, mockProvider.ApplyChangesCalls, 2)
+	emitter.AssertCalled(t, "Add", events.NewEventFromEndpoint(good, events.ActionCreate, events.RecordReady))
+	emitter.AssertCalled(t, "Add", events.NewEventFromEndpoint(bad, events.ActionCreate, events.RecordError))
+	emitter.AssertNumberOfCalls(t, "Add", 2)
+}
+
+func TestRunOnceAllZonesFailing(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	cfg := getTestConfig()
+
+	r, err := registryfactory.Select(cfg, &zoneFailingProvider{failingZone: "example.org"})
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:               source,
+		Registry:             r,
+		Policy:               &plan.SyncPolicy{},
+		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
+		ZoneLister:           domainZones{"example.org"},
+		ZoneApplyConcurrency: 1,
+	}
+
+	err = ctrl.RunOnce(t.Context())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, provider.SoftError)
+}
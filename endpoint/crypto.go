@@ -23,11 +23,30 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 )
 
-const standardGcmNonceSize = 12
+const (
+	standardGcmNonceSize = 12
+	aesKeySize           = 32
+)
+
+// ParseAESKey returns the 32 byte AES key given in plain text or base64-encoded format, or nil for an empty key.
+func ParseAESKey(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+	if len(key) == aesKeySize {
+		return []byte(key), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(decoded) != aesKeySize {
+		return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
+	}
+	return decoded, nil
+}
 
 // GenerateNonce creates a random base64-encoded nonce of a fixed size.
 func GenerateNonce() (string, error) {
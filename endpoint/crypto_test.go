@@ -67,6 +67,23 @@ func TestEncrypt(t *testing.T) {
 	}
 }
 
+func TestParseAESKey(t *testing.T) {
+	key, err := ParseAESKey("")
+	require.NoError(t, err)
+	require.Nil(t, key)
+
+	key, err = ParseAESKey("passphrasewhichneedstobe32bytes!")
+	require.NoError(t, err)
+	require.Equal(t, []byte("passphrasewhichneedstobe32bytes!"), key)
+
+	key, err = ParseAESKey(base64.StdEncoding.EncodeToString([]byte("passphrasewhichneedstobe32bytes!")))
+	require.NoError(t, err)
+	require.Equal(t, []byte("passphrasewhichneedstobe32bytes!"), key)
+
+	_, err = ParseAESKey("too-short")
+	require.EqualError(t, err, "the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
+}
+
 func TestGenerateNonceSuccess(t *testing.T) {
 	nonce, err := GenerateNonce()
 	require.NoError(t, err)
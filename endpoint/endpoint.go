@@ -60,6 +60,11 @@ const (
 	// ProviderSpecificRecordType is the provider-specific property name used to
 	// request a particular DNS record type (e.g. "ptr") on an endpoint.
 	ProviderSpecificRecordType = "record-type"
+
+	// ProviderSpecificAdopt is the provider-specific property name used to
+	// request the adoption of an existing record without owner. It is removed
+	// by the planner and never reaches the provider.
+	ProviderSpecificAdopt = "adopt"
 )
 
 var (
@@ -107,6 +112,14 @@ func NewTargets(target ...string) Targets {
 	return set.New(target...).SortedList()
 }
 
+// NewTargetsFromString returns the Targets of a string as returned by String.
+func NewTargetsFromString(s string) Targets {
+	if s == "" {
+		return Targets{}
+	}
+	return NewTargets(strings.Split(s, ";")...)
+}
+
 // String returns the targets joined by semicolons.
 func (t Targets) String() string {
 	return strings.Join(t, ";")
@@ -503,6 +516,18 @@ func (e *Endpoint) IsOwnedBy(ownerID string) bool {
 	return ok && endpointOwner == ownerID
 }
 
+// IsAdopted returns true if the planner marked the endpoint, a record without owner, as adopted by a resource.
+func (e *Endpoint) IsAdopted() bool {
+	_, ok := e.Labels[AdoptedLabelKey]
+	return ok
+}
+
+// IsShared returns true if the registry reported the endpoint as a record shared by several owners.
+func (e *Endpoint) IsShared() bool {
+	_, ok := e.Labels[ForeignTargetsLabelKey]
+	return ok
+}
+
 // GetNakedDomain returns the parent domain of the DNS name (without the first label).
 // For example, "www.example.com" returns "example.com".
 // For apex/two-label names like "example.com", the full name is returned unchanged.
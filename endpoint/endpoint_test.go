@@ -851,6 +851,24 @@ func TestIsOwnedBy(t *testing.T) {
 	}
 }
 
+func TestIsAdopted(t *testing.T) {
+	assert.False(t, (&Endpoint{}).IsAdopted())
+	assert.False(t, (&Endpoint{Labels: Labels{OwnerLabelKey: "foo"}}).IsAdopted())
+	assert.True(t, (&Endpoint{Labels: Labels{OwnerLabelKey: "foo", AdoptedLabelKey: "true"}}).IsAdopted())
+}
+
+func TestIsShared(t *testing.T) {
+	assert.False(t, (&Endpoint{}).IsShared())
+	assert.False(t, (&Endpoint{Labels: Labels{SharedTargetsLabelKey: "1.2.3.4"}}).IsShared())
+	assert.True(t, (&Endpoint{Labels: Labels{ForeignTargetsLabelKey: ""}}).IsShared())
+}
+
+func TestNewTargetsFromString(t *testing.T) {
+	assert.Empty(t, NewTargetsFromString(""))
+	assert.Equal(t, Targets{"1.2.3.4"}, NewTargetsFromString("1.2.3.4"))
+	assert.Equal(t, Targets{"1.2.3.4", "5.6.7.8"}, NewTargetsFromString("5.6.7.8;1.2.3.4"))
+}
+
 func TestDuplicatedEndpointsWithSimpleZone(t *testing.T) {
 	foo1 := &Endpoint{
 		DNSName:    "foo.com",
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +37,18 @@ const (
 	ResourceLabelKey = "resource"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
+	// PendingDeletionLabelKey is the name of the label that holds the time a record was first found to be no longer
+	// desired, in RFC 3339 format. It is set when deletions are delayed by a grace period.
+	PendingDeletionLabelKey = "pending-deletion"
+	// AdoptedLabelKey is the name of the label set by the planner on a record without owner which is adopted
+	// by a resource. Registries store the ownership of the record instead of updating it.
+	AdoptedLabelKey = "adopted"
+	// SharedTargetsLabelKey is the name of the label holding the targets an owner contributes to a record shared
+	// by several owners, separated by semicolons.
+	SharedTargetsLabelKey = "shared-targets"
+	// ForeignTargetsLabelKey is the name of the label set by the registry on a record shared by several owners,
+	// holding the targets contributed by the other owners, separated by semicolons. It is never stored.
+	ForeignTargetsLabelKey = "foreign-targets"
 
 	// AWSSDDescriptionLabel label responsible for storing raw owner/resource combination information in the Labels
 	// supposed to be inserted by AWS SD Provider, and parsed into OwnerLabelKey and ResourceLabelKey key by AWS SD Registry
@@ -43,6 +56,9 @@ const (
 
 	// txtEncryptionNonce label for keep same nonce for same txt records, for prevent different result of encryption for same txt record, it can cause issues for some providers
 	txtEncryptionNonce = "txt-encryption-nonce"
+	// txtEncryptionKey label holds the index of the previous AES key the labels were decrypted with,
+	// so that they are serialized to the same text until they are re-encrypted with the current key
+	txtEncryptionKey = "txt-encryption-key"
 )
 
 // Labels store metadata related to the endpoint
@@ -88,21 +104,49 @@ func NewLabelsFromStringPlain(labelText string) (Labels, error) {
 
 func NewLabelsFromString(labelText string, aesKey []byte) (Labels, error) {
 	if len(aesKey) != 0 {
+		return NewLabelsFromStringWithKeys(labelText, [][]byte{aesKey})
+	}
+	return NewLabelsFromStringPlain(labelText)
+}
+
+// NewLabelsFromStringWithKeys is NewLabelsFromString decrypting with the first of the given keys that succeeds.
+// The first key is the current key, the others are previous keys; labels decrypted with a previous key
+// remember it, see EncryptedWithPreviousKey.
+func NewLabelsFromStringWithKeys(labelText string, aesKeys [][]byte) (Labels, error) {
+	for i, aesKey := range aesKeys {
 		decryptedText, encryptionNonce, err := DecryptText(strings.Trim(labelText, "\""), aesKey)
-		// in case if we have a decryption error, try process original text
+		// in case if we have a decryption error, try the next key and then process original text
 		// decryption errors should be ignored here, because we can already have plain-text labels in the registry
+		if err != nil {
+			continue
+		}
+		labels, err := NewLabelsFromStringPlain(decryptedText)
 		if err == nil {
-			labels, err := NewLabelsFromStringPlain(decryptedText)
-			if err == nil {
-				labels[txtEncryptionNonce] = encryptionNonce
+			labels[txtEncryptionNonce] = encryptionNonce
+			if i > 0 {
+				labels[txtEncryptionKey] = strconv.Itoa(i)
 			}
-
-			return labels, err
 		}
+		return labels, err
 	}
 	return NewLabelsFromStringPlain(labelText)
 }
 
+// EncryptedWithPreviousKey returns true if the labels were decrypted with a previous AES key.
+func (l Labels) EncryptedWithPreviousKey() bool {
+	_, ok := l[txtEncryptionKey]
+	return ok
+}
+
+// ForgetPreviousKey makes labels decrypted with a previous AES key be encrypted with the current key
+// and a new nonce when they are serialized next.
+func (l Labels) ForgetPreviousKey() {
+	if l.EncryptedWithPreviousKey() {
+		delete(l, txtEncryptionKey)
+		delete(l, txtEncryptionNonce)
+	}
+}
+
 // SerializePlain transforms endpoints labels into a external-dns recognizable format string
 // withQuotes adds additional quotes
 func (l Labels) SerializePlain(withQuotes bool) string {
@@ -115,7 +159,7 @@ func (l Labels) SerializePlain(withQuotes bool) string {
 	sort.Strings(keys) // sort for consistency
 
 	for _, key := range keys {
-		if key == txtEncryptionNonce {
+		if key == txtEncryptionNonce || key == txtEncryptionKey || key == ForeignTargetsLabelKey {
 			continue
 		}
 		tokens = append(tokens, fmt.Sprintf("%s/%s=%s", heritage, key, l[key]))
@@ -126,6 +170,18 @@ func (l Labels) SerializePlain(withQuotes bool) string {
 	return strings.Join(tokens, ",")
 }
 
+// SerializeWithKeys is Serialize encrypting with the previous key the labels were decrypted with, if any,
+// and with the current key, the first of the given keys, otherwise.
+func (l Labels) SerializeWithKeys(withQuotes bool, txtEncryptEnabled bool, aesKeys [][]byte) string {
+	if !txtEncryptEnabled {
+		return l.SerializePlain(withQuotes)
+	}
+	if i, err := strconv.Atoi(l[txtEncryptionKey]); err == nil && i > 0 && i < len(aesKeys) {
+		return l.Serialize(withQuotes, txtEncryptEnabled, aesKeys[i])
+	}
+	return l.Serialize(withQuotes, txtEncryptEnabled, aesKeys[0])
+}
+
 // Serialize same to SerializePlain, but encrypt data, if encryption enabled
 func (l Labels) Serialize(withQuotes bool, txtEncryptEnabled bool, aesKey []byte) string {
 	if !txtEncryptEnabled {
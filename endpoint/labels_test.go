@@ -90,6 +90,27 @@ func (suite *LabelsSuite) TestEncryptionKeyChanged() {
 	suite.NotEqual(serialised, suite.fooAsTextEncrypted, "serialized result should be equal")
 }
 
+func (suite *LabelsSuite) TestDecryptionWithPreviousKey() {
+	currentKey := []byte("passphrasewhichneedstobe32bytes!")
+	encrypted := suite.foo.Serialize(false, true, suite.aesKey)
+
+	foo, err := NewLabelsFromStringWithKeys(encrypted, [][]byte{currentKey, suite.aesKey})
+	suite.Require().NoError(err, "should decrypt with the previous key")
+	suite.Equal("foo-owner", foo[OwnerLabelKey])
+	suite.True(foo.EncryptedWithPreviousKey())
+	suite.Equal(encrypted, foo.SerializeWithKeys(false, true, [][]byte{currentKey, suite.aesKey}), "should serialize with the previous key and nonce")
+
+	foo.ForgetPreviousKey()
+	suite.False(foo.EncryptedWithPreviousKey())
+	reencrypted := foo.SerializeWithKeys(false, true, [][]byte{currentKey, suite.aesKey})
+	suite.NotEqual(encrypted, reencrypted)
+
+	foo, err = NewLabelsFromStringWithKeys(reencrypted, [][]byte{currentKey, suite.aesKey})
+	suite.Require().NoError(err, "should decrypt with the current key")
+	suite.Equal("foo-owner", foo[OwnerLabelKey])
+	suite.False(foo.EncryptedWithPreviousKey())
+}
+
 func (suite *LabelsSuite) TestEncryptionFailed() {
 	foo, err := NewLabelsFromString(suite.fooAsTextEncrypted, suite.aesKey)
 	suite.NoError(err, "should succeed for valid label text")
@@ -204,6 +225,14 @@ func TestParseLabels(t *testing.T) {
 				"resource": "ingress/default/example",
 			},
 		},
+		{
+			name:      "parses pending deletion timestamp",
+			labelText: "heritage=external-dns,external-dns/owner=team-platform,external-dns/pending-deletion=2026-01-02T03:04:05Z",
+			expected: Labels{
+				OwnerLabelKey:           "team-platform",
+				PendingDeletionLabelKey: "2026-01-02T03:04:05Z",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -31,6 +31,7 @@ type FlagBinder interface {
 	DurationVar(name, help string, def time.Duration, target *time.Duration)
 	IntVar(name, help string, def int, target *int)
 	Int64Var(name, help string, def int64, target *int64)
+	Float64Var(name, help string, def float64, target *float64)
 	StringsVar(name, help string, def []string, target *[]string)
 	EnumVar(name, help, def string, target *string, allowed ...string)
 	// StringsEnumVar binds a repeatable string flag with an allowed set.
@@ -76,6 +77,10 @@ func (b *KingpinBinder) Int64Var(name, help string, def int64, target *int64) {
 	b.App.Flag(name, help).Default(strconv.FormatInt(def, 10)).Int64Var(target)
 }
 
+func (b *KingpinBinder) Float64Var(name, help string, def float64, target *float64) {
+	b.App.Flag(name, help).Default(strconv.FormatFloat(def, 'g', -1, 64)).Float64Var(target)
+}
+
 func (b *KingpinBinder) StringsVar(name, help string, def []string, target *[]string) {
 	if len(def) > 0 {
 		b.App.Flag(name, help).Default(def...).StringsVar(target)
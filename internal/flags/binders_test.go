@@ -36,6 +36,7 @@ func TestKingpinBinderParsesAllTypes(t *testing.T) {
 		d    time.Duration
 		i    int
 		i64  int64
+		f64  float64
 		ss   []string
 		e    string
 	)
@@ -45,10 +46,11 @@ func TestKingpinBinderParsesAllTypes(t *testing.T) {
 	b.DurationVar("d", "duration flag", 5*time.Second, &d)
 	b.IntVar("i", "int flag", 7, &i)
 	b.Int64Var("i64", "int64 flag", 9, &i64)
+	b.Float64Var("f64", "float64 flag", 0.5, &f64)
 	b.StringsVar("ss", "strings flag", []string{"x"}, &ss)
 	b.EnumVar("e", "enum flag", "a", &e, "a", "b")
 
-	_, err := app.Parse([]string{"--s=abc", "--no-b", "--d=2s", "--i=42", "--i64=64", "--ss=one", "--ss=two", "--e=b"})
+	_, err := app.Parse([]string{"--s=abc", "--no-b", "--d=2s", "--i=42", "--i64=64", "--f64=0.25", "--ss=one", "--ss=two", "--e=b"})
 	require.NoError(t, err)
 
 	assert.Equal(t, "abc", s)
@@ -56,6 +58,7 @@ func TestKingpinBinderParsesAllTypes(t *testing.T) {
 	assert.Equal(t, 2*time.Second, d)
 	assert.Equal(t, 42, i)
 	assert.Equal(t, int64(64), i64)
+	assert.InDelta(t, 0.25, f64, 0)
 	assert.ElementsMatch(t, []string{"one", "two"}, ss)
 	assert.Equal(t, "b", e)
 }
@@ -17,11 +17,16 @@ limitations under the License.
 package externaldns
 
 const (
-	RegistryTXT      = "txt"
-	RegistryNoop     = "noop"
-	RegistryDynamoDB = "dynamodb"
-	RegistryAWSSD    = "aws-sd"
-	RegistryCRD      = "crd"
+	RegistryTXT       = "txt"
+	RegistryNoop      = "noop"
+	RegistryDynamoDB  = "dynamodb"
+	RegistryAWSSD     = "aws-sd"
+	RegistryCRD       = "crd"
+	RegistryConfigMap = "configmap"
+
+	TXTOrphanCleanupDisabled = "disabled"
+	TXTOrphanCleanupDryRun   = "dry-run"
+	TXTOrphanCleanupDelete   = "delete"
 
 	ProviderAlibabaCloud = "alibabacloud"
 	ProviderAWS          = "aws"
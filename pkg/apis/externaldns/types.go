@@ -114,6 +114,10 @@ type Config struct {
 	AWSZoneMatchParent                            bool
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
+	ConfigMapRegistryName                         string
+	ConfigMapRegistryNamespace                    string
+	ConfigMapRegistryShards                       int
+	CRDRegistryVerifyInterval                     time.Duration
 	AzureConfigFile                               string
 	AzureResourceGroup                            string
 	AzureSubscriptionID                           string
@@ -150,23 +154,55 @@ type Config struct {
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	ConflictResolver                              string
+	ConflictResolverNamespacePriority             []string
+	DeletionThresholdCount                        int
+	DeletionThresholdPercentage                   int
+	DeletionThresholdOverride                     bool
+	DeletionGracePeriod                           time.Duration
+	ZoneApplyConcurrency                          int
+	ProvidersConfig                               string
+	Audit                                         bool
+	MigrateRegistryTo                             string
+	MigrateRegistryDeleteSource                   bool
 	Registry                                      string
 	TXTOwnerID                                    string
 	TXTOwnerOld                                   string
 	TXTPrefix                                     string
 	TXTSuffix                                     string
+	TXTNameTemplate                               string
+	TXTConsolidated                               bool
+	TXTSharedOwnership                            bool
 	TXTEncryptEnabled                             bool
-	TXTEncryptAESKey                              string `secure:"yes"`
+	TXTEncryptAESKey                              string   `secure:"yes"`
+	TXTEncryptPreviousAESKeys                     []string `secure:"yes"`
+	TXTEncryptReencrypt                           bool
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	ReconcileBackoffInitial                       time.Duration
+	ReconcileBackoffMax                           time.Duration
+	ReconcileBackoffJitter                        float64
+	ReconcileRetryBudget                          int
+	SoftErrorRetryInitial                         time.Duration
 	MinTTL                                        time.Duration
 	Once                                          bool
+	PlanOutput                                    string
+	PlanOutputFormat                              string
+	PlanApply                                     string
+	EnableLeaderElection                          bool
+	LeaderElectionNamespace                       string
+	LeaderElectionLeaseName                       string
+	LeaderElectionLeaseDuration                   time.Duration
+	LeaderElectionRenewDeadline                   time.Duration
+	LeaderElectionRetryPeriod                     time.Duration
 	DryRun                                        bool
 	UpdateEvents                                  bool
 	LogFormat                                     string
 	MetricsAddress                                string
 	LogLevel                                      string
 	TXTCacheInterval                              time.Duration
+	TXTOrphanCleanup                              string
+	TXTOrphanMinAge                               time.Duration
 	TXTWildcardReplacement                        string
 	ExoscaleEndpoint                              string
 	ExoscaleAPIKey                                string `secure:"yes"`
@@ -176,6 +212,8 @@ type Config struct {
 	ExoscaleZoneCacheDuration                     time.Duration
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	FileSourceDir                                 string
+	FileSourceInterval                            time.Duration
 	ServiceTypeFilter                             []string
 	ResolveServiceLoadBalancerHostname            bool
 	RFC2136Host                                   []string
@@ -220,6 +258,7 @@ type Config struct {
 	EmitEvents                                    []string
 	ForceDefaultTargets                           bool
 	UnstructuredResources                         []string
+	UnstructuredMappingConfig                     string
 	PreferAlias                                   bool
 }
 
@@ -237,6 +276,8 @@ var defaultConfig = &Config{
 	AWSBatchChangeSizeValues: 1000,
 	AWSDynamoDBRegion:        "",
 	AWSDynamoDBTable:         "external-dns",
+	ConfigMapRegistryName:    "external-dns-registry",
+	ConfigMapRegistryShards:  4,
 	AWSEvaluateTargetHealth:  true,
 	AWSPreferCNAME:           false,
 	AWSSDCreateTag:           map[string]string{},
@@ -262,11 +303,14 @@ var defaultConfig = &Config{
 
 	CombineFQDNAndAnnotation:     false,
 	Compatibility:                "",
+	ConflictResolver:             "per-resource",
 	ConnectorSourceServer:        "localhost:8080",
 	CoreDNSPrefix:                "/skydns/",
 	CoreDNSStrictlyOwned:         false,
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
 	CRDSourceKind:                "DNSEndpoint",
+	FileSourceDir:                "",
+	FileSourceInterval:           10 * time.Second,
 	DefaultTargets:               []string{},
 	DomainFilter:                 []string{},
 	DryRun:                       false,
@@ -304,6 +348,11 @@ var defaultConfig = &Config{
 	Interval:                     time.Minute,
 	KubeConfig:                   "",
 	LabelFilter:                  labels.Everything().String(),
+	LeaderElectionLeaseDuration:  15 * time.Second,
+	LeaderElectionLeaseName:      "external-dns",
+	LeaderElectionNamespace:      "",
+	LeaderElectionRenewDeadline:  10 * time.Second,
+	LeaderElectionRetryPeriod:    2 * time.Second,
 	LogFormat:                    "text",
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
@@ -326,6 +375,7 @@ var defaultConfig = &Config{
 	PDNSServerID:                 "localhost",
 	PDNSSkipTLSVerify:            false,
 	PiholePassword:               "",
+	PlanOutputFormat:             "json",
 	PiholeServer:                 "",
 	PiholeTLSInsecureSkipVerify:  false,
 	PodSourceDomain:              "",
@@ -336,6 +386,9 @@ var defaultConfig = &Config{
 	PublishHostIP:                false,
 	PublishInternal:              false,
 	RegexDomainExclude:           regexp.MustCompile(""),
+	ReconcileBackoffMax:          5 * time.Minute,
+	ReconcileBackoffJitter:       0.1,
+	ReconcileRetryBudget:         5,
 	RegexDomainFilter:            regexp.MustCompile(""),
 	Registry:                     RegistryTXT,
 	RequestTimeout:               time.Second * 30,
@@ -370,6 +423,8 @@ var defaultConfig = &Config{
 	TraefikEnableLegacy:          false,
 	TraefikDisableNew:            false,
 	TXTCacheInterval:             0,
+	TXTOrphanCleanup:             TXTOrphanCleanupDisabled,
+	TXTOrphanMinAge:              time.Hour,
 	TXTEncryptAESKey:             "",
 	TXTEncryptEnabled:            false,
 	TXTOwnerID:                   "default",
@@ -385,6 +440,7 @@ var defaultConfig = &Config{
 	ZoneIDFilter:                 []string{},
 	ForceDefaultTargets:          false,
 	UnstructuredResources:        []string{},
+	UnstructuredMappingConfig:    "",
 	PreferAlias:                  false,
 }
 
@@ -442,6 +498,7 @@ var allowedSources = []string{
 	"f5-transportserver",
 	"traefik-proxy",
 	"unstructured",
+	"file",
 }
 
 // NewConfig returns new Config object
@@ -461,7 +518,12 @@ func (cfg *Config) String() string {
 		f := t.Field(i)
 		if val, ok := f.Tag.Lookup("secure"); ok && val == "yes" {
 			v := reflect.ValueOf(&temp).Elem().Field(i)
-			if v.String() != "" {
+			switch {
+			case v.Kind() == reflect.Slice:
+				if v.Len() > 0 {
+					v.Set(reflect.ValueOf([]string{passwordMask}))
+				}
+			case v.String() != "":
 				v.SetString(passwordMask)
 			}
 		}
@@ -527,7 +589,7 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.StringVar("annotation-filter", "Filter resources queried for endpoints by annotation, using label selector semantics", defaultConfig.AnnotationFilter, &cfg.AnnotationFilter)
 	b.StringVar("annotation-prefix", "Annotation prefix for external-dns annotations (default: external-dns.kubernetes.io/)", defaultConfig.AnnotationPrefix, &cfg.AnnotationPrefix)
 	b.EnumVar("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)", defaultConfig.Compatibility, &cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
-	b.StringVar("connector-source-server", "The server to connect for connector source, valid only when using connector source", defaultConfig.ConnectorSourceServer, &cfg.ConnectorSourceServer)
+	b.StringVar("connector-source-server", "The server to connect for connector source, valid only when using connector source; a host:port address for the TCP protocol, or an http(s):// URL for the streaming protocol, with the --tls-* flags for TLS", defaultConfig.ConnectorSourceServer, &cfg.ConnectorSourceServer)
 	b.StringVar("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source", defaultConfig.CRDSourceAPIVersion, &cfg.CRDSourceAPIVersion)
 	b.StringVar("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion", defaultConfig.CRDSourceKind, &cfg.CRDSourceKind)
 	b.StringsVar("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Only applies to the crd source (DNSEndpoint resources with empty targets). Specify multiple times for multiple targets (optional)", nil, &cfg.DefaultTargets)
@@ -535,6 +597,8 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("prefer-alias", "When enabled, CNAME records will have the alias annotation set, signaling providers that support ALIAS records to use them instead of CNAMEs. Supported by: PowerDNS, AWS (with --aws-prefer-cname disabled)", defaultConfig.PreferAlias, &cfg.PreferAlias)
 	b.StringsVar("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)", nil, &cfg.ExcludeDNSRecordTypes)
 	b.StringsVar("exclude-target-net", "Exclude target nets (optional)", nil, &cfg.ExcludeTargetNets)
+	b.StringVar("file-source-dir", "When using the file source, the directory of the YAML, JSON (.yaml, .yml, .json) and zone (.zone) files declaring the endpoints; read recursively", defaultConfig.FileSourceDir, &cfg.FileSourceDir)
+	b.DurationVar("file-source-interval", "When using the file source, the interval between two checks of the files for changes (0s disables the checks)", defaultConfig.FileSourceInterval, &cfg.FileSourceInterval)
 	b.BoolVar("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)", defaultConfig.ExcludeUnschedulable, &cfg.ExcludeUnschedulable)
 	b.BoolVar("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional, default: false)", false, &cfg.ExposeInternalIPV6)
 	b.StringVar("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)", defaultConfig.GatewayLabelFilter, &cfg.GatewayLabelFilter)
@@ -561,8 +625,10 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 	b.BoolVar("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group", defaultConfig.TraefikDisableNew, &cfg.TraefikDisableNew)
 
 	b.StringsVar("unstructured-resource", "When using the unstructured source, specify resources in resource.version.group format (e.g., virtualmachineinstances.v1.kubevirt.io, configmap.v1); specify multiple times for multiple resources", nil, &cfg.UnstructuredResources)
-	b.StringsVar("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: RecordReady, RecordDeleted, RecordError)", defaultConfig.EmitEvents, &cfg.EmitEvents)
+	b.StringVar("unstructured-mapping-config", "When using the unstructured source, map the fields of resources to DNS records with the JSONPath expressions of this YAML file; the resources with a mapping are watched (optional)", defaultConfig.UnstructuredMappingConfig, &cfg.UnstructuredMappingConfig)
+	b.StringsVar("events-emit", "Events that should be emitted. Specify multiple times for multiple events support (optional, default: none, expected: RecordReady, RecordDeleted, RecordError, RecordConflict, RecordDeletionBlocked, RecordAdopted)", defaultConfig.EmitEvents, &cfg.EmitEvents)
 	b.DurationVar("provider-cache-time", "The time to cache the DNS provider record list requests.", defaultConfig.ProviderCacheTime, &cfg.ProviderCacheTime)
+	b.StringVar("providers-config", "When set, runs a provider per entry of this YAML file, each with its own domain filter and registry; endpoints are routed to the first provider whose domain filter matches (optional)", defaultConfig.ProvidersConfig, &cfg.ProvidersConfig)
 	b.BoolVar("create-ptr", "When enabled, automatically create PTR records for A/AAAA records. Per-resource annotations can override this default. The provider must have authority over the reverse DNS zones (e.g. in-addr.arpa). Include reverse zones in --domain-filter.", defaultConfig.CreatePTR, &cfg.CreatePTR)
 	b.StringsVar("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)", []string{""}, &cfg.DomainFilter)
 	b.StringsVar("exclude-domains", "Exclude subdomains (optional)", []string{""}, &cfg.DomainExclude)
@@ -673,24 +739,59 @@ func bindFlags(b flags.FlagBinder, cfg *Config) {
 
 	// Flags related to policies
 	b.EnumVar("policy", "Modify how DNS records are synchronized between sources and providers (required, no default; options: sync, upsert-only, create-only)", defaultConfig.Policy, &cfg.Policy, "", "sync", "upsert-only", "create-only")
+	b.EnumVar("conflict-resolver", "Modify how a DNS name claimed by several resources is assigned (default: per-resource, options: per-resource, oldest-resource, namespace-priority, refuse)", defaultConfig.ConflictResolver, &cfg.ConflictResolver, "per-resource", "oldest-resource", "namespace-priority", "refuse")
+	b.StringsVar("conflict-resolver-namespace-priority", "When using the namespace-priority conflict resolver, a namespace whose resources win conflicting DNS names; specify multiple times in descending order of priority (required when --conflict-resolver=namespace-priority)", nil, &cfg.ConflictResolverNamespacePriority)
+	b.IntVar("deletion-threshold-count", "Block all changes of a synchronization that would delete more than this number of records (default: 0, disabled)", defaultConfig.DeletionThresholdCount, &cfg.DeletionThresholdCount)
+	b.IntVar("deletion-threshold-percentage", "Block all changes of a synchronization that would delete more than this percentage of the current records (default: 0, disabled, max: 100)", defaultConfig.DeletionThresholdPercentage, &cfg.DeletionThresholdPercentage)
+	b.BoolVar("deletion-threshold-override", "Apply changes exceeding --deletion-threshold-count or --deletion-threshold-percentage, only logging a warning (default: disabled)", defaultConfig.DeletionThresholdOverride, &cfg.DeletionThresholdOverride)
+	b.DurationVar("deletion-grace-period", "Delete records only after they have been absent from the sources for this duration; the start of the grace period is stored in the registry (default: 0, disabled; not supported with the aws-sd and noop registries)", defaultConfig.DeletionGracePeriod, &cfg.DeletionGracePeriod)
 
 	// Flags related to the registry
-	b.EnumVar("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: aws-sd, crd, dynamodb, noop, txt)", defaultConfig.Registry, &cfg.Registry, RegistryAWSSD, RegistryCRD, RegistryDynamoDB, RegistryNoop, RegistryTXT)
+	b.EnumVar("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: aws-sd, configmap, crd, dynamodb, noop, txt)", defaultConfig.Registry, &cfg.Registry, RegistryAWSSD, RegistryConfigMap, RegistryCRD, RegistryDynamoDB, RegistryNoop, RegistryTXT)
 	b.StringVar("txt-owner-id", "When using the TXT, DynamoDB or CRD registry, a name that identifies this instance of ExternalDNS (default: default)", defaultConfig.TXTOwnerID, &cfg.TXTOwnerID)
 	b.StringVar("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!", defaultConfig.TXTPrefix, &cfg.TXTPrefix)
 	b.StringVar("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!", defaultConfig.TXTSuffix, &cfg.TXTSuffix)
+	b.StringVar("txt-name-template", "When using the TXT registry, a template of the name of each ownership DNS record, such as '%{record_type}.%{host}._owner.%{domain}' (optional). Must contain %{record_type} and %{host} and end with .%{domain}. Mutual exclusive with txt-prefix and txt-suffix!", defaultConfig.TXTNameTemplate, &cfg.TXTNameTemplate)
+	b.BoolVar("txt-consolidated", "When using the TXT registry, store the ownership of all the records of a DNS name in a single TXT record instead of one per record type; existing TXT records are migrated (default: disabled)", defaultConfig.TXTConsolidated, &cfg.TXTConsolidated)
+	b.BoolVar("txt-shared-ownership", "When using the TXT registry, share the ownership of the A and AAAA records with the other owners enabling this option, the records holding the targets of all of them (default: disabled)", defaultConfig.TXTSharedOwnership, &cfg.TXTSharedOwnership)
+	b.EnumVar("txt-orphan-cleanup", "When using the TXT registry, delete the TXT records owned by this instance whose record no longer exists, or only report them with dry-run (default: disabled, options: disabled, dry-run, delete)", defaultConfig.TXTOrphanCleanup, &cfg.TXTOrphanCleanup, TXTOrphanCleanupDisabled, TXTOrphanCleanupDryRun, TXTOrphanCleanupDelete)
+	b.DurationVar("txt-orphan-min-age", "When using the TXT registry with --txt-orphan-cleanup, the minimum time a TXT record has been seen without its record before it is deleted (default: 1h)", defaultConfig.TXTOrphanMinAge, &cfg.TXTOrphanMinAge)
 	b.StringVar("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)", defaultConfig.TXTWildcardReplacement, &cfg.TXTWildcardReplacement)
 	b.BoolVar("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)", defaultConfig.TXTEncryptEnabled, &cfg.TXTEncryptEnabled)
 	b.StringVar("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)", defaultConfig.TXTEncryptAESKey, &cfg.TXTEncryptAESKey)
+	b.StringsVar("txt-encrypt-previous-aes-key", "When using the TXT registry, a previous AES key TXT records are still decrypted with after a key rotation; specify multiple times for multiple keys (optional)", nil, &cfg.TXTEncryptPreviousAESKeys)
+	b.BoolVar("txt-encrypt-reencrypt", "When enabled, owned TXT records still encrypted with a previous AES key are updated to the current key (default: disabled)", defaultConfig.TXTEncryptReencrypt, &cfg.TXTEncryptReencrypt)
 	b.StringVar("migrate-from-txt-owner", "Old txt-owner-id that needs to be overwritten (default: default)", defaultConfig.TXTOwnerOld, &cfg.TXTOwnerOld)
+	b.EnumVar("migrate-registry-to", "When set, copies the ownership of the records owned by --txt-owner-id from --registry to this registry, writes a JSON report to stdout and exits; with --dry-run, only reports what would be migrated (optional, options: configmap, crd, dynamodb, txt)", defaultConfig.MigrateRegistryTo, &cfg.MigrateRegistryTo, "", RegistryConfigMap, RegistryCRD, RegistryDynamoDB, RegistryTXT)
+	b.BoolVar("migrate-registry-delete-source", "When migrating from the TXT registry with --migrate-registry-to, deletes the TXT ownership records once the migrated ownership is verified (default: disabled)", defaultConfig.MigrateRegistryDeleteSource, &cfg.MigrateRegistryDeleteSource)
 	b.StringVar("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)", cfg.AWSDynamoDBRegion, &cfg.AWSDynamoDBRegion)
 	b.StringVar("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")", defaultConfig.AWSDynamoDBTable, &cfg.AWSDynamoDBTable)
+	b.StringVar("configmap-registry-name", "When using the ConfigMap registry, the name of the ConfigMaps storing the ownership, suffixed by the shard number (default: \"external-dns-registry\")", defaultConfig.ConfigMapRegistryName, &cfg.ConfigMapRegistryName)
+	b.StringVar("configmap-registry-namespace", "When using the ConfigMap registry, the namespace of the ConfigMaps (default: namespace of the service account, or \"default\")", defaultConfig.ConfigMapRegistryNamespace, &cfg.ConfigMapRegistryNamespace)
+	b.DurationVar("crd-registry-verify-interval", "When using the CRD registry, the interval between the verifications of the DNSRecord objects against the records of the provider, flagging drifted records and deleting the objects of records removed out-of-band (default: 0, disabled)", defaultConfig.CRDRegistryVerifyInterval, &cfg.CRDRegistryVerifyInterval)
+	b.IntVar("configmap-registry-shards", "When using the ConfigMap registry, the number of ConfigMaps the ownership is spread over; increase it when a ConfigMap would exceed the size limit of Kubernetes objects (default: 4)", defaultConfig.ConfigMapRegistryShards, &cfg.ConfigMapRegistryShards)
 
 	// Flags related to the main control loop
 	b.DurationVar("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)", defaultConfig.TXTCacheInterval, &cfg.TXTCacheInterval)
 	b.DurationVar("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)", defaultConfig.Interval, &cfg.Interval)
 	b.DurationVar("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)", defaultConfig.MinEventSyncInterval, &cfg.MinEventSyncInterval)
+	b.DurationVar("reconcile-backoff-initial", "When set, a failed synchronization is retried after this delay, doubled for every consecutive failure, instead of exiting (default: 0, disabled)", defaultConfig.ReconcileBackoffInitial, &cfg.ReconcileBackoffInitial)
+	b.DurationVar("reconcile-backoff-max", "The maximum delay between retries of failed synchronizations (default: 5m)", defaultConfig.ReconcileBackoffMax, &cfg.ReconcileBackoffMax)
+	b.Float64Var("reconcile-backoff-jitter", "The maximum random delay added to every retry, as a fraction of the delay (default: 0.1)", defaultConfig.ReconcileBackoffJitter, &cfg.ReconcileBackoffJitter)
+	b.IntVar("reconcile-retry-budget", "The number of consecutive failed synchronizations retried with --reconcile-backoff-initial before exiting (default: 5)", defaultConfig.ReconcileRetryBudget, &cfg.ReconcileRetryBudget)
+	b.DurationVar("soft-error-retry-initial", "When set, a synchronization failing with a soft error is retried after this delay, doubled for every consecutive soft error up to --interval, instead of at the next interval (default: 0, disabled)", defaultConfig.SoftErrorRetryInitial, &cfg.SoftErrorRetryInitial)
 	b.BoolVar("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)", defaultConfig.Once, &cfg.Once)
+	b.StringVar("plan-output", "When set together with --once, writes the calculated changes to this file instead of applying them; use '-' for stdout (optional)", defaultConfig.PlanOutput, &cfg.PlanOutput)
+	b.StringVar("plan-apply", "When set together with --once, applies the changes of a plan written by --plan-output instead of calculating them; refuses if records to update or delete changed since (optional)", defaultConfig.PlanApply, &cfg.PlanApply)
+	b.EnumVar("plan-output-format", "The format of the plan written to --plan-output (default: json, options: json, diff)", defaultConfig.PlanOutputFormat, &cfg.PlanOutputFormat, "json", "diff")
+	b.BoolVar("enable-leader-election", "When enabled, only the replica holding a coordination.k8s.io Lease reconciles DNS records; other replicas stay on standby (default: disabled)", defaultConfig.EnableLeaderElection, &cfg.EnableLeaderElection)
+	b.StringVar("leader-election-namespace", "The namespace of the leader election Lease (default: namespace of the service account, or \"default\")", defaultConfig.LeaderElectionNamespace, &cfg.LeaderElectionNamespace)
+	b.StringVar("leader-election-lease-name", "The name of the leader election Lease (default: external-dns)", defaultConfig.LeaderElectionLeaseName, &cfg.LeaderElectionLeaseName)
+	b.DurationVar("leader-election-lease-duration", "The duration that standby replicas wait before trying to acquire an unrenewed leader Lease (default: 15s)", defaultConfig.LeaderElectionLeaseDuration, &cfg.LeaderElectionLeaseDuration)
+	b.DurationVar("leader-election-renew-deadline", "The duration that the leader retries refreshing the Lease before giving up leadership (default: 10s)", defaultConfig.LeaderElectionRenewDeadline, &cfg.LeaderElectionRenewDeadline)
+	b.DurationVar("leader-election-retry-period", "The duration between leader election attempts (default: 2s)", defaultConfig.LeaderElectionRetryPeriod, &cfg.LeaderElectionRetryPeriod)
+	b.IntVar("zone-apply-concurrency", "Apply the changes of every DNS zone separately, with up to this number of zones in parallel; a failing zone does not prevent the others from being applied (default: 0, apply all changes at once)", defaultConfig.ZoneApplyConcurrency, &cfg.ZoneApplyConcurrency)
+	b.BoolVar("audit", "When enabled, classifies records as in sync, drifted, colliding or orphaned instead of applying changes; the report is served on /audit of the metrics server (default: disabled)", defaultConfig.Audit, &cfg.Audit)
 	b.BoolVar("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)", defaultConfig.DryRun, &cfg.DryRun)
 	b.BoolVar("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)", defaultConfig.UpdateEvents, &cfg.UpdateEvents)
 	b.DurationVar("min-ttl", "Configure global TTL for records in duration format. This value is used when the TTL for a source is not set or set to 0. (optional; examples: 1m12s, 72s, 72)", defaultConfig.MinTTL, &cfg.MinTTL)
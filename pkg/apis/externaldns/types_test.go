@@ -76,6 +76,8 @@ var (
 		AWSSDServiceCleanup:                    false,
 		AWSSDCreateTag:                         map[string]string{},
 		AWSDynamoDBTable:                       "external-dns",
+		ConfigMapRegistryName:                  "external-dns-registry",
+		ConfigMapRegistryShards:                4,
 		AzureConfigFile:                        "/etc/kubernetes/azure.json",
 		AzureResourceGroup:                     "",
 		AzureSubscriptionID:                    "",
@@ -100,14 +102,25 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
 		Policy:                                        "",
+		ConflictResolver:                              "per-resource",
 		Registry:                                      "txt",
 		TXTOwnerID:                                    "default",
 		TXTOwnerOld:                                   "",
 		TXTPrefix:                                     "",
 		TXTCacheInterval:                              0,
+		TXTOrphanCleanup:                              "disabled",
+		TXTOrphanMinAge:                               time.Hour,
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
+		ReconcileBackoffMax:                           5 * time.Minute,
+		ReconcileBackoffJitter:                        0.1,
+		ReconcileRetryBudget:                          5,
 		Once:                                          false,
+		PlanOutputFormat:                              "json",
+		LeaderElectionLeaseName:                       "external-dns",
+		LeaderElectionLeaseDuration:                   15 * time.Second,
+		LeaderElectionRenewDeadline:                   10 * time.Second,
+		LeaderElectionRetryPeriod:                     2 * time.Second,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
@@ -120,6 +133,7 @@ var (
 		ExoscaleAPISecret:                             "",
 		CRDSourceAPIVersion:                           "externaldns.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "DNSEndpoint",
+		FileSourceInterval:                            10 * time.Second,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 		RFC2136BatchChangeSize:                        50,
 		RFC2136Host:                                   []string{""},
@@ -180,6 +194,8 @@ var (
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
 		AWSDynamoDBTable:                       "custom-table",
+		ConfigMapRegistryName:                  "external-dns-registry",
+		ConfigMapRegistryShards:                4,
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
 		AzureSubscriptionID:                    "arg",
@@ -209,15 +225,26 @@ var (
 		TLSClientCertKey:                              "/path/to/key.pem",
 		PodSourceDomain:                               "example.org",
 		Policy:                                        "sync",
+		ConflictResolver:                              "per-resource",
 		Registry:                                      "noop",
 		TXTOwnerID:                                    "owner-1",
 		TXTPrefix:                                     "associated-txt-record",
 		TXTOwnerOld:                                   "old-owner",
 		TXTCacheInterval:                              12 * time.Hour,
+		TXTOrphanCleanup:                              "disabled",
+		TXTOrphanMinAge:                               time.Hour,
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
+		ReconcileBackoffMax:                           5 * time.Minute,
+		ReconcileBackoffJitter:                        0.1,
+		ReconcileRetryBudget:                          5,
 		MinTTL:                                        40 * time.Second,
 		Once:                                          true,
+		PlanOutputFormat:                              "json",
+		LeaderElectionLeaseName:                       "external-dns",
+		LeaderElectionLeaseDuration:                   15 * time.Second,
+		LeaderElectionRenewDeadline:                   10 * time.Second,
+		LeaderElectionRetryPeriod:                     2 * time.Second,
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
 		LogFormat:                                     "json",
@@ -230,6 +257,7 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		FileSourceInterval:                            10 * time.Second,
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
@@ -547,10 +575,12 @@ func TestConfigStringMasksSecureFields(t *testing.T) {
 	cfg := NewConfig()
 	cfg.AWSAssumeRoleExternalID = "sensitive-value"
 	cfg.GoDaddyAPIKey = "another-secret"
+	cfg.TXTEncryptPreviousAESKeys = []string{"previous-secret"}
 
 	s := cfg.String()
 	require.NotContains(t, s, "sensitive-value")
 	require.NotContains(t, s, "another-secret")
+	require.NotContains(t, s, "previous-secret")
 	require.Contains(t, s, passwordMask)
 }
 
@@ -860,6 +890,105 @@ func TestParseFlagsTXTRegistry(t *testing.T) {
 	assert.Equal(t, "X", cfg.TXTWildcardReplacement)
 }
 
+func TestParseFlagsTXTNameTemplate(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--txt-name-template=%{record_type}.%{host}._owner.%{domain}")
+	assert.Equal(t, "%{record_type}.%{host}._owner.%{domain}", cfg.TXTNameTemplate)
+}
+
+func TestParseFlagsTXTConsolidated(t *testing.T) {
+	t.Parallel()
+	assert.False(t, parseCfg(t).TXTConsolidated)
+	assert.True(t, parseCfg(t, "--txt-consolidated").TXTConsolidated)
+}
+
+func TestParseFlagsTXTOrphanCleanup(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--txt-orphan-cleanup=dry-run",
+		"--txt-orphan-min-age=24h",
+	)
+
+	assert.Equal(t, TXTOrphanCleanupDryRun, cfg.TXTOrphanCleanup)
+	assert.Equal(t, 24*time.Hour, cfg.TXTOrphanMinAge)
+}
+
+func TestParseFlagsTXTSharedOwnership(t *testing.T) {
+	t.Parallel()
+	assert.False(t, parseCfg(t).TXTSharedOwnership)
+	assert.True(t, parseCfg(t, "--txt-shared-ownership").TXTSharedOwnership)
+}
+
+func TestParseFlagsConfigMapRegistry(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--registry=configmap",
+		"--configmap-registry-name=dns-ownership",
+		"--configmap-registry-namespace=external-dns",
+		"--configmap-registry-shards=8",
+	)
+
+	assert.Equal(t, RegistryConfigMap, cfg.Registry)
+	assert.Equal(t, "dns-ownership", cfg.ConfigMapRegistryName)
+	assert.Equal(t, "external-dns", cfg.ConfigMapRegistryNamespace)
+	assert.Equal(t, 8, cfg.ConfigMapRegistryShards)
+}
+
+func TestParseFlagsCRDRegistryVerifyInterval(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--registry=crd",
+		"--crd-registry-verify-interval=10m",
+	)
+
+	assert.Equal(t, RegistryCRD, cfg.Registry)
+	assert.Equal(t, 10*time.Minute, cfg.CRDRegistryVerifyInterval)
+}
+
+func TestParseFlagsMigrateRegistry(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--registry=txt",
+		"--migrate-registry-to=crd",
+		"--migrate-registry-delete-source",
+	)
+
+	assert.Equal(t, RegistryCRD, cfg.MigrateRegistryTo)
+	assert.True(t, cfg.MigrateRegistryDeleteSource)
+}
+
+func TestParseFlagsTXTEncryptRotation(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--txt-encrypt-enabled",
+		"--txt-encrypt-aes-key=0123456789abcdef0123456789abcdef",
+		"--txt-encrypt-previous-aes-key=passphrasewhichneedstobe32bytes!",
+		"--txt-encrypt-previous-aes-key=ZPitL0NGVQBZbTD6DwXJzD8RiStSazzYXQsdUowLURY=",
+		"--txt-encrypt-reencrypt",
+	)
+
+	assert.Equal(t, []string{"passphrasewhichneedstobe32bytes!", "ZPitL0NGVQBZbTD6DwXJzD8RiStSazzYXQsdUowLURY="}, cfg.TXTEncryptPreviousAESKeys)
+	assert.True(t, cfg.TXTEncryptReencrypt)
+}
+
+func TestParseFlagsLeaderElection(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--enable-leader-election",
+		"--leader-election-namespace=kube-system",
+		"--leader-election-lease-name=external-dns-public",
+		"--leader-election-lease-duration=30s",
+		"--leader-election-renew-deadline=20s",
+		"--leader-election-retry-period=5s",
+	)
+	assert.True(t, cfg.EnableLeaderElection)
+	assert.Equal(t, "kube-system", cfg.LeaderElectionNamespace)
+	assert.Equal(t, "external-dns-public", cfg.LeaderElectionLeaseName)
+	assert.Equal(t, 30*time.Second, cfg.LeaderElectionLeaseDuration)
+	assert.Equal(t, 20*time.Second, cfg.LeaderElectionRenewDeadline)
+	assert.Equal(t, 5*time.Second, cfg.LeaderElectionRetryPeriod)
+}
+
 func TestParseFlagsWebhookProvider(t *testing.T) {
 	t.Parallel()
 	cfg := parseCfg(t,
@@ -985,3 +1114,102 @@ func TestIsPTRSupported(t *testing.T) {
 	cfg.ManagedDNSRecordTypes = append(cfg.ManagedDNSRecordTypes, endpoint.RecordTypePTR)
 	assert.True(t, cfg.IsPTRSupported())
 }
+
+func TestParseFlagsConflictResolver(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--conflict-resolver=namespace-priority",
+		"--conflict-resolver-namespace-priority=prod",
+		"--conflict-resolver-namespace-priority=staging",
+	)
+
+	assert.Equal(t, "namespace-priority", cfg.ConflictResolver)
+	assert.Equal(t, []string{"prod", "staging"}, cfg.ConflictResolverNamespacePriority)
+}
+
+func TestParseFlagsZoneApplyConcurrency(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--zone-apply-concurrency=4")
+
+	assert.Equal(t, 4, cfg.ZoneApplyConcurrency)
+}
+
+func TestParseFlagsProvidersConfig(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--providers-config=/etc/external-dns/providers.yaml")
+
+	assert.Equal(t, "/etc/external-dns/providers.yaml", cfg.ProvidersConfig)
+}
+
+func TestParseFlagsUnstructuredMappingConfig(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--unstructured-mapping-config=/etc/external-dns/mappings.yaml")
+
+	assert.Equal(t, "/etc/external-dns/mappings.yaml", cfg.UnstructuredMappingConfig)
+}
+
+func TestParseFlagsAudit(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--audit")
+
+	assert.True(t, cfg.Audit)
+}
+
+func TestParseFlagsDeletionThreshold(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--deletion-threshold-count=20",
+		"--deletion-threshold-percentage=10",
+		"--deletion-threshold-override",
+		"--deletion-grace-period=10m",
+	)
+
+	assert.Equal(t, 20, cfg.DeletionThresholdCount)
+	assert.Equal(t, 10, cfg.DeletionThresholdPercentage)
+	assert.True(t, cfg.DeletionThresholdOverride)
+	assert.Equal(t, 10*time.Minute, cfg.DeletionGracePeriod)
+}
+
+func TestParseFlagsPlanOutput(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--once",
+		"--plan-output=/tmp/plan.diff",
+		"--plan-output-format=diff",
+	)
+	assert.True(t, cfg.Once)
+	assert.Equal(t, "/tmp/plan.diff", cfg.PlanOutput)
+	assert.Equal(t, "diff", cfg.PlanOutputFormat)
+}
+
+func TestParseFlagsPlanApply(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--once", "--plan-apply=/tmp/plan.json")
+	assert.Equal(t, "/tmp/plan.json", cfg.PlanApply)
+}
+
+func TestParseFlagsReconcileBackoff(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t,
+		"--reconcile-backoff-initial=10s",
+		"--reconcile-backoff-max=2m",
+		"--reconcile-backoff-jitter=0.5",
+		"--reconcile-retry-budget=3",
+		"--soft-error-retry-initial=5s",
+	)
+
+	assert.Equal(t, 10*time.Second, cfg.ReconcileBackoffInitial)
+	assert.Equal(t, 2*time.Minute, cfg.ReconcileBackoffMax)
+	assert.InDelta(t, 0.5, cfg.ReconcileBackoffJitter, 0)
+	assert.Equal(t, 3, cfg.ReconcileRetryBudget)
+	assert.Equal(t, 5*time.Second, cfg.SoftErrorRetryInitial)
+}
+
+func TestParseFlagsFileSource(t *testing.T) {
+	t.Parallel()
+	cfg := parseCfg(t, "--source=file", "--file-source-dir=/etc/external-dns/records", "--file-source-interval=1m")
+
+	assert.Contains(t, cfg.Sources, "file")
+	assert.Equal(t, "/etc/external-dns/records", cfg.FileSourceDir)
+	assert.Equal(t, time.Minute, cfg.FileSourceInterval)
+}
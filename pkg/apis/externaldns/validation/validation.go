@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/registry/mapper"
 )
 
 // ValidateConfig performs validation on the Config object
@@ -47,6 +48,19 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if cfg.TXTNameTemplate != "" {
+		if len(cfg.TXTPrefix) > 0 || len(cfg.TXTSuffix) > 0 {
+			return errors.New("--txt-name-template is mutually exclusive with --txt-prefix and --txt-suffix")
+		}
+		if _, err := mapper.NewTemplateNameMapper(cfg.TXTNameTemplate, cfg.TXTWildcardReplacement); err != nil {
+			return fmt.Errorf("--txt-name-template: %w", err)
+		}
+	}
+
+	if cfg.TXTSharedOwnership && cfg.TXTConsolidated {
+		return errors.New("--txt-shared-ownership is mutually exclusive with --txt-consolidated")
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
@@ -74,6 +88,79 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("--create-ptr requires PTR in --managed-record-types")
 	}
 
+	if cfg.PlanOutput != "" && !cfg.Once {
+		return errors.New("--plan-output requires --once")
+	}
+	if cfg.PlanApply != "" && !cfg.Once {
+		return errors.New("--plan-apply requires --once")
+	}
+	if cfg.PlanApply != "" && cfg.PlanOutput != "" {
+		return errors.New("--plan-apply and --plan-output are mutually exclusive")
+	}
+
+	if cfg.ConflictResolver == "namespace-priority" && len(cfg.ConflictResolverNamespacePriority) == 0 {
+		return errors.New("--conflict-resolver-namespace-priority must be set when using --conflict-resolver=namespace-priority")
+	}
+
+	if cfg.DeletionThresholdCount < 0 {
+		return errors.New("--deletion-threshold-count must not be negative")
+	}
+	if cfg.DeletionThresholdPercentage < 0 || cfg.DeletionThresholdPercentage > 100 {
+		return errors.New("--deletion-threshold-percentage must be between 0 and 100")
+	}
+	if cfg.ZoneApplyConcurrency < 0 {
+		return errors.New("--zone-apply-concurrency must not be negative")
+	}
+	if cfg.Audit && (cfg.PlanOutput != "" || cfg.PlanApply != "") {
+		return errors.New("--audit cannot be used with --plan-output or --plan-apply")
+	}
+	if err := validateProvidersConfig(cfg); err != nil {
+		return err
+	}
+	if err := validateReconcileBackoff(cfg); err != nil {
+		return err
+	}
+	if err := validateMigrateRegistry(cfg); err != nil {
+		return err
+	}
+	if cfg.Registry == externaldns.RegistryConfigMap && cfg.ConfigMapRegistryShards < 1 {
+		return errors.New("--configmap-registry-shards must be at least 1")
+	}
+	if cfg.TXTOrphanMinAge < 0 {
+		return errors.New("--txt-orphan-min-age must not be negative")
+	}
+	if cfg.CRDRegistryVerifyInterval < 0 {
+		return errors.New("--crd-registry-verify-interval must not be negative")
+	}
+	if cfg.DeletionGracePeriod < 0 {
+		return errors.New("--deletion-grace-period must not be negative")
+	}
+	if cfg.DeletionGracePeriod > 0 && (cfg.Registry == externaldns.RegistryAWSSD || cfg.Registry == externaldns.RegistryNoop) {
+		return fmt.Errorf("--deletion-grace-period is not supported with --registry=%s", cfg.Registry)
+	}
+
+	if cfg.EnableLeaderElection {
+		if err := validateLeaderElection(cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateLeaderElection(cfg *externaldns.Config) error {
+	if cfg.LeaderElectionLeaseName == "" {
+		return errors.New("--leader-election-lease-name cannot be empty")
+	}
+	if cfg.LeaderElectionRetryPeriod <= 0 {
+		return errors.New("--leader-election-retry-period must be greater than 0")
+	}
+	if cfg.LeaderElectionRenewDeadline <= cfg.LeaderElectionRetryPeriod {
+		return errors.New("--leader-election-renew-deadline must be greater than --leader-election-retry-period")
+	}
+	if cfg.LeaderElectionLeaseDuration <= cfg.LeaderElectionRenewDeadline {
+		return errors.New("--leader-election-lease-duration must be greater than --leader-election-renew-deadline")
+	}
 	return nil
 }
 
@@ -128,3 +215,62 @@ func validateConfigForRfc2136(cfg *externaldns.Config) error {
 	}
 	return nil
 }
+
+func validateReconcileBackoff(cfg *externaldns.Config) error {
+	if cfg.ReconcileBackoffInitial < 0 || cfg.ReconcileBackoffMax < 0 || cfg.SoftErrorRetryInitial < 0 {
+		return errors.New("--reconcile-backoff-initial, --reconcile-backoff-max and --soft-error-retry-initial must not be negative")
+	}
+	if cfg.ReconcileBackoffInitial > cfg.ReconcileBackoffMax {
+		return errors.New("--reconcile-backoff-initial must not be greater than --reconcile-backoff-max")
+	}
+	if cfg.ReconcileBackoffJitter < 0 || cfg.ReconcileBackoffJitter > 1 {
+		return errors.New("--reconcile-backoff-jitter must be between 0 and 1")
+	}
+	if cfg.ReconcileRetryBudget < 0 {
+		return errors.New("--reconcile-retry-budget must not be negative")
+	}
+	return nil
+}
+
+func validateMigrateRegistry(cfg *externaldns.Config) error {
+	if cfg.MigrateRegistryTo == "" {
+		if cfg.MigrateRegistryDeleteSource {
+			return errors.New("--migrate-registry-delete-source requires --migrate-registry-to")
+		}
+		return nil
+	}
+	switch cfg.Registry {
+	case externaldns.RegistryTXT, externaldns.RegistryDynamoDB, externaldns.RegistryCRD, externaldns.RegistryConfigMap:
+	default:
+		return fmt.Errorf("--migrate-registry-to is not supported with --registry=%s", cfg.Registry)
+	}
+	if cfg.MigrateRegistryTo == cfg.Registry {
+		return errors.New("--migrate-registry-to must differ from --registry")
+	}
+	if cfg.MigrateRegistryDeleteSource && cfg.Registry != externaldns.RegistryTXT {
+		return errors.New("--migrate-registry-delete-source requires --registry=txt")
+	}
+	if cfg.PlanOutput != "" || cfg.PlanApply != "" || cfg.Audit || cfg.ProvidersConfig != "" {
+		return errors.New("--migrate-registry-to cannot be used with --plan-output, --plan-apply, --audit or --providers-config")
+	}
+	return nil
+}
+
+func validateProvidersConfig(cfg *externaldns.Config) error {
+	if cfg.ProvidersConfig == "" {
+		return nil
+	}
+	if cfg.PlanOutput != "" || cfg.PlanApply != "" {
+		return errors.New("--providers-config cannot be used with --plan-output or --plan-apply")
+	}
+	if cfg.WebhookServer {
+		return errors.New("--providers-config cannot be used with --webhook-server")
+	}
+	if cfg.Audit {
+		return errors.New("--providers-config cannot be used with --audit")
+	}
+	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
+		return errors.New("--providers-config cannot be used with --regex-domain-filter, set domainFilter per provider instead")
+	}
+	return nil
+}
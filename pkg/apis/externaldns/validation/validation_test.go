@@ -17,7 +17,9 @@ limitations under the License.
 package validation
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -386,3 +388,251 @@ func TestValidateCreatePTRWithPTRManagedPasses(t *testing.T) {
 	err := ValidateConfig(cfg)
 	assert.NoError(t, err)
 }
+
+func TestValidatePlanOutput(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.PlanOutput = "plan.json"
+	require.EqualError(t, ValidateConfig(cfg), "--plan-output requires --once")
+
+	cfg.Once = true
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidatePlanApply(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.PlanApply = "plan.json"
+	require.EqualError(t, ValidateConfig(cfg), "--plan-apply requires --once")
+
+	cfg.Once = true
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.PlanOutput = "plan.json"
+	require.EqualError(t, ValidateConfig(cfg), "--plan-apply and --plan-output are mutually exclusive")
+}
+
+func TestValidateTXTNameTemplate(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTNameTemplate = "%{record_type}.%{host}._owner.%{domain}"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.TXTPrefix = "txt-"
+	require.EqualError(t, ValidateConfig(cfg), "--txt-name-template is mutually exclusive with --txt-prefix and --txt-suffix")
+
+	cfg.TXTPrefix = ""
+	cfg.TXTNameTemplate = "%{host}._owner.%{domain}"
+	require.ErrorContains(t, ValidateConfig(cfg), "--txt-name-template: TXT name template")
+
+	cfg.TXTNameTemplate = "%{record_type}%{host}.%{domain}"
+	require.ErrorContains(t, ValidateConfig(cfg), "which maps back to")
+}
+
+func TestValidateTXTSharedOwnership(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTSharedOwnership = true
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.TXTConsolidated = true
+	require.EqualError(t, ValidateConfig(cfg), "--txt-shared-ownership is mutually exclusive with --txt-consolidated")
+}
+
+func TestValidateConflictResolver(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ConflictResolver = "namespace-priority"
+	require.EqualError(t, ValidateConfig(cfg), "--conflict-resolver-namespace-priority must be set when using --conflict-resolver=namespace-priority")
+
+	cfg.ConflictResolverNamespacePriority = []string{"prod"}
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateDeletionThreshold(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.DeletionThresholdCount = -1
+	require.EqualError(t, ValidateConfig(cfg), "--deletion-threshold-count must not be negative")
+
+	cfg.DeletionThresholdCount = 10
+	cfg.DeletionThresholdPercentage = 101
+	require.EqualError(t, ValidateConfig(cfg), "--deletion-threshold-percentage must be between 0 and 100")
+
+	cfg.DeletionThresholdPercentage = 50
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateZoneApplyConcurrency(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ZoneApplyConcurrency = -1
+	require.EqualError(t, ValidateConfig(cfg), "--zone-apply-concurrency must not be negative")
+
+	cfg.ZoneApplyConcurrency = 4
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateProvidersConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ProvidersConfig = "providers.yaml"
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.Once = true
+	cfg.PlanOutput = "-"
+	require.EqualError(t, ValidateConfig(cfg), "--providers-config cannot be used with --plan-output or --plan-apply")
+
+	cfg.PlanOutput = ""
+	cfg.WebhookServer = true
+	require.EqualError(t, ValidateConfig(cfg), "--providers-config cannot be used with --webhook-server")
+
+	cfg.WebhookServer = false
+	cfg.Audit = true
+	require.EqualError(t, ValidateConfig(cfg), "--providers-config cannot be used with --audit")
+
+	cfg.Audit = false
+	cfg.RegexDomainFilter = regexp.MustCompile(`example\.com$`)
+	require.EqualError(t, ValidateConfig(cfg), "--providers-config cannot be used with --regex-domain-filter, set domainFilter per provider instead")
+}
+
+func TestValidateAudit(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Audit = true
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.Once = true
+	cfg.PlanOutput = "-"
+	require.EqualError(t, ValidateConfig(cfg), "--audit cannot be used with --plan-output or --plan-apply")
+}
+
+func TestValidateReconcileBackoff(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ReconcileBackoffInitial = -time.Second
+	require.EqualError(t, ValidateConfig(cfg), "--reconcile-backoff-initial, --reconcile-backoff-max and --soft-error-retry-initial must not be negative")
+
+	cfg.ReconcileBackoffInitial = 10 * time.Minute
+	cfg.ReconcileBackoffMax = 5 * time.Minute
+	require.EqualError(t, ValidateConfig(cfg), "--reconcile-backoff-initial must not be greater than --reconcile-backoff-max")
+
+	cfg.ReconcileBackoffInitial = 10 * time.Second
+	cfg.ReconcileBackoffJitter = 1.5
+	require.EqualError(t, ValidateConfig(cfg), "--reconcile-backoff-jitter must be between 0 and 1")
+
+	cfg.ReconcileBackoffJitter = 0.1
+	cfg.ReconcileRetryBudget = -1
+	require.EqualError(t, ValidateConfig(cfg), "--reconcile-retry-budget must not be negative")
+
+	cfg.ReconcileRetryBudget = 5
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateDeletionGracePeriod(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.DeletionGracePeriod = -time.Minute
+	require.EqualError(t, ValidateConfig(cfg), "--deletion-grace-period must not be negative")
+
+	cfg.DeletionGracePeriod = 10 * time.Minute
+	cfg.Registry = externaldns.RegistryNoop
+	require.EqualError(t, ValidateConfig(cfg), "--deletion-grace-period is not supported with --registry=noop")
+
+	cfg.Registry = externaldns.RegistryTXT
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateLeaderElection(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		modify  func(cfg *externaldns.Config)
+		wantErr string
+	}{
+		{
+			name:   "valid durations",
+			modify: func(_ *externaldns.Config) {},
+		},
+		{
+			name:    "empty lease name",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionLeaseName = "" },
+			wantErr: "--leader-election-lease-name cannot be empty",
+		},
+		{
+			name:    "zero retry period",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionRetryPeriod = 0 },
+			wantErr: "--leader-election-retry-period must be greater than 0",
+		},
+		{
+			name:    "renew deadline not greater than retry period",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionRenewDeadline = cfg.LeaderElectionRetryPeriod },
+			wantErr: "--leader-election-renew-deadline must be greater than --leader-election-retry-period",
+		},
+		{
+			name:    "lease duration not greater than renew deadline",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionLeaseDuration = cfg.LeaderElectionRenewDeadline },
+			wantErr: "--leader-election-lease-duration must be greater than --leader-election-renew-deadline",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.EnableLeaderElection = true
+			cfg.LeaderElectionLeaseName = "external-dns"
+			cfg.LeaderElectionLeaseDuration = 15 * time.Second
+			cfg.LeaderElectionRenewDeadline = 10 * time.Second
+			cfg.LeaderElectionRetryPeriod = 2 * time.Second
+			tt.modify(cfg)
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateMigrateRegistry(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.MigrateRegistryDeleteSource = true
+	require.EqualError(t, ValidateConfig(cfg), "--migrate-registry-delete-source requires --migrate-registry-to")
+
+	cfg.Registry = externaldns.RegistryTXT
+	cfg.MigrateRegistryTo = externaldns.RegistryCRD
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.MigrateRegistryTo = externaldns.RegistryTXT
+	require.EqualError(t, ValidateConfig(cfg), "--migrate-registry-to must differ from --registry")
+
+	cfg.Registry = externaldns.RegistryNoop
+	require.EqualError(t, ValidateConfig(cfg), "--migrate-registry-to is not supported with --registry=noop")
+
+	cfg.Registry = externaldns.RegistryDynamoDB
+	require.EqualError(t, ValidateConfig(cfg), "--migrate-registry-delete-source requires --registry=txt")
+
+	cfg.MigrateRegistryDeleteSource = false
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.Audit = true
+	require.EqualError(t, ValidateConfig(cfg), "--migrate-registry-to cannot be used with --plan-output, --plan-apply, --audit or --providers-config")
+}
+
+func TestValidateConfigMapRegistryShards(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Registry = externaldns.RegistryConfigMap
+	cfg.ConfigMapRegistryShards = 2
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg.ConfigMapRegistryShards = 0
+	require.EqualError(t, ValidateConfig(cfg), "--configmap-registry-shards must be at least 1")
+}
+
+func TestValidateTXTOrphanMinAge(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTOrphanCleanup = externaldns.TXTOrphanCleanupDelete
+	cfg.TXTOrphanMinAge = -time.Minute
+	require.EqualError(t, ValidateConfig(cfg), "--txt-orphan-min-age must not be negative")
+
+	cfg.TXTOrphanMinAge = 0
+	require.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateCRDRegistryVerifyInterval(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Registry = externaldns.RegistryCRD
+	cfg.CRDRegistryVerifyInterval = -time.Minute
+	require.EqualError(t, ValidateConfig(cfg), "--crd-registry-verify-interval must not be negative")
+
+	cfg.CRDRegistryVerifyInterval = 10 * time.Minute
+	require.NoError(t, ValidateConfig(cfg))
+}
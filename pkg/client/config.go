@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -35,6 +36,12 @@ import (
 	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 )
 
+const (
+	// serviceAccountNamespaceFile is where the in-cluster namespace is mounted.
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	defaultNamespace            = "default"
+)
+
 // InstrumentedRESTConfig builds a REST config with Prometheus transport metrics, request timeout,
 // and a token-bucket rate limiter. When qps > 0, it overrides the client-go defaults (5 QPS / 10 burst).
 func InstrumentedRESTConfig(
@@ -75,6 +82,20 @@ func NewKubeClient(config *rest.Config) (kubernetes.Interface, error) {
 	return client, nil
 }
 
+// InClusterNamespace returns the configured namespace, falling back to the
+// namespace of the in-cluster service account and finally to "default".
+func InClusterNamespace(namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if ns := strings.TrimSpace(string(data)); ns != "" {
+			return ns
+		}
+	}
+	return defaultNamespace
+}
+
 // buildRestConfig returns the REST client configuration for Kubernetes API access.
 // Supports both in-cluster and external cluster configurations.
 //
@@ -45,6 +45,12 @@ func TestGetRestConfig_WithKubeConfig(t *testing.T) {
 	assert.Equal(t, svr.URL, config.Host)
 }
 
+func TestInClusterNamespace(t *testing.T) {
+	assert.Equal(t, "kube-system", InClusterNamespace("kube-system"))
+	// outside a cluster no service account namespace is mounted
+	assert.Equal(t, defaultNamespace, InClusterNamespace(""))
+}
+
 func TestNewKubeClient(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
 	defer svr.Close()
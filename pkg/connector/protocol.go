@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connector implements the streaming protocol of the connector source, along with a reference server.
+//
+// The connector source sends a GET request to the server, which answers with a long-lived stream of
+// newline-delimited JSON messages. The first message is a snapshot of the desired endpoints, and the following
+// messages are the upserts and deletions of endpoints, identified by their DNS name, record type and set identifier.
+// The server sends heartbeats when there are no changes, so that the source detects broken connections.
+// On a new connection, the server sends a new snapshot which replaces the endpoints of the source.
+package connector
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// ContentType is the media type of the stream.
+	ContentType = "application/x-ndjson"
+)
+
+// MessageType is the type of a message of the stream.
+type MessageType string
+
+const (
+	// MessageSnapshot replaces all the endpoints.
+	MessageSnapshot MessageType = "snapshot"
+	// MessageUpsert creates the endpoints, or replaces the endpoints with the same key.
+	MessageUpsert MessageType = "upsert"
+	// MessageDelete deletes the endpoints with the same key, only their key is used.
+	MessageDelete MessageType = "delete"
+	// MessageHeartbeat keeps the stream alive, it has no endpoints.
+	MessageHeartbeat MessageType = "heartbeat"
+)
+
+// Message is a message of the stream.
+type Message struct {
+	Type      MessageType          `json:"type"`
+	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
+}
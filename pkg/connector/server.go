@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// DefaultHeartbeatInterval is the interval of the heartbeats sent on idle streams.
+	DefaultHeartbeatInterval = 30 * time.Second
+
+	// subscriberBuffer is the number of messages buffered per stream. A stream falling further
+	// behind is closed, and its source reconnects to receive a new snapshot.
+	subscriberBuffer = 64
+)
+
+// Server serves the desired endpoints to the connector sources over streams, and pushes their changes.
+// It is an http.Handler, to be served with TLS and client certificates, see tlsutils.NewServerTLSConfig.
+type Server struct {
+	heartbeatInterval time.Duration
+
+	mu          sync.Mutex
+	endpoints   map[endpoint.EndpointKey]*endpoint.Endpoint
+	subscribers map[chan Message]struct{}
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithHeartbeatInterval sets the interval of the heartbeats, DefaultHeartbeatInterval by default.
+// The connector source closes the streams which are idle for two minutes.
+func WithHeartbeatInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.heartbeatInterval = interval
+	}
+}
+
+// NewServer creates a Server without endpoints.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		heartbeatInterval: DefaultHeartbeatInterval,
+		endpoints:         map[endpoint.EndpointKey]*endpoint.Endpoint{},
+		subscribers:       map[chan Message]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Set replaces the desired endpoints, and pushes the endpoints which changed.
+func (s *Server) Set(endpoints []*endpoint.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	desired := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(endpoints))
+	var upserts, deletes []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		key := ep.Key()
+		desired[key] = ep.DeepCopy()
+		if current, ok := s.endpoints[key]; !ok || !sameEndpoint(current, ep) {
+			upserts = append(upserts, ep.DeepCopy())
+		}
+	}
+	for key, ep := range s.endpoints {
+		if _, ok := desired[key]; !ok {
+			deletes = append(deletes, ep)
+		}
+	}
+	s.endpoints = desired
+
+	if len(upserts) > 0 {
+		s.broadcast(Message{Type: MessageUpsert, Endpoints: upserts})
+	}
+	if len(deletes) > 0 {
+		s.broadcast(Message{Type: MessageDelete, Endpoints: deletes})
+	}
+}
+
+// Upsert creates the endpoints, or replaces the endpoints with the same key, and pushes them.
+func (s *Server) Upsert(endpoints ...*endpoint.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upserts := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		s.endpoints[ep.Key()] = ep.DeepCopy()
+		upserts = append(upserts, ep.DeepCopy())
+	}
+	if len(upserts) > 0 {
+		s.broadcast(Message{Type: MessageUpsert, Endpoints: upserts})
+	}
+}
+
+// Delete deletes the endpoints with the same key, and pushes their deletion.
+func (s *Server) Delete(endpoints ...*endpoint.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deletes []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if current, ok := s.endpoints[ep.Key()]; ok {
+			delete(s.endpoints, ep.Key())
+			deletes = append(deletes, current)
+		}
+	}
+	if len(deletes) > 0 {
+		s.broadcast(Message{Type: MessageDelete, Endpoints: deletes})
+	}
+}
+
+// ServeHTTP streams the snapshot of the endpoints, then their changes, until the request is canceled.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	snapshot, messages := s.subscribe()
+	defer s.unsubscribe(messages)
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	send := func(message Message) bool {
+		if err := encoder.Encode(message); err != nil {
+			log.Debugf("Closing the connector stream of %s: %v", r.RemoteAddr, err)
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	if !send(snapshot) {
+		return
+	}
+	heartbeat := time.NewTicker(s.heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				log.Warnf("Closing the connector stream of %s, it is too slow to receive the changes", r.RemoteAddr)
+				return
+			}
+			if !send(message) {
+				return
+			}
+			heartbeat.Reset(s.heartbeatInterval)
+		case <-heartbeat.C:
+			if !send(Message{Type: MessageHeartbeat}) {
+				return
+			}
+		}
+	}
+}
+
+// subscribe returns the snapshot of the endpoints along with the channel of their changes, atomically.
+func (s *Server) subscribe() (Message, chan Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(s.endpoints), compareKeys)
+	snapshot := Message{Type: MessageSnapshot, Endpoints: make([]*endpoint.Endpoint, 0, len(keys))}
+	for _, key := range keys {
+		snapshot.Endpoints = append(snapshot.Endpoints, s.endpoints[key])
+	}
+	messages := make(chan Message, subscriberBuffer)
+	s.subscribers[messages] = struct{}{}
+	return snapshot, messages
+}
+
+func (s *Server) unsubscribe(messages chan Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[messages]; ok {
+		delete(s.subscribers, messages)
+		close(messages)
+	}
+}
+
+// broadcast sends the message to the streams, closing the streams whose buffer is full.
+// It must be called with mu held.
+func (s *Server) broadcast(message Message) {
+	for messages := range s.subscribers {
+		select {
+		case messages <- message:
+		default:
+			delete(s.subscribers, messages)
+			close(messages)
+		}
+	}
+}
+
+func sameEndpoint(a, b *endpoint.Endpoint) bool {
+	return a.Targets.Same(b.Targets) &&
+		a.RecordTTL == b.RecordTTL &&
+		maps.Equal(a.Labels, b.Labels) &&
+		slices.Equal(a.ProviderSpecific, b.ProviderSpecific)
+}
+
+func compareKeys(a, b endpoint.EndpointKey) int {
+	return cmp.Or(
+		strings.Compare(a.DNSName, b.DNSName),
+		strings.Compare(a.RecordType, b.RecordType),
+		strings.Compare(a.SetIdentifier, b.SetIdentifier),
+	)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// openStream connects to the server and returns the decoder of its messages.
+func openStream(t *testing.T, url string) *json.Decoder {
+	t.Helper()
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, ContentType, response.Header.Get("Content-Type"))
+	return json.NewDecoder(response.Body)
+}
+
+func nextMessage(t *testing.T, decoder *json.Decoder) Message {
+	t.Helper()
+	var message Message
+	require.NoError(t, decoder.Decode(&message))
+	return message
+}
+
+func dnsNames(message Message) []string {
+	names := make([]string, 0, len(message.Endpoints))
+	for _, ep := range message.Endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
+
+func TestServerStream(t *testing.T) {
+	server := NewServer()
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	})
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	decoder := openStream(t, httpServer.URL)
+
+	snapshot := nextMessage(t, decoder)
+	assert.Equal(t, MessageSnapshot, snapshot.Type)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, dnsNames(snapshot))
+
+	server.Upsert(endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeCNAME, "lb.example.com"))
+	upsert := nextMessage(t, decoder)
+	assert.Equal(t, MessageUpsert, upsert.Type)
+	require.Len(t, upsert.Endpoints, 1)
+	assert.Equal(t, "c.example.com", upsert.Endpoints[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, upsert.Endpoints[0].Targets)
+
+	// the endpoints which do not exist are not pushed
+	server.Delete(endpoint.NewEndpoint("missing.example.com", endpoint.RecordTypeA))
+	server.Delete(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA))
+	deletion := nextMessage(t, decoder)
+	assert.Equal(t, MessageDelete, deletion.Type)
+	assert.Equal(t, []string{"a.example.com"}, dnsNames(deletion))
+
+	// a new stream receives the current endpoints
+	snapshot = nextMessage(t, openStream(t, httpServer.URL))
+	assert.Equal(t, MessageSnapshot, snapshot.Type)
+	assert.Equal(t, []string{"b.example.com", "c.example.com"}, dnsNames(snapshot))
+}
+
+func TestServerSet(t *testing.T) {
+	server := NewServer()
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("kept.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+		endpoint.NewEndpoint("deleted.example.com", endpoint.RecordTypeA, "10.0.0.3"),
+	})
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	decoder := openStream(t, httpServer.URL)
+	assert.Len(t, nextMessage(t, decoder).Endpoints, 3)
+
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("kept.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpointWithTTL("changed.example.com", endpoint.RecordTypeA, 60, "10.0.0.2"),
+		endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeA, "10.0.0.4"),
+	})
+
+	upsert := nextMessage(t, decoder)
+	assert.Equal(t, MessageUpsert, upsert.Type)
+	assert.ElementsMatch(t, []string{"changed.example.com", "created.example.com"}, dnsNames(upsert))
+	deletion := nextMessage(t, decoder)
+	assert.Equal(t, MessageDelete, deletion.Type)
+	assert.Equal(t, []string{"deleted.example.com"}, dnsNames(deletion))
+
+	// no change, no message
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("kept.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpointWithTTL("changed.example.com", endpoint.RecordTypeA, 60, "10.0.0.2"),
+		endpoint.NewEndpoint("created.example.com", endpoint.RecordTypeA, "10.0.0.4"),
+	})
+	server.Upsert(endpoint.NewEndpoint("marker.example.com", endpoint.RecordTypeA, "10.0.0.5"))
+	assert.Equal(t, []string{"marker.example.com"}, dnsNames(nextMessage(t, decoder)))
+}
+
+func TestServerHeartbeat(t *testing.T) {
+	httpServer := httptest.NewServer(NewServer(WithHeartbeatInterval(10 * time.Millisecond)))
+	t.Cleanup(httpServer.Close)
+
+	decoder := openStream(t, httpServer.URL)
+	snapshot := nextMessage(t, decoder)
+	assert.Equal(t, MessageSnapshot, snapshot.Type)
+	assert.Empty(t, snapshot.Endpoints)
+	assert.Equal(t, Message{Type: MessageHeartbeat}, nextMessage(t, decoder))
+}
+
+func TestServerSlowSubscriber(t *testing.T) {
+	server := NewServer()
+	snapshot, messages := server.subscribe()
+	assert.Equal(t, MessageSnapshot, snapshot.Type)
+
+	for range subscriberBuffer + 1 {
+		server.Upsert(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"))
+	}
+	for range subscriberBuffer {
+		_, ok := <-messages
+		require.True(t, ok)
+	}
+	_, ok := <-messages
+	assert.False(t, ok, "the messages of a subscriber falling behind are closed")
+	assert.Empty(t, server.subscribers)
+
+	// unsubscribing a closed subscriber is a no-op
+	server.unsubscribe(messages)
+}
+
+func TestServerMethodNotAllowed(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewServer().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
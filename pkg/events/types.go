@@ -44,6 +44,15 @@ const (
 	RecordReady   Reason = "RecordReady"
 	RecordDeleted Reason = "RecordDeleted"
 	RecordError   Reason = "RecordError"
+	// RecordConflict is emitted when several resources claim the same DNS name
+	// and the configured conflict resolver refuses to pick one of them.
+	RecordConflict Reason = "RecordConflict"
+	// RecordDeletionBlocked is emitted when a run is blocked because it would
+	// delete more records than the deletion threshold allows.
+	RecordDeletionBlocked Reason = "RecordDeletionBlocked"
+	// RecordAdopted is emitted when a resource adopts an existing record which
+	// had no owner, see the adopt annotation.
+	RecordAdopted Reason = "RecordAdopted"
 
 	EventTypeNormal  EventType = EventType(apiv1.EventTypeNormal)
 	EventTypeWarning EventType = EventType(apiv1.EventTypeWarning)
@@ -80,6 +89,8 @@ type (
 		name       string
 		uid        types.UID
 		source     string
+		// creationTimestamp is the creation time of the referenced object, zero when unknown.
+		creationTimestamp time.Time
 	}
 
 	Config struct {
@@ -118,6 +129,8 @@ func NewObjectReference(obj runtime.Object, source string) *ObjectReference {
 		name:       obj.GetName(),
 		uid:        obj.GetUID(),
 		source:     source,
+
+		creationTimestamp: obj.GetCreationTimestamp().Time,
 	}
 }
 
@@ -134,6 +147,15 @@ func NewEvent(obj *ObjectReference, msg string, a Action, r Reason) Event {
 	}
 }
 
+// NewWarningEvent creates a Warning Event for the given object.
+func NewWarningEvent(obj *ObjectReference, msg string, a Action, r Reason) Event {
+	e := NewEvent(obj, msg, a, r)
+	if len(e.refs) > 0 {
+		e.eType = EventTypeWarning
+	}
+	return e
+}
+
 // NewEventFromEndpoint creates an Event from an EndpointInfo with formatted message.
 // All ref objects on the endpoint are stored in the event; one Kubernetes event is
 // emitted per ref when the event is processed by the Controller.
@@ -162,6 +184,15 @@ func NewEventFromEndpoint(ep EndpointInfo, a Action, r Reason) Event {
 	}
 }
 
+// NewWarningEventFromEndpoint creates a Warning Event from an EndpointInfo with formatted message.
+func NewWarningEventFromEndpoint(ep EndpointInfo, a Action, r Reason) Event {
+	e := NewEventFromEndpoint(ep, a, r)
+	if len(e.refs) > 0 {
+		e.eType = EventTypeWarning
+	}
+	return e
+}
+
 // Action returns the action associated with the event (e.g. Created, Updated, Deleted).
 func (e *Event) Action() Action {
 	return e.action
@@ -265,7 +296,7 @@ func WithEmitEvents(events []string) ConfigOption {
 		if len(events) > 0 {
 			c.emitEvents = sets.New[Reason]()
 			for _, event := range events {
-				if slices.Contains([]string{string(RecordReady), string(RecordError)}, event) {
+				if slices.Contains([]string{string(RecordReady), string(RecordError), string(RecordConflict), string(RecordDeletionBlocked), string(RecordAdopted)}, event) {
 					c.emitEvents.Insert(Reason(event))
 				}
 			}
@@ -325,6 +356,12 @@ func (r *ObjectReference) UID() types.UID {
 	return r.uid
 }
 
+// CreationTimestamp returns the creation time of the referenced Kubernetes object.
+// The zero time is returned when it is unknown.
+func (r *ObjectReference) CreationTimestamp() time.Time {
+	return r.creationTimestamp
+}
+
 func (r *ObjectReference) objectRef() *apiv1.ObjectReference {
 	return &apiv1.ObjectReference{
 		Kind:       r.kind,
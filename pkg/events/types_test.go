@@ -225,6 +225,24 @@ func TestWithEmitEvents(t *testing.T) {
 				require.True(t, c.IsEnabled())
 			},
 		},
+		{
+			name:     "conflict event",
+			input:    []string{string(RecordConflict)},
+			expected: sets.New(RecordConflict),
+			assert: func(c *Config) {
+				require.Equal(t, sets.New(RecordConflict), c.emitEvents)
+				require.True(t, c.IsEnabled())
+			},
+		},
+		{
+			name:     "adopted event",
+			input:    []string{string(RecordAdopted)},
+			expected: sets.New(RecordAdopted),
+			assert: func(c *Config) {
+				require.Equal(t, sets.New(RecordAdopted), c.emitEvents)
+				require.True(t, c.IsEnabled())
+			},
+		},
 		{
 			name:     "invalid event",
 			input:    []string{"InvalidEvent"},
@@ -573,6 +591,44 @@ func TestEvent_Accessors(t *testing.T) {
 	assert.Equal(t, EventTypeNormal, ev.EventType())
 }
 
+func TestNewWarningEventFromEndpoint(t *testing.T) {
+	ep := &mockEndpointInfo{
+		dnsName:    "foo.example.com",
+		recordType: "A",
+		targets:    []string{"1.2.3.4"},
+		refObjects: []*ObjectReference{{kind: "Ingress", namespace: "default", name: "foo"}},
+	}
+	ev := NewWarningEventFromEndpoint(ep, ActionFailed, RecordConflict)
+	assert.Equal(t, EventTypeWarning, ev.EventType())
+	assert.Equal(t, RecordConflict, ev.Reason())
+	assert.Equal(t, ActionFailed, ev.Action())
+
+	assert.Equal(t, Event{}, NewWarningEventFromEndpoint(&mockEndpointInfo{dnsName: "foo.example.com"}, ActionFailed, RecordConflict))
+}
+
+func TestNewWarningEvent(t *testing.T) {
+	obj := NewObjectReferenceFromParts("Pod", "v1", "default", "external-dns", "", "controller")
+	ev := NewWarningEvent(obj, "blocked", ActionFailed, RecordDeletionBlocked)
+	assert.Equal(t, EventTypeWarning, ev.EventType())
+	assert.Equal(t, RecordDeletionBlocked, ev.Reason())
+
+	assert.Equal(t, Event{}, NewWarningEvent(nil, "blocked", ActionFailed, RecordDeletionBlocked))
+}
+
+func TestObjectReference_CreationTimestamp(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ref := NewObjectReference(&apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "my-pod",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}, "pod")
+	assert.True(t, created.Equal(ref.CreationTimestamp()))
+
+	assert.True(t, NewObjectReferenceFromParts("Pod", "v1", "default", "my-pod", "", "pod").CreationTimestamp().IsZero())
+}
+
 func TestObjectReference_Key(t *testing.T) {
 	tests := []struct {
 		name     string
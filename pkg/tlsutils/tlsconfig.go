@@ -74,6 +74,30 @@ func NewTLSConfig(certPath, keyPath, caPath, serverName string, insecure bool, m
 	}, nil
 }
 
+// NewServerTLSConfig creates a tls.Config instance for a server, loading its cert and key from disk.
+// When clientCAPath is set, the clients must present a certificate signed by this certificate authority.
+func NewServerTLSConfig(certPath, keyPath, clientCAPath string, minVersion uint16) (*tls.Config, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, errors.New("both cert and key must be provided")
+	}
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load TLS cert: %w", err)
+	}
+	config := &tls.Config{
+		MinVersion:   minVersion,
+		Certificates: []tls.Certificate{cert},
+	}
+	if clientCAPath != "" {
+		config.ClientCAs, err = loadRoots(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
 // loads CA cert
 func loadRoots(caPath string) (*x509.CertPool, error) {
 	roots := x509.NewCertPool()
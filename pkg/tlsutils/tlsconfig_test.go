@@ -193,3 +193,32 @@ func TestCreateTLSConfig(t *testing.T) {
 	}
 
 }
+
+func TestNewServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert")
+	keyPath := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(certPath, []byte(rsaCertPEM), 0644))
+	require.NoError(t, os.WriteFile(keyPath, []byte(rsaKeyPEM), 0644))
+
+	config, err := NewServerTLSConfig(certPath, keyPath, "", tls.VersionTLS13)
+	require.NoError(t, err)
+	assert.Len(t, config.Certificates, 1)
+	assert.Nil(t, config.ClientCAs)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+
+	config, err = NewServerTLSConfig(certPath, keyPath, certPath, defaultMinVersion)
+	require.NoError(t, err)
+	assert.NotNil(t, config.ClientCAs)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	_, err = NewServerTLSConfig(certPath, "", "", defaultMinVersion)
+	require.ErrorContains(t, err, "both cert and key must be provided")
+
+	_, err = NewServerTLSConfig(certPath, certPath, "", defaultMinVersion)
+	require.ErrorContains(t, err, "could not load TLS cert")
+
+	_, err = NewServerTLSConfig(certPath, keyPath, keyPath, defaultMinVersion)
+	require.ErrorContains(t, err, "could not parse PEM certificates from")
+}
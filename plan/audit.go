@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"cmp"
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/idna"
+)
+
+// AuditStatus classifies a current record by ownership and by whether it matches the desired state.
+type AuditStatus string
+
+const (
+	// AuditInSync is an owned record matching its desired endpoint.
+	AuditInSync AuditStatus = "in-sync"
+	// AuditDrifted is an owned record that would be updated to match its desired endpoint.
+	AuditDrifted AuditStatus = "drifted"
+	// AuditColliding is a record owned by someone else, or nobody, with the name of a desired endpoint.
+	AuditColliding AuditStatus = "colliding"
+	// AuditOrphaned is an owned record without a desired endpoint.
+	AuditOrphaned AuditStatus = "orphaned"
+)
+
+// AuditStatuses lists all audit statuses.
+var AuditStatuses = []AuditStatus{AuditInSync, AuditDrifted, AuditColliding, AuditOrphaned}
+
+// AuditRecord is the classification of a current record.
+type AuditRecord struct {
+	DNSName       string      `json:"dnsName"`
+	RecordType    string      `json:"recordType"`
+	SetIdentifier string      `json:"setIdentifier,omitempty"`
+	Owner         string      `json:"owner,omitempty"`
+	Status        AuditStatus `json:"status"`
+}
+
+// Audit classifies the current records managed by the plan without applying any change.
+// Drift is detected by calculating the plan with the sync policy, regardless of Policies,
+// so that it is reported even by policies not allowing updates or blocking deletions.
+// Records that are neither owned nor desired are left out.
+func (p *Plan) Audit() []AuditRecord {
+	syncPlan := *p
+	syncPlan.Policies = []Policy{&SyncPolicy{}}
+	changes := syncPlan.Calculate().Changes
+
+	domainFilter := p.DomainFilter
+	if domainFilter == nil {
+		domainFilter = endpoint.MatchAllDomainFilters(nil)
+	}
+	desired := map[endpoint.EndpointKey]bool{}
+	for _, ep := range filterRecordsForPlan(p.Desired, domainFilter, p.ManagedRecords, p.ExcludeRecords) {
+		desired[auditKey(ep)] = true
+	}
+	drifted := map[endpoint.EndpointKey]bool{}
+	for _, ep := range changes.UpdateOld {
+		drifted[auditKey(ep)] = true
+	}
+
+	var records []AuditRecord
+	for _, current := range filterRecordsForPlan(p.Current, domainFilter, p.ManagedRecords, p.ExcludeRecords) {
+		key := auditKey(current)
+		owned := p.OwnerID == "" || current.IsOwnedBy(p.OwnerID)
+
+		var status AuditStatus
+		switch {
+		case owned && !desired[key]:
+			status = AuditOrphaned
+		case owned && drifted[key]:
+			status = AuditDrifted
+		case owned:
+			status = AuditInSync
+		case desired[key]:
+			status = AuditColliding
+		default:
+			continue
+		}
+		records = append(records, AuditRecord{
+			DNSName:       current.DNSName,
+			RecordType:    current.RecordType,
+			SetIdentifier: current.SetIdentifier,
+			Owner:         current.Labels[endpoint.OwnerLabelKey],
+			Status:        status,
+		})
+	}
+
+	slices.SortFunc(records, func(a, b AuditRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+	return records
+}
+
+func auditKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	return endpoint.EndpointKey{
+		DNSName:       idna.NormalizeDNSName(ep.DNSName),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+}
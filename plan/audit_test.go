@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestAudit(t *testing.T) {
+	owned := func(name, target string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeA, target).WithLabel(endpoint.OwnerLabelKey, "me")
+	}
+
+	p := &Plan{
+		// the policy does not allow updates, which must not hide drift
+		Policies: []Policy{&CreateOnlyPolicy{}},
+		Current: []*endpoint.Endpoint{
+			owned("in-sync.example.com", "1.1.1.1"),
+			owned("drifted.example.com", "1.1.1.1"),
+			owned("orphaned.example.com", "1.1.1.1"),
+			endpoint.NewEndpoint("colliding.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.OwnerLabelKey, "other"),
+			endpoint.NewEndpoint("foreign.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.OwnerLabelKey, "other"),
+			owned("filtered.example.org", "1.1.1.1"),
+		},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("in-sync.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("drifted.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("colliding.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		},
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"})},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "me",
+	}
+
+	assert.Equal(t, []AuditRecord{
+		{DNSName: "colliding.example.com", RecordType: "A", Owner: "other", Status: AuditColliding},
+		{DNSName: "drifted.example.com", RecordType: "A", Owner: "me", Status: AuditDrifted},
+		{DNSName: "in-sync.example.com", RecordType: "A", Owner: "me", Status: AuditInSync},
+		{DNSName: "orphaned.example.com", RecordType: "A", Owner: "me", Status: AuditOrphaned},
+	}, p.Audit())
+}
+
+func TestAuditWithoutOwnerID(t *testing.T) {
+	p := &Plan{
+		Current:        []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.1.1.1")},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}
+
+	assert.Equal(t, []AuditRecord{
+		{DNSName: "foo.example.com", RecordType: "A", Status: AuditOrphaned},
+	}, p.Audit())
+}
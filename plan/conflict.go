@@ -18,6 +18,8 @@ package plan
 
 import (
 	"slices"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -32,6 +34,33 @@ type ConflictResolver interface {
 	ResolveRecordTypes(key planKey, row *planTableRow) map[string]*domainEndpoints
 }
 
+// ConflictResolverConfig holds the settings used to build a ConflictResolver.
+type ConflictResolverConfig struct {
+	// NamespacePriority lists namespaces in descending order of priority, used by NamespacePriority.
+	NamespacePriority []string
+}
+
+// ConflictResolvers is a registry of available conflict resolvers, keyed by name.
+var ConflictResolvers = map[string]func(cfg ConflictResolverConfig) ConflictResolver{
+	"per-resource": func(ConflictResolverConfig) ConflictResolver { return PerResource{} },
+	"oldest-resource": func(ConflictResolverConfig) ConflictResolver {
+		return OldestResource{}
+	},
+	"namespace-priority": func(cfg ConflictResolverConfig) ConflictResolver {
+		return NewNamespacePriority(cfg.NamespacePriority)
+	},
+	"refuse": func(ConflictResolverConfig) ConflictResolver { return RefuseConflicts{} },
+}
+
+// Conflict describes desired endpoints of different resources claiming the same DNS name
+// for which the ConflictResolver refused to pick a winner.
+type Conflict struct {
+	// Current is the record currently occupying the DNS name, nil if there is none.
+	Current *endpoint.Endpoint
+	// Candidates are the desired endpoints claiming the DNS name.
+	Candidates []*endpoint.Endpoint
+}
+
 // PerResource allows only one resource to own a given dns name
 type PerResource struct{}
 
@@ -110,4 +139,144 @@ func compareEndpoints(a, b *endpoint.Endpoint) int {
 	return 0
 }
 
-// TODO: with cross-resource/cross-cluster setup alternative variations of ConflictResolver can be used
+// OldestResource gives a DNS name to the resource that was created first, based on the
+// creation timestamp of the Kubernetes objects an endpoint was derived from.
+// Endpoints without a known creation timestamp lose against those with one.
+// Ties are broken the same way as PerResource.
+type OldestResource struct {
+	PerResource
+}
+
+// ResolveCreate picks the endpoint of the oldest resource.
+func (s OldestResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.PerResource.ResolveCreate(bestCandidates(candidates, compareCreationTimestamps))
+}
+
+// ResolveUpdate picks the endpoint of the oldest resource, keeping the current owner on ties.
+func (s OldestResource) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.PerResource.ResolveUpdate(current, bestCandidates(candidates, compareCreationTimestamps))
+}
+
+// NamespacePriority gives a DNS name to the resource whose namespace comes first in a priority list.
+// Resources in namespaces that are not listed have the lowest priority.
+// Ties are broken the same way as PerResource.
+type NamespacePriority struct {
+	PerResource
+	priority map[string]int
+}
+
+// NewNamespacePriority returns a NamespacePriority resolver for namespaces given in descending order of priority.
+func NewNamespacePriority(namespaces []string) NamespacePriority {
+	priority := make(map[string]int, len(namespaces))
+	for i, ns := range namespaces {
+		if _, ok := priority[ns]; !ok {
+			priority[ns] = i
+		}
+	}
+	return NamespacePriority{priority: priority}
+}
+
+// ResolveCreate picks the endpoint from the namespace with the highest priority.
+func (s NamespacePriority) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.PerResource.ResolveCreate(bestCandidates(candidates, s.compare))
+}
+
+// ResolveUpdate picks the endpoint from the namespace with the highest priority,
+// keeping the current owner on ties.
+func (s NamespacePriority) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.PerResource.ResolveUpdate(current, bestCandidates(candidates, s.compare))
+}
+
+func (s NamespacePriority) compare(a, b *endpoint.Endpoint) int {
+	return s.rank(a) - s.rank(b)
+}
+
+func (s NamespacePriority) rank(ep *endpoint.Endpoint) int {
+	best := len(s.priority)
+	for _, ns := range endpointNamespaces(ep) {
+		if p, ok := s.priority[ns]; ok && p < best {
+			best = p
+		}
+	}
+	return best
+}
+
+// RefuseConflicts does not pick a winner when endpoints of different resources claim the same DNS name.
+// The DNS name is left untouched and the conflict is reported on the calculated Plan so that
+// a warning can be emitted for every resource involved.
+type RefuseConflicts struct {
+	PerResource
+}
+
+// ResolveCreate returns nil if the candidates belong to more than one resource.
+func (s RefuseConflicts) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	if hasMultipleResources(candidates) {
+		return nil
+	}
+	return s.PerResource.ResolveCreate(candidates)
+}
+
+// ResolveUpdate returns nil if the candidates belong to more than one resource.
+func (s RefuseConflicts) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	if hasMultipleResources(candidates) {
+		return nil
+	}
+	return s.PerResource.ResolveUpdate(current, candidates)
+}
+
+// bestCandidates returns the candidates that compare lowest according to cmp.
+func bestCandidates(candidates []*endpoint.Endpoint, cmp func(a, b *endpoint.Endpoint) int) []*endpoint.Endpoint {
+	best := slices.MinFunc(candidates, cmp)
+	return slices.DeleteFunc(slices.Clone(candidates), func(ep *endpoint.Endpoint) bool {
+		return cmp(ep, best) != 0
+	})
+}
+
+// compareCreationTimestamps orders endpoints by the creation time of their oldest
+// referenced object. Endpoints without a known creation time are ordered last.
+func compareCreationTimestamps(a, b *endpoint.Endpoint) int {
+	ta, tb := oldestCreationTimestamp(a), oldestCreationTimestamp(b)
+	switch {
+	case ta.IsZero() && tb.IsZero():
+		return 0
+	case ta.IsZero():
+		return 1
+	case tb.IsZero():
+		return -1
+	}
+	return ta.Compare(tb)
+}
+
+func oldestCreationTimestamp(ep *endpoint.Endpoint) time.Time {
+	var oldest time.Time
+	for _, ref := range ep.RefObjects() {
+		if ts := ref.CreationTimestamp(); !ts.IsZero() && (oldest.IsZero() || ts.Before(oldest)) {
+			oldest = ts
+		}
+	}
+	return oldest
+}
+
+// endpointNamespaces returns the namespaces of the resources an endpoint was derived from.
+// It falls back to the resource label ("kind/namespace/name") when no references are attached.
+func endpointNamespaces(ep *endpoint.Endpoint) []string {
+	var namespaces []string
+	for _, ref := range ep.RefObjects() {
+		namespaces = append(namespaces, ref.Namespace())
+	}
+	if len(namespaces) == 0 {
+		if parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/"); len(parts) == 3 {
+			namespaces = append(namespaces, parts[1])
+		}
+	}
+	return namespaces
+}
+
+func hasMultipleResources(candidates []*endpoint.Endpoint) bool {
+	for _, ep := range candidates[1:] {
+		if ep.Labels[endpoint.ResourceLabelKey] != candidates[0].Labels[endpoint.ResourceLabelKey] {
+			return true
+		}
+	}
+	return false
+}
@@ -19,16 +19,27 @@ package plan
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
 )
 
-var _ ConflictResolver = PerResource{}
+var (
+	_ ConflictResolver = PerResource{}
+	_ ConflictResolver = OldestResource{}
+	_ ConflictResolver = NamespacePriority{}
+	_ ConflictResolver = RefuseConflicts{}
+)
 
 type ResolverSuite struct {
 	// resolvers
@@ -367,3 +378,95 @@ func TestPerResource_ResolveRecordTypes_LogsWarning(t *testing.T) {
 func TestConflictResolver(t *testing.T) {
 	suite.Run(t, new(ResolverSuite))
 }
+
+// newIngressEndpoint returns an A endpoint for dnsName derived from an Ingress created at the given time.
+func newIngressEndpoint(dnsName, namespace, name, target string, created time.Time) *endpoint.Endpoint {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	ep := endpoint.NewEndpoint(dnsName, endpoint.RecordTypeA, target)
+	ep.Labels[endpoint.ResourceLabelKey] = "ingress/" + namespace + "/" + name
+	return ep.WithRefObject(events.NewObjectReference(ing, "ingress"))
+}
+
+func TestConflictResolvers(t *testing.T) {
+	for _, name := range []string{"per-resource", "oldest-resource", "namespace-priority", "refuse"} {
+		newResolver, ok := ConflictResolvers[name]
+		require.True(t, ok, name)
+		assert.NotNil(t, newResolver(ConflictResolverConfig{}), name)
+	}
+}
+
+func TestOldestResource(t *testing.T) {
+	now := time.Now()
+	older := newIngressEndpoint("foo.example.com", "team-b", "older", "9.9.9.9", now.Add(-time.Hour))
+	newer := newIngressEndpoint("foo.example.com", "team-a", "newer", "1.1.1.1", now)
+	sameAge := newIngressEndpoint("foo.example.com", "team-c", "same-age", "2.2.2.2", now.Add(-time.Hour))
+	unknown := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "0.0.0.0")
+
+	resolver := OldestResource{}
+
+	assert.Equal(t, older, resolver.ResolveCreate([]*endpoint.Endpoint{newer, older}), "should pick the oldest resource")
+	assert.Equal(t, newer, resolver.ResolveCreate([]*endpoint.Endpoint{unknown, newer}), "should prefer a known creation timestamp")
+	assert.Equal(t, sameAge, resolver.ResolveCreate([]*endpoint.Endpoint{older, sameAge}), "should pick min target on ties")
+	assert.Equal(t, unknown, resolver.ResolveCreate([]*endpoint.Endpoint{unknown}))
+
+	current := &endpoint.Endpoint{
+		DNSName:    "foo.example.com",
+		RecordType: endpoint.RecordTypeA,
+		Targets:    endpoint.Targets{"1.1.1.1"},
+		Labels:     map[string]string{endpoint.ResourceLabelKey: "ingress/team-a/newer"},
+	}
+	assert.Equal(t, older, resolver.ResolveUpdate(current, []*endpoint.Endpoint{newer, older}), "should hand over to the oldest resource")
+
+	current.Labels[endpoint.ResourceLabelKey] = "ingress/team-b/older"
+	assert.Equal(t, older, resolver.ResolveUpdate(current, []*endpoint.Endpoint{sameAge, older}), "should keep the current resource on ties")
+}
+
+func TestNamespacePriority(t *testing.T) {
+	now := time.Now()
+	prod := newIngressEndpoint("foo.example.com", "prod", "foo", "9.9.9.9", now)
+	staging := newIngressEndpoint("foo.example.com", "staging", "foo", "1.1.1.1", now)
+	other := newIngressEndpoint("foo.example.com", "other", "foo", "0.0.0.0", now)
+	otherToo := newIngressEndpoint("foo.example.com", "other-too", "foo", "0.0.0.1", now)
+	labelOnly := &endpoint.Endpoint{
+		DNSName:    "foo.example.com",
+		RecordType: endpoint.RecordTypeA,
+		Targets:    endpoint.Targets{"8.8.8.8"},
+		Labels:     map[string]string{endpoint.ResourceLabelKey: "service/staging/foo"},
+	}
+
+	resolver := NewNamespacePriority([]string{"prod", "staging"})
+
+	assert.Equal(t, prod, resolver.ResolveCreate([]*endpoint.Endpoint{other, staging, prod}), "should pick the highest priority namespace")
+	assert.Equal(t, staging, resolver.ResolveCreate([]*endpoint.Endpoint{other, staging}), "should prefer listed namespaces")
+	assert.Equal(t, other, resolver.ResolveCreate([]*endpoint.Endpoint{otherToo, other}), "should pick min target when no namespace is listed")
+	assert.Equal(t, labelOnly, resolver.ResolveCreate([]*endpoint.Endpoint{other, labelOnly}), "should fall back to the resource label")
+
+	current := &endpoint.Endpoint{
+		DNSName:    "foo.example.com",
+		RecordType: endpoint.RecordTypeA,
+		Targets:    endpoint.Targets{"1.1.1.1"},
+		Labels:     map[string]string{endpoint.ResourceLabelKey: "ingress/staging/foo"},
+	}
+	assert.Equal(t, prod, resolver.ResolveUpdate(current, []*endpoint.Endpoint{staging, prod}), "should hand over to the higher priority namespace")
+	assert.Equal(t, staging, resolver.ResolveUpdate(current, []*endpoint.Endpoint{staging, labelOnly}), "should keep the current resource on ties")
+}
+
+func TestRefuseConflicts(t *testing.T) {
+	now := time.Now()
+	fooA := newIngressEndpoint("foo.example.com", "team-a", "foo", "1.1.1.1", now)
+	fooB := newIngressEndpoint("foo.example.com", "team-b", "foo", "2.2.2.2", now)
+	fooASecond := newIngressEndpoint("foo.example.com", "team-a", "foo", "0.0.0.0", now)
+
+	resolver := RefuseConflicts{}
+
+	assert.Nil(t, resolver.ResolveCreate([]*endpoint.Endpoint{fooA, fooB}), "should refuse different resources")
+	assert.Equal(t, fooASecond, resolver.ResolveCreate([]*endpoint.Endpoint{fooA, fooASecond}), "should resolve endpoints of a single resource")
+	assert.Nil(t, resolver.ResolveUpdate(fooA, []*endpoint.Endpoint{fooA, fooB}), "should refuse different resources")
+	assert.Equal(t, fooA, resolver.ResolveUpdate(fooA, []*endpoint.Endpoint{fooA}))
+}
@@ -36,10 +36,19 @@ var (
 		},
 		[]string{"record_type", "owner", "foreign_owner", "domain"},
 	)
+
+	deletionThresholdExceeded = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "deletion_threshold_exceeded_total",
+			Help:      "Number of reconcile loops whose deletions exceeded the deletion threshold.",
+		},
+	)
 )
 
 func init() {
 	metrics.RegisterMetric.MustRegister(registryOwnerMismatchPerSync)
+	metrics.RegisterMetric.MustRegister(deletionThresholdExceeded)
 }
 
 // recordOwnerMismatch increments the per-sync gauge for a single skipped record due to an
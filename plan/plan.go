@@ -25,6 +25,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/idna"
+	"sigs.k8s.io/external-dns/internal/sets"
 )
 
 // Plan can convert a list of desired and current records to a series of create,
@@ -51,6 +52,15 @@ type Plan struct {
 	OwnerID string
 	// Old owner ID we migrate from
 	OldOwnerID string
+	// Resolver decides which desired endpoint gets a DNS name claimed by several resources.
+	// PerResource is used when nil.
+	Resolver ConflictResolver
+	// Conflicts the Resolver refused to resolve.
+	// Populated after calling Calculate()
+	Conflicts []Conflict
+	// Blocked is the reason a Guard policy rejected the changes, which are then empty.
+	// Populated after calling Calculate()
+	Blocked error
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -90,12 +100,18 @@ type planKey struct {
 //	--------------------------------------------------------------
 //	"=", i.e. result of calculation relies on supplied ConflictResolver
 type planTable struct {
-	rows     map[planKey]*planTableRow
-	resolver ConflictResolver
+	rows      map[planKey]*planTableRow
+	resolver  ConflictResolver
+	conflicts []Conflict
+	// adopting holds the candidates requesting the adoption of the current record of their type without owner
+	adopting sets.Set[*endpoint.Endpoint]
 }
 
-func newPlanTable() planTable { // TODO: make resolver configurable
-	return planTable{map[planKey]*planTableRow{}, PerResource{}}
+func newPlanTable(resolver ConflictResolver) *planTable {
+	if resolver == nil {
+		resolver = PerResource{}
+	}
+	return &planTable{rows: map[planKey]*planTableRow{}, resolver: resolver, adopting: sets.New[*endpoint.Endpoint]()}
 }
 
 // planTableRow represents a set of current and desired domain resource records.
@@ -128,12 +144,45 @@ func (t *planTable) addCurrent(e *endpoint.Endpoint) {
 }
 
 func (t *planTable) addCandidate(e *endpoint.Endpoint) {
+	if _, ok := e.GetProviderSpecificProperty(endpoint.ProviderSpecificAdopt); ok {
+		// the adoption request is not a property of the record, it must not reach the provider
+		e = e.DeepCopy()
+		e.DeleteProviderSpecificProperty(endpoint.ProviderSpecificAdopt)
+		t.adopting.Insert(e)
+	}
 	key := t.newPlanKey(e)
 	row := t.rows[key]
 	row.candidates = append(row.candidates, e)
 	row.records[e.RecordType].candidates = append(row.records[e.RecordType].candidates, e)
 }
 
+// currentCount returns the number of current records in the table.
+func (t *planTable) currentCount() int {
+	n := 0
+	for _, row := range t.rows {
+		n += len(row.current)
+	}
+	return n
+}
+
+// resolveCreate asks the resolver for the candidate to create, recording a conflict if it refuses.
+func (t *planTable) resolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	create := t.resolver.ResolveCreate(candidates)
+	if create == nil {
+		t.conflicts = append(t.conflicts, Conflict{Candidates: candidates})
+	}
+	return create
+}
+
+// resolveUpdate asks the resolver for the candidate replacing current, recording a conflict if it refuses.
+func (t *planTable) resolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	update := t.resolver.ResolveUpdate(current, candidates)
+	if update == nil {
+		t.conflicts = append(t.conflicts, Conflict{Current: current, Candidates: candidates})
+	}
+	return update
+}
+
 func (t *planTable) newPlanKey(e *endpoint.Endpoint) planKey {
 	key := planKey{
 		dnsName:       idna.NormalizeDNSName(e.DNSName),
@@ -164,7 +213,7 @@ func (c *Changes) HasChanges() bool {
 // state. It then passes those changes to the current policy for further
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
-	t := newPlanTable()
+	t := newPlanTable(p.Resolver)
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
@@ -180,7 +229,7 @@ func (p *Plan) Calculate() *Plan {
 	if p.OwnerID != "" {
 		registryOwnerMismatchPerSync.Gauge.Reset()
 	}
-	changes := p.calculateChanges(t)
+	changes, blocked := p.calculateChanges(t)
 
 	// Return a minimal plan with only the fields relevant to callers.
 	// ManagedRecords is reset to the canonical defaults (A/AAAA/CNAME) —
@@ -193,12 +242,14 @@ func (p *Plan) Calculate() *Plan {
 		Desired:        p.Desired,
 		Changes:        changes,
 		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		Conflicts:      t.conflicts,
+		Blocked:        blocked,
 	}
 
 	return plan
 }
 
-func (p *Plan) calculateChanges(t planTable) *Changes {
+func (p *Plan) calculateChanges(t *planTable) (*Changes, error) {
 	changes := &Changes{}
 
 	for key, row := range t.rows {
@@ -208,13 +259,17 @@ func (p *Plan) calculateChanges(t planTable) *Changes {
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
 			for _, records := range recordsByType {
 				if len(records.candidates) > 0 {
-					changes.Create = append(changes.Create, t.resolver.ResolveCreate(records.candidates))
+					if create := t.resolveCreate(records.candidates); create != nil {
+						changes.Create = append(changes.Create, create)
+					}
 				}
 			}
 
 		// dns name released or possibly owned by a different external dns
 		case len(row.candidates) == 0:
-			changes.Delete = append(changes.Delete, row.current...)
+			for _, current := range row.current {
+				p.appendRemoval(changes, current)
+			}
 
 		// dns name is taken
 		case len(row.candidates) > 0:
@@ -222,10 +277,6 @@ func (p *Plan) calculateChanges(t planTable) *Changes {
 		}
 	}
 
-	for _, pol := range p.Policies {
-		changes = pol.Apply(changes)
-	}
-
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
 		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
@@ -234,11 +285,24 @@ func (p *Plan) calculateChanges(t planTable) *Changes {
 		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
 
-	return changes
+	// the policies apply to the changes of the owned records only
+	for _, pol := range p.Policies {
+		changes = pol.Apply(changes)
+	}
+
+	for _, pol := range p.Policies {
+		if guard, ok := pol.(Guard); ok {
+			if err := guard.Check(changes, t.currentCount()); err != nil {
+				return &Changes{}, err
+			}
+		}
+	}
+
+	return changes, nil
 }
 
 func (p *Plan) appendTakenDNSNameChanges(
-	t planTable,
+	t *planTable,
 	changes *Changes,
 	key planKey,
 	row *planTableRow) {
@@ -251,11 +315,17 @@ func (p *Plan) appendTakenDNSNameChanges(
 		return
 	}
 
-	// only add creates if the external dns has ownership claim on the domain
+	// only add creates if the external dns has ownership claim on the domain, including the records it adopts
+	adopted := sets.New[string]()
+	for _, ep := range rowChanges.UpdateOld {
+		if ep.IsAdopted() {
+			adopted.Insert(ep.RecordType)
+		}
+	}
 	ownersMatch := true
 	if p.OwnerID != "" {
 		for _, current := range row.current {
-			if !current.IsOwnedBy(p.OwnerID) {
+			if !current.IsOwnedBy(p.OwnerID) && !adopted.Has(current.RecordType) && !current.IsShared() {
 				ownersMatch = false
 				recordOwnerMismatch(p.OwnerID, current)
 				if log.IsLevelEnabled(log.DebugLevel) {
@@ -270,7 +340,7 @@ func (p *Plan) appendTakenDNSNameChanges(
 	}
 }
 
-func (p *Plan) calculatePlanTableRowChanges(t planTable, key planKey, row *planTableRow) *Changes {
+func (p *Plan) calculatePlanTableRowChanges(t *planTable, key planKey, row *planTableRow) *Changes {
 	changes := &Changes{}
 
 	recordsByType := t.resolver.ResolveRecordTypes(key, row)
@@ -278,11 +348,14 @@ func (p *Plan) calculatePlanTableRowChanges(t planTable, key planKey, row *planT
 		switch {
 		// record type not desired
 		case records.current != nil && len(records.candidates) == 0:
-			changes.Delete = append(changes.Delete, records.current)
+			p.appendRemoval(changes, records.current)
 
 		// new record type desired
 		case records.current == nil && len(records.candidates) > 0:
-			update := t.resolver.ResolveCreate(records.candidates)
+			update := t.resolveCreate(records.candidates)
+			if update == nil {
+				continue
+			}
 			// creates are evaluated after all domain records have been processed to
 			// validate that this external dns has ownership claim on the domain before
 			// adding the records to planned changes.
@@ -297,21 +370,91 @@ func (p *Plan) calculatePlanTableRowChanges(t planTable, key planKey, row *planT
 	return changes
 }
 
-func (p *Plan) appendEndpointUpdates(t planTable, changes *Changes, current *endpoint.Endpoint, candidates []*endpoint.Endpoint) {
-	update := t.resolver.ResolveUpdate(current, candidates)
+func (p *Plan) appendEndpointUpdates(t *planTable, changes *Changes, current *endpoint.Endpoint, candidates []*endpoint.Endpoint) {
+	update := t.resolveUpdate(current, candidates)
+	if update == nil {
+		return
+	}
+
+	if p.adopts(t, update, current) {
+		log.Infof("Adopting record %s of type %s without owner", current.DNSName, current.RecordType)
+		// the current record is copied, so that it keeps having no owner should the changes not be applied
+		adopted := current.DeepCopy()
+		adopted.WithLabel(endpoint.OwnerLabelKey, p.OwnerID).WithLabel(endpoint.AdoptedLabelKey, "true")
+		changes.UpdateNew = append(changes.UpdateNew, update.WithLabel(endpoint.OwnerLabelKey, p.OwnerID))
+		changes.UpdateOld = append(changes.UpdateOld, adopted)
+		return
+	}
+
+	if current.IsShared() {
+		p.appendSharedUpdate(changes, current, update)
+		return
+	}
 
 	if shouldUpdateTTL(update, current) || targetChanged(update, current) ||
-		p.providerSpecificChanged(update, current) || p.isOldOwnerIDSetAndDifferent(current) {
+		p.providerSpecificChanged(update, current) || p.isOldOwnerIDSetAndDifferent(current) ||
+		isPendingDeletion(current) {
 		inheritOwner(current, update)
 		changes.UpdateNew = append(changes.UpdateNew, update)
 		changes.UpdateOld = append(changes.UpdateOld, current)
 	}
 }
 
+// appendSharedUpdate updates a record shared with other owners to the targets of the desired endpoint,
+// merged with the targets of the other owners. This owner joins the owners of the record if needed.
+func (p *Plan) appendSharedUpdate(changes *Changes, current, desired *endpoint.Endpoint) {
+	own := endpoint.NewTargets(desired.Targets...)
+	foreign := endpoint.NewTargetsFromString(current.Labels[endpoint.ForeignTargetsLabelKey])
+	shared := desired.DeepCopy()
+	shared.Targets = endpoint.NewTargets(append(slices.Clone(own), foreign...)...)
+	shared.WithLabel(endpoint.OwnerLabelKey, p.OwnerID).WithLabel(endpoint.SharedTargetsLabelKey, own.String())
+
+	joining := !current.IsOwnedBy(p.OwnerID)
+	if !joining && !shouldUpdateTTL(shared, current) && !targetChanged(shared, current) &&
+		current.Labels[endpoint.SharedTargetsLabelKey] == own.String() &&
+		!p.providerSpecificChanged(shared, current) && !isPendingDeletion(current) {
+		return
+	}
+	if joining {
+		log.Infof("Joining the owners of the shared record %s of type %s", current.DNSName, current.RecordType)
+		current = current.DeepCopy().WithLabel(endpoint.OwnerLabelKey, p.OwnerID)
+	}
+	changes.UpdateOld = append(changes.UpdateOld, current)
+	changes.UpdateNew = append(changes.UpdateNew, shared)
+}
+
+// appendRemoval deletes the current record, unless it is shared with other owners,
+// in which case only the targets contributed by this owner are removed from it.
+func (p *Plan) appendRemoval(changes *Changes, current *endpoint.Endpoint) {
+	foreign := endpoint.NewTargetsFromString(current.Labels[endpoint.ForeignTargetsLabelKey])
+	if !current.IsShared() || !current.IsOwnedBy(p.OwnerID) || len(foreign) == 0 {
+		changes.Delete = append(changes.Delete, current)
+		return
+	}
+	remaining := current.DeepCopy()
+	remaining.Targets = foreign
+	delete(remaining.Labels, endpoint.SharedTargetsLabelKey)
+	delete(remaining.Labels, endpoint.ForeignTargetsLabelKey)
+	changes.UpdateOld = append(changes.UpdateOld, current)
+	changes.UpdateNew = append(changes.UpdateNew, remaining)
+}
+
+// adopts reports whether the desired endpoint requested the adoption of the current record, which has no owner.
+func (p *Plan) adopts(t *planTable, desired, current *endpoint.Endpoint) bool {
+	return p.OwnerID != "" && t.adopting.Has(desired) && current.Labels[endpoint.OwnerLabelKey] == ""
+}
+
 func (p *Plan) isOldOwnerIDSetAndDifferent(current *endpoint.Endpoint) bool {
 	return p.OldOwnerID != "" && current.Labels[endpoint.OwnerLabelKey] != p.OldOwnerID
 }
 
+// isPendingDeletion reports whether current was marked by a DeletionGracePeriodPolicy.
+// Updating it with the desired endpoint clears the mark.
+func isPendingDeletion(current *endpoint.Endpoint) bool {
+	_, ok := current.Labels[endpoint.PendingDeletionLabelKey]
+	return ok
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
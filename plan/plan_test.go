@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -751,6 +752,286 @@ func (suite *PlanTestSuite) TestNoCurrentWithConflictingDesired() {
 	validateEntries(suite.T(), changes.Delete, expectedDelete)
 }
 
+// TestRefuseConflictsResolver verifies that names claimed by several resources are left untouched
+// and reported as conflicts when the resolver refuses to pick one of them.
+func (suite *PlanTestSuite) TestRefuseConflictsResolver() {
+	current := []*endpoint.Endpoint{suite.fooV1Cname}
+	desired := []*endpoint.Endpoint{suite.fooV1Cname, suite.fooV2Cname, suite.bar127A}
+	expectedCreate := []*endpoint.Endpoint{suite.bar127A}
+	expectedUpdateOld := []*endpoint.Endpoint{}
+	expectedUpdateNew := []*endpoint.Endpoint{}
+	expectedDelete := []*endpoint.Endpoint{}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		Resolver:       RefuseConflicts{},
+	}
+
+	calculated := p.Calculate()
+	changes := calculated.Changes
+	validateEntries(suite.T(), changes.Create, expectedCreate)
+	validateEntries(suite.T(), changes.UpdateNew, expectedUpdateNew)
+	validateEntries(suite.T(), changes.UpdateOld, expectedUpdateOld)
+	validateEntries(suite.T(), changes.Delete, expectedDelete)
+
+	suite.Require().Len(calculated.Conflicts, 1)
+	suite.Equal(suite.fooV1Cname, calculated.Conflicts[0].Current)
+	suite.ElementsMatch([]*endpoint.Endpoint{suite.fooV1Cname, suite.fooV2Cname}, calculated.Conflicts[0].Candidates)
+}
+
+// TestDeletionThresholdBlocksChanges verifies that a Guard policy rejecting the changes
+// empties them and reports why the plan was blocked.
+func (suite *PlanTestSuite) TestDeletionThresholdBlocksChanges() {
+	current := []*endpoint.Endpoint{suite.fooV1Cname, suite.bar127A}
+	desired := []*endpoint.Endpoint{suite.fooV1Cname}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}, &DeletionThresholdPolicy{MaxDeletePercentage: 40}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+
+	calculated := p.Calculate()
+	suite.Require().ErrorIs(calculated.Blocked, ErrDeletionThresholdExceeded)
+	suite.False(calculated.Changes.HasChanges())
+
+	p.Policies = []Policy{&SyncPolicy{}, &DeletionThresholdPolicy{MaxDeletePercentage: 50}}
+	calculated = p.Calculate()
+	suite.Require().NoError(calculated.Blocked)
+	validateEntries(suite.T(), calculated.Changes.Delete, []*endpoint.Endpoint{suite.bar127A})
+}
+
+// TestPendingDeletionClearedWhenDesired verifies that a record marked as pending deletion
+// is updated to clear the mark once it is desired again.
+func (suite *PlanTestSuite) TestPendingDeletionClearedWhenDesired() {
+	current := suite.fooV1Cname.DeepCopy().WithLabel(endpoint.PendingDeletionLabelKey, "2026-01-02T03:04:05Z")
+	desired := suite.fooV1Cname.DeepCopy()
+	delete(desired.Labels, endpoint.PendingDeletionLabelKey)
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeCNAME},
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.UpdateOld, []*endpoint.Endpoint{current})
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.NotContains(changes.UpdateNew[0].Labels, endpoint.PendingDeletionLabelKey)
+	suite.Empty(changes.Create)
+	suite.Empty(changes.Delete)
+}
+
+// TestDeletionGracePeriodSkipsForeignRecords verifies that only the records owned by this instance
+// are marked as pending deletion, and not the records of other owners which it never deletes.
+func (suite *PlanTestSuite) TestDeletionGracePeriodSkipsForeignRecords() {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	foreign := suite.bar127A.DeepCopy().WithLabel(endpoint.OwnerLabelKey, "other")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}, &DeletionGracePeriodPolicy{GracePeriod: 10 * time.Minute, now: func() time.Time { return now }}},
+		Current:        []*endpoint.Endpoint{suite.fooV1Cname, foreign},
+		Desired:        []*endpoint.Endpoint{},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        suite.fooV1Cname.Labels[endpoint.OwnerLabelKey],
+	}
+
+	hook := logtest.LogsUnderTestWithLogLevel(log.InfoLevel, suite.T())
+	changes := p.Calculate().Changes
+	logtest.TestHelperLogNotContains("Marking "+foreign.String(), hook, suite.T())
+	validateEntries(suite.T(), changes.UpdateOld, []*endpoint.Endpoint{suite.fooV1Cname})
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Equal("2026-01-02T03:04:05Z", changes.UpdateNew[0].Labels[endpoint.PendingDeletionLabelKey])
+	suite.Empty(changes.Delete)
+}
+
+// TestAdoptRecordWithoutOwner verifies that a record without owner is updated with the ownership
+// of the desired record requesting its adoption, along with the creation of the other record types of the name.
+func (suite *PlanTestSuite) TestAdoptRecordWithoutOwner() {
+	current := suite.fooA5.DeepCopy()
+	current.Labels = nil
+	desired := suite.fooA5.DeepCopy().WithProviderSpecific(endpoint.ProviderSpecificAdopt, "true")
+	desired.Targets = endpoint.Targets{"1.2.3.4"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired, suite.fooAAAA},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:        "pwner",
+	}
+
+	changes := p.Calculate().Changes
+	suite.Require().Len(changes.UpdateOld, 1)
+	suite.True(changes.UpdateOld[0].IsAdopted())
+	suite.Equal("pwner", changes.UpdateOld[0].Labels[endpoint.OwnerLabelKey])
+	suite.Equal(endpoint.Targets{"5.5.5.5"}, changes.UpdateOld[0].Targets)
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Equal("pwner", changes.UpdateNew[0].Labels[endpoint.OwnerLabelKey])
+	suite.Equal(endpoint.Targets{"1.2.3.4"}, changes.UpdateNew[0].Targets)
+	suite.Empty(changes.UpdateNew[0].ProviderSpecific)
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{suite.fooAAAA})
+	suite.Empty(changes.Delete)
+
+	// neither the current nor the desired record are modified
+	suite.Nil(current.Labels)
+	suite.Equal(endpoint.ProviderSpecific{{Name: endpoint.ProviderSpecificAdopt, Value: "true"}}, desired.ProviderSpecific)
+}
+
+// TestAdoptRecordWithoutChange verifies that a record without owner is adopted even when it already
+// matches the desired record, and that it is no longer updated once owned.
+func (suite *PlanTestSuite) TestAdoptRecordWithoutChange() {
+	current := suite.fooA5.DeepCopy()
+	current.Labels = nil
+	desired := suite.fooA5.DeepCopy().WithProviderSpecific(endpoint.ProviderSpecificAdopt, "true")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "pwner",
+	}
+	changes := p.Calculate().Changes
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Require().Len(changes.UpdateOld, 1)
+
+	p.Current = []*endpoint.Endpoint{changes.UpdateNew[0]}
+	suite.False(p.Calculate().Changes.HasChanges())
+}
+
+// TestAdoptRecordOwnedByAnotherOwner verifies that only records without owner are adopted.
+func (suite *PlanTestSuite) TestAdoptRecordOwnedByAnotherOwner() {
+	current := suite.fooA5.DeepCopy().WithLabel(endpoint.OwnerLabelKey, "other")
+	desired := suite.fooA5.DeepCopy().WithProviderSpecific(endpoint.ProviderSpecificAdopt, "true")
+	desired.Targets = endpoint.Targets{"1.2.3.4"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "pwner",
+	}
+
+	suite.False(p.Calculate().Changes.HasChanges())
+}
+
+// TestNoAdoptionWithoutRequest verifies that records without owner are left untouched
+// unless their adoption is requested.
+func (suite *PlanTestSuite) TestNoAdoptionWithoutRequest() {
+	current := suite.fooA5.DeepCopy()
+	current.Labels = nil
+	desired := suite.fooA5.DeepCopy()
+	desired.Targets = endpoint.Targets{"1.2.3.4"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired, suite.fooAAAA},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+		OwnerID:        "pwner",
+	}
+
+	changes := p.Calculate().Changes
+	suite.Empty(changes.UpdateOld)
+	suite.Empty(changes.UpdateNew)
+	suite.Empty(changes.Create)
+}
+
+// TestJoinSharedRecord verifies that the targets of a record shared with another owner
+// are merged with the desired ones.
+func (suite *PlanTestSuite) TestJoinSharedRecord() {
+	current := suite.fooA5.DeepCopy().
+		WithLabel(endpoint.OwnerLabelKey, "other").
+		WithLabel(endpoint.ForeignTargetsLabelKey, "5.5.5.5")
+	desired := suite.fooA5.DeepCopy()
+	desired.Targets = endpoint.Targets{"1.2.3.4"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "pwner",
+	}
+
+	changes := p.Calculate().Changes
+	suite.Require().Len(changes.UpdateOld, 1)
+	suite.Equal("pwner", changes.UpdateOld[0].Labels[endpoint.OwnerLabelKey])
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Equal("pwner", changes.UpdateNew[0].Labels[endpoint.OwnerLabelKey])
+	suite.Equal("1.2.3.4", changes.UpdateNew[0].Labels[endpoint.SharedTargetsLabelKey])
+	suite.Equal(endpoint.Targets{"1.2.3.4", "5.5.5.5"}, changes.UpdateNew[0].Targets)
+	suite.Empty(changes.Create)
+	suite.Empty(changes.Delete)
+
+	// the current record is not modified
+	suite.Equal("other", current.Labels[endpoint.OwnerLabelKey])
+}
+
+// TestUpdateSharedRecord verifies that an owner of a shared record only updates it
+// when the targets it contributes change.
+func (suite *PlanTestSuite) TestUpdateSharedRecord() {
+	current := suite.fooA5.DeepCopy().
+		WithLabel(endpoint.OwnerLabelKey, "pwner").
+		WithLabel(endpoint.SharedTargetsLabelKey, "1.2.3.4").
+		WithLabel(endpoint.ForeignTargetsLabelKey, "5.5.5.5")
+	current.Targets = endpoint.Targets{"1.2.3.4", "5.5.5.5"}
+	desired := suite.fooA5.DeepCopy()
+	desired.Targets = endpoint.Targets{"1.2.3.4"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		Desired:        []*endpoint.Endpoint{desired},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "pwner",
+	}
+	suite.False(p.Calculate().Changes.HasChanges())
+
+	desired.Targets = endpoint.Targets{"8.8.8.8"}
+	changes := p.Calculate().Changes
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Equal("8.8.8.8", changes.UpdateNew[0].Labels[endpoint.SharedTargetsLabelKey])
+	suite.Equal(endpoint.Targets{"5.5.5.5", "8.8.8.8"}, changes.UpdateNew[0].Targets)
+}
+
+// TestLeaveSharedRecord verifies that an owner of a shared record removes its targets only,
+// and that the last owner deletes the record.
+func (suite *PlanTestSuite) TestLeaveSharedRecord() {
+	current := suite.fooA5.DeepCopy().
+		WithLabel(endpoint.OwnerLabelKey, "pwner").
+		WithLabel(endpoint.SharedTargetsLabelKey, "1.2.3.4").
+		WithLabel(endpoint.ForeignTargetsLabelKey, "5.5.5.5")
+	current.Targets = endpoint.Targets{"1.2.3.4", "5.5.5.5"}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{current},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "pwner",
+	}
+
+	changes := p.Calculate().Changes
+	suite.Empty(changes.Delete)
+	suite.Require().Len(changes.UpdateNew, 1)
+	suite.Equal(endpoint.Targets{"5.5.5.5"}, changes.UpdateNew[0].Targets)
+	suite.NotContains(changes.UpdateNew[0].Labels, endpoint.SharedTargetsLabelKey)
+	suite.NotContains(changes.UpdateNew[0].Labels, endpoint.ForeignTargetsLabelKey)
+
+	current.Labels[endpoint.ForeignTargetsLabelKey] = ""
+	current.Targets = endpoint.Targets{"1.2.3.4"}
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.Delete, []*endpoint.Endpoint{current})
+	suite.Empty(changes.UpdateNew)
+}
+
 func (suite *PlanTestSuite) TestIgnoreTXT() {
 	current := []*endpoint.Endpoint{suite.fooV2TXT}
 	desired := []*endpoint.Endpoint{suite.fooV2Cname}
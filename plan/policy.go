@@ -16,11 +16,31 @@ limitations under the License.
 
 package plan
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ErrDeletionThresholdExceeded is returned when a run would delete more records than allowed.
+var ErrDeletionThresholdExceeded = errors.New("deletion threshold exceeded")
+
 // Policy allows to apply different rules to a set of changes.
 type Policy interface {
 	Apply(changes *Changes) *Changes
 }
 
+// Guard is implemented by policies that can block all changes of a run.
+// Check is called with the final changes and the number of current records they were calculated from.
+type Guard interface {
+	Check(changes *Changes, current int) error
+}
+
 // Policies is a registry of available policies, keyed by name.
 var Policies = map[string]Policy{
 	"sync":        &SyncPolicy{},
@@ -57,3 +77,97 @@ func (p *CreateOnlyPolicy) Apply(changes *Changes) *Changes {
 		Create: changes.Create,
 	}
 }
+
+// DeletionThresholdPolicy blocks all changes of a run that would delete more records than allowed,
+// e.g. when a misconfigured filter makes a source return almost nothing.
+// Apply is a pass-through so that it can be combined with another policy such as SyncPolicy.
+type DeletionThresholdPolicy struct {
+	// MaxDeletes is the number of records a run may delete, 0 disables the limit.
+	MaxDeletes int
+	// MaxDeletePercentage is the percentage of current records a run may delete, 0 disables the limit.
+	MaxDeletePercentage int
+	// Override lets changes exceeding the limits through.
+	Override bool
+}
+
+// Apply is a pass-through: the limits are enforced by Check.
+func (p *DeletionThresholdPolicy) Apply(changes *Changes) *Changes {
+	return changes
+}
+
+// Check returns an error wrapping ErrDeletionThresholdExceeded if changes delete more records than allowed,
+// unless the policy is overridden.
+func (p *DeletionThresholdPolicy) Check(changes *Changes, current int) error {
+	deletes := len(changes.Delete)
+	var exceeded []string
+	if p.MaxDeletes > 0 && deletes > p.MaxDeletes {
+		exceeded = append(exceeded, fmt.Sprintf("%d deletes exceed the maximum of %d", deletes, p.MaxDeletes))
+	}
+	if p.MaxDeletePercentage > 0 && current > 0 && deletes*100 > p.MaxDeletePercentage*current {
+		exceeded = append(exceeded, fmt.Sprintf("%d deletes of %d current records exceed the maximum of %d%%", deletes, current, p.MaxDeletePercentage))
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	deletionThresholdExceeded.Counter.Inc()
+	err := fmt.Errorf("%w: %s", ErrDeletionThresholdExceeded, strings.Join(exceeded, ", "))
+	if p.Override {
+		log.Warnf("%v; applying changes as the deletion threshold is overridden", err)
+		return nil
+	}
+	return err
+}
+
+// DeletionGracePeriodPolicy delays deleting records until they have been absent from the desired state
+// for GracePeriod, e.g. while a Service is recreated during an upgrade. Instead of being deleted, a record
+// is first updated with the endpoint.PendingDeletionLabelKey label holding the current time, which the
+// registry persists across restarts. The record is deleted once the grace period has elapsed.
+// The planner clears the label again when the record becomes desired before that.
+type DeletionGracePeriodPolicy struct {
+	GracePeriod time.Duration
+	// now returns the current time, time.Now is used when nil
+	now func() time.Time
+}
+
+// Apply replaces the deletions of records within their grace period by updates marking them as pending deletion.
+func (p *DeletionGracePeriodPolicy) Apply(changes *Changes) *Changes {
+	now := time.Now()
+	if p.now != nil {
+		now = p.now()
+	}
+
+	deletes := make([]*endpoint.Endpoint, 0, len(changes.Delete))
+	for _, ep := range changes.Delete {
+		since, ok := pendingDeletionSince(ep)
+		switch {
+		case !ok:
+			log.Infof("Marking %s for deletion after %s", ep, p.GracePeriod)
+			marked := ep.DeepCopy().WithLabel(endpoint.PendingDeletionLabelKey, now.UTC().Format(time.RFC3339))
+			changes.UpdateOld = append(changes.UpdateOld, ep)
+			changes.UpdateNew = append(changes.UpdateNew, marked)
+		case now.Sub(since) >= p.GracePeriod:
+			deletes = append(deletes, ep)
+		default:
+			log.Debugf("Keeping %s until its deletion grace period ends at %s", ep, since.Add(p.GracePeriod).Format(time.RFC3339))
+		}
+	}
+	changes.Delete = deletes
+
+	return changes
+}
+
+// pendingDeletionSince returns the time ep was marked as pending deletion.
+// A missing or malformed label reports false.
+func pendingDeletionSince(ep *endpoint.Endpoint) (time.Time, bool) {
+	value, ok := ep.Labels[endpoint.PendingDeletionLabelKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Warnf("Ignoring invalid %s label %q of %s: %v", endpoint.PendingDeletionLabelKey, value, ep, err)
+		return time.Time{}, false
+	}
+	return since, true
+}
@@ -19,6 +19,10 @@ package plan
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -86,3 +90,79 @@ func validatePolicy(t *testing.T, policy, expected Policy) {
 		t.Errorf("expected %q to match %q", policyType, expectedType)
 	}
 }
+
+// TestDeletionThresholdPolicy tests that deletions exceeding a threshold are blocked unless overridden.
+func TestDeletionThresholdPolicy(t *testing.T) {
+	deletes := []*endpoint.Endpoint{
+		{DNSName: "foo", Targets: endpoint.Targets{"v1"}},
+		{DNSName: "bar", Targets: endpoint.Targets{"v1"}},
+		{DNSName: "baz", Targets: endpoint.Targets{"v1"}},
+	}
+	changes := &Changes{Delete: deletes}
+
+	for _, tc := range []struct {
+		name    string
+		policy  *DeletionThresholdPolicy
+		current int
+		wantErr string
+	}{
+		{name: "disabled", policy: &DeletionThresholdPolicy{}, current: 3},
+		{name: "count within threshold", policy: &DeletionThresholdPolicy{MaxDeletes: 3}, current: 3},
+		{
+			name:    "count exceeded",
+			policy:  &DeletionThresholdPolicy{MaxDeletes: 2},
+			current: 10,
+			wantErr: "deletion threshold exceeded: 3 deletes exceed the maximum of 2",
+		},
+		{name: "percentage within threshold", policy: &DeletionThresholdPolicy{MaxDeletePercentage: 30}, current: 10},
+		{
+			name:    "percentage exceeded",
+			policy:  &DeletionThresholdPolicy{MaxDeletePercentage: 25},
+			current: 10,
+			wantErr: "deletion threshold exceeded: 3 deletes of 10 current records exceed the maximum of 25%",
+		},
+		{
+			name:    "both exceeded",
+			policy:  &DeletionThresholdPolicy{MaxDeletes: 1, MaxDeletePercentage: 10},
+			current: 3,
+			wantErr: "deletion threshold exceeded: 3 deletes exceed the maximum of 1, 3 deletes of 3 current records exceed the maximum of 10%",
+		},
+		{name: "overridden", policy: &DeletionThresholdPolicy{MaxDeletes: 1, Override: true}, current: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, changes, tc.policy.Apply(changes))
+			err := tc.policy.Check(changes, tc.current)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrDeletionThresholdExceeded)
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+// TestDeletionGracePeriodPolicy tests that deletions are delayed until the grace period has elapsed.
+func TestDeletionGracePeriodPolicy(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	policy := &DeletionGracePeriodPolicy{GracePeriod: 10 * time.Minute, now: func() time.Time { return now }}
+
+	unmarked := endpoint.NewEndpoint("foo", endpoint.RecordTypeA, "v1")
+	pending := endpoint.NewEndpoint("bar", endpoint.RecordTypeA, "v1").
+		WithLabel(endpoint.PendingDeletionLabelKey, now.Add(-5*time.Minute).Format(time.RFC3339))
+	expired := endpoint.NewEndpoint("baz", endpoint.RecordTypeA, "v1").
+		WithLabel(endpoint.PendingDeletionLabelKey, now.Add(-10*time.Minute).Format(time.RFC3339))
+	invalid := endpoint.NewEndpoint("qux", endpoint.RecordTypeA, "v1").
+		WithLabel(endpoint.PendingDeletionLabelKey, "yesterday")
+
+	changes := policy.Apply(&Changes{Delete: []*endpoint.Endpoint{unmarked, pending, expired, invalid}})
+
+	assert.Equal(t, []*endpoint.Endpoint{expired}, changes.Delete)
+	assert.Equal(t, []*endpoint.Endpoint{unmarked, invalid}, changes.UpdateOld)
+	require.Len(t, changes.UpdateNew, 2)
+	for i, ep := range changes.UpdateNew {
+		assert.Equal(t, changes.UpdateOld[i].DNSName, ep.DNSName)
+		assert.Equal(t, "2026-01-02T03:04:05Z", ep.Labels[endpoint.PendingDeletionLabelKey])
+	}
+	assert.NotContains(t, unmarked.Labels, endpoint.PendingDeletionLabelKey, "the current record must not be modified")
+}
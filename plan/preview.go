@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/idna"
+)
+
+const (
+	// PreviewFormatJSON renders a Preview as a JSON document.
+	PreviewFormatJSON = "json"
+	// PreviewFormatDiff renders a Preview as unified-diff style text.
+	PreviewFormatDiff = "diff"
+)
+
+// Actions of a PreviewRecord.
+const (
+	PreviewActionCreate = "create"
+	PreviewActionUpdate = "update"
+	PreviewActionDelete = "delete"
+)
+
+// Preview is a reviewable view of calculated Changes, similar to `terraform plan`.
+// Changes holds the raw changes so that a saved preview can be applied later,
+// Records describes every change per DNS record.
+type Preview struct {
+	// OwnerID of the registry the changes were calculated for
+	OwnerID string `json:"ownerId,omitempty"`
+	// Changes as calculated by the plan
+	Changes *Changes `json:"changes"`
+	// Records is a per-record summary of Changes
+	Records []PreviewRecord `json:"records"`
+}
+
+// PreviewRecord describes the change of a single DNS record.
+type PreviewRecord struct {
+	Action        string              `json:"action"`
+	DNSName       string              `json:"dnsName"`
+	RecordType    string              `json:"recordType"`
+	SetIdentifier string              `json:"setIdentifier,omitempty"`
+	Owner         string              `json:"owner,omitempty"`
+	Resource      string              `json:"resource,omitempty"`
+	Refs          []PreviewObjectRef  `json:"refs,omitempty"`
+	Old           *PreviewRecordState `json:"old,omitempty"`
+	New           *PreviewRecordState `json:"new,omitempty"`
+}
+
+// PreviewRecordState holds the TTL and targets of a DNS record before or after a change.
+type PreviewRecordState struct {
+	RecordTTL endpoint.TTL     `json:"recordTTL,omitempty"`
+	Targets   endpoint.Targets `json:"targets"`
+}
+
+// PreviewObjectRef identifies a Kubernetes object a DNS record was derived from.
+type PreviewObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Source    string `json:"source,omitempty"`
+}
+
+// NewPreview builds a Preview of changes. Creates that carry no owner label are
+// attributed to ownerID, as the registry labels them when applying the changes.
+func NewPreview(changes *Changes, ownerID string) *Preview {
+	if changes == nil {
+		changes = &Changes{}
+	}
+	records := make([]PreviewRecord, 0, len(changes.Create)+len(changes.UpdateNew)+len(changes.Delete))
+
+	for _, ep := range changes.Create {
+		r := newPreviewRecord(PreviewActionCreate, ep)
+		if r.Owner == "" {
+			r.Owner = ownerID
+		}
+		r.New = newPreviewRecordState(ep)
+		records = append(records, r)
+	}
+
+	updateOld := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(changes.UpdateOld))
+	for _, ep := range changes.UpdateOld {
+		updateOld[ep.Key()] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		r := newPreviewRecord(PreviewActionUpdate, ep)
+		if old, ok := updateOld[ep.Key()]; ok {
+			r.Old = newPreviewRecordState(old)
+		}
+		r.New = newPreviewRecordState(ep)
+		records = append(records, r)
+	}
+
+	for _, ep := range changes.Delete {
+		r := newPreviewRecord(PreviewActionDelete, ep)
+		r.Old = newPreviewRecordState(ep)
+		records = append(records, r)
+	}
+
+	slices.SortStableFunc(records, func(a, b PreviewRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.DNSName, b.DNSName),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+		)
+	})
+
+	return &Preview{
+		OwnerID: ownerID,
+		Changes: changes,
+		Records: records,
+	}
+}
+
+func newPreviewRecord(action string, ep *endpoint.Endpoint) PreviewRecord {
+	r := PreviewRecord{
+		Action:        action,
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		Owner:         ep.Labels[endpoint.OwnerLabelKey],
+		Resource:      ep.Labels[endpoint.ResourceLabelKey],
+	}
+	for _, ref := range ep.RefObjects() {
+		r.Refs = append(r.Refs, PreviewObjectRef{
+			Kind:      ref.Kind(),
+			Namespace: ref.Namespace(),
+			Name:      ref.Name(),
+			Source:    ref.Source(),
+		})
+	}
+	return r
+}
+
+func newPreviewRecordState(ep *endpoint.Endpoint) *PreviewRecordState {
+	return &PreviewRecordState{RecordTTL: ep.RecordTTL, Targets: ep.Targets}
+}
+
+// Write renders the preview in the given format.
+func (p *Preview) Write(w io.Writer, format string) error {
+	switch format {
+	case PreviewFormatJSON:
+		return p.WriteJSON(w)
+	case PreviewFormatDiff:
+		return p.WriteDiff(w)
+	default:
+		return fmt.Errorf("unknown plan preview format: %s", format)
+	}
+}
+
+// WriteJSON writes the preview as an indented JSON document.
+func (p *Preview) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteDiff writes the preview as unified-diff style text, with one hunk per DNS record:
+//
+//	@@ update foo.example.com A (owner: default; refs: Ingress/default/foo) @@
+//	-foo.example.com 300 IN A 1.2.3.4
+//	+foo.example.com 300 IN A 5.6.7.8
+func (p *Preview) WriteDiff(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("--- current\n+++ desired\n")
+	for _, r := range p.Records {
+		fmt.Fprintf(&b, "@@ %s %s %s", r.Action, r.DNSName, r.RecordType)
+		if details := r.details(); details != "" {
+			fmt.Fprintf(&b, " (%s)", details)
+		}
+		b.WriteString(" @@\n")
+		if r.Old != nil {
+			r.writeState(&b, "-", r.Old)
+		}
+		if r.New != nil {
+			r.writeState(&b, "+", r.New)
+		}
+	}
+	fmt.Fprintf(&b, "# %d to create, %d to update, %d to delete\n",
+		len(p.Changes.Create), len(p.Changes.UpdateNew), len(p.Changes.Delete))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r PreviewRecord) details() string {
+	var details []string
+	if r.SetIdentifier != "" {
+		details = append(details, "set-identifier: "+r.SetIdentifier)
+	}
+	if r.Owner != "" {
+		details = append(details, "owner: "+r.Owner)
+	}
+	refs := make([]string, 0, len(r.Refs))
+	for _, ref := range r.Refs {
+		if ref.Namespace == "" {
+			refs = append(refs, ref.Kind+"/"+ref.Name)
+		} else {
+			refs = append(refs, ref.Kind+"/"+ref.Namespace+"/"+ref.Name)
+		}
+	}
+	if len(refs) == 0 && r.Resource != "" {
+		refs = append(refs, r.Resource)
+	}
+	if len(refs) > 0 {
+		details = append(details, "refs: "+strings.Join(refs, ", "))
+	}
+	return strings.Join(details, "; ")
+}
+
+func (r PreviewRecord) writeState(b *strings.Builder, prefix string, s *PreviewRecordState) {
+	for _, target := range s.Targets {
+		fmt.Fprintf(b, "%s%s %d IN %s %s\n", prefix, r.DNSName, s.RecordTTL, r.RecordType, target)
+	}
+}
+
+// ReadChanges reads the Changes of a saved plan. Both a Preview document and a
+// bare Changes document are accepted.
+func ReadChanges(r io.Reader) (*Changes, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	var preview Preview
+	if err := json.Unmarshal(data, &preview); err != nil {
+		return nil, fmt.Errorf("decoding plan: %w", err)
+	}
+	if preview.Changes != nil {
+		return preview.Changes, nil
+	}
+	changes := &Changes{}
+	if err := json.Unmarshal(data, changes); err != nil {
+		return nil, fmt.Errorf("decoding plan: %w", err)
+	}
+	return changes, nil
+}
+
+// StaleEndpoints returns the UpdateOld and Delete endpoints that no longer match a record in current,
+// because the record is gone or its targets, TTL or owner changed since the changes were calculated.
+func (c *Changes) StaleEndpoints(current []*endpoint.Endpoint) []*endpoint.Endpoint {
+	records := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(current))
+	for _, ep := range current {
+		records[normalizedKey(ep)] = ep
+	}
+	var stale []*endpoint.Endpoint
+	for _, ep := range slices.Concat(c.UpdateOld, c.Delete) {
+		cur, ok := records[normalizedKey(ep)]
+		if !ok || !cur.Targets.Same(ep.Targets) || cur.RecordTTL != ep.RecordTTL ||
+			cur.Labels[endpoint.OwnerLabelKey] != ep.Labels[endpoint.OwnerLabelKey] {
+			stale = append(stale, ep)
+		}
+	}
+	return stale
+}
+
+func normalizedKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	return endpoint.EndpointKey{
+		DNSName:       idna.NormalizeDNSName(ep.DNSName),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+)
+
+func newPreviewTestChanges() *Changes {
+	ref := events.NewObjectReferenceFromParts("Ingress", "networking.k8s.io/v1", "default", "foo", "", "ingress")
+	return &Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4").WithRefObject(ref),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeCNAME, 300, "old.elb.com").
+				WithLabel(endpoint.OwnerLabelKey, "owner"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeCNAME, 60, "new.elb.com").
+				WithLabel(endpoint.OwnerLabelKey, "owner").
+				WithLabel(endpoint.ResourceLabelKey, "service/default/bar"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("baz.example.com", endpoint.RecordTypeA, "9.9.9.9").
+				WithLabel(endpoint.OwnerLabelKey, "owner").
+				WithSetIdentifier("eu"),
+		},
+	}
+}
+
+func TestNewPreview(t *testing.T) {
+	preview := NewPreview(newPreviewTestChanges(), "owner")
+
+	require.Len(t, preview.Records, 3)
+
+	update := preview.Records[0]
+	assert.Equal(t, PreviewActionUpdate, update.Action)
+	assert.Equal(t, "bar.example.com", update.DNSName)
+	assert.Equal(t, "service/default/bar", update.Resource)
+	assert.Equal(t, &PreviewRecordState{RecordTTL: 300, Targets: endpoint.Targets{"old.elb.com"}}, update.Old)
+	assert.Equal(t, &PreviewRecordState{RecordTTL: 60, Targets: endpoint.Targets{"new.elb.com"}}, update.New)
+
+	del := preview.Records[1]
+	assert.Equal(t, PreviewActionDelete, del.Action)
+	assert.Equal(t, "eu", del.SetIdentifier)
+	assert.Equal(t, endpoint.Targets{"9.9.9.9"}, del.Old.Targets)
+	assert.Nil(t, del.New)
+
+	create := preview.Records[2]
+	assert.Equal(t, PreviewActionCreate, create.Action)
+	assert.Equal(t, "owner", create.Owner, "creates are attributed to the registry owner")
+	assert.Equal(t, []PreviewObjectRef{{Kind: "Ingress", Namespace: "default", Name: "foo", Source: "ingress"}}, create.Refs)
+	assert.Nil(t, create.Old)
+}
+
+func TestNewPreviewNilChanges(t *testing.T) {
+	preview := NewPreview(nil, "")
+	assert.NotNil(t, preview.Changes)
+	assert.Empty(t, preview.Records)
+}
+
+func TestPreviewWriteJSON(t *testing.T) {
+	changes := newPreviewTestChanges()
+	var buf bytes.Buffer
+	require.NoError(t, NewPreview(changes, "owner").Write(&buf, PreviewFormatJSON))
+
+	var decoded Preview
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "owner", decoded.OwnerID)
+	assert.Len(t, decoded.Records, 3)
+	require.Len(t, decoded.Changes.UpdateOld, 1)
+	assert.Equal(t, changes.UpdateOld[0].Targets, decoded.Changes.UpdateOld[0].Targets)
+	assert.Equal(t, "owner", decoded.Changes.Delete[0].Labels[endpoint.OwnerLabelKey])
+}
+
+func TestPreviewWriteDiff(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewPreview(newPreviewTestChanges(), "owner").Write(&buf, PreviewFormatDiff))
+
+	expected := `--- current
++++ desired
+@@ update bar.example.com CNAME (owner: owner; refs: service/default/bar) @@
+-bar.example.com 300 IN CNAME old.elb.com
++bar.example.com 60 IN CNAME new.elb.com
+@@ delete baz.example.com A (set-identifier: eu; owner: owner) @@
+-baz.example.com 0 IN A 9.9.9.9
+@@ create foo.example.com A (owner: owner; refs: Ingress/default/foo) @@
++foo.example.com 300 IN A 1.2.3.4
+# 1 to create, 1 to update, 1 to delete
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestPreviewWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.EqualError(t, NewPreview(&Changes{}, "").Write(&buf, "yaml"), "unknown plan preview format: yaml")
+}
+
+func TestReadChanges(t *testing.T) {
+	changes := newPreviewTestChanges()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPreview(changes, "owner").WriteJSON(&buf))
+	fromPreview, err := ReadChanges(&buf)
+	require.NoError(t, err)
+	require.Len(t, fromPreview.Delete, 1)
+	assert.Equal(t, changes.Delete[0].Targets, fromPreview.Delete[0].Targets)
+
+	bare, err := json.Marshal(changes)
+	require.NoError(t, err)
+	fromChanges, err := ReadChanges(bytes.NewReader(bare))
+	require.NoError(t, err)
+	require.Len(t, fromChanges.UpdateNew, 1)
+	assert.Equal(t, changes.UpdateNew[0].Targets, fromChanges.UpdateNew[0].Targets)
+
+	_, err = ReadChanges(bytes.NewBufferString("not json"))
+	require.Error(t, err)
+}
+
+func TestStaleEndpoints(t *testing.T) {
+	changes := newPreviewTestChanges()
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("bar.example.com", endpoint.RecordTypeCNAME, 300, "old.elb.com").
+			WithLabel(endpoint.OwnerLabelKey, "owner"),
+		endpoint.NewEndpoint("BAZ.example.com", endpoint.RecordTypeA, "9.9.9.9").
+			WithLabel(endpoint.OwnerLabelKey, "owner").
+			WithSetIdentifier("eu"),
+	}
+
+	assert.Empty(t, changes.StaleEndpoints(current))
+
+	tests := []struct {
+		name   string
+		modify func(current []*endpoint.Endpoint) []*endpoint.Endpoint
+	}{
+		{
+			name:   "record deleted",
+			modify: func(current []*endpoint.Endpoint) []*endpoint.Endpoint { return current[:1] },
+		},
+		{
+			name: "targets changed",
+			modify: func(current []*endpoint.Endpoint) []*endpoint.Endpoint {
+				current[1].Targets = endpoint.Targets{"8.8.8.8"}
+				return current
+			},
+		},
+		{
+			name: "ttl changed",
+			modify: func(current []*endpoint.Endpoint) []*endpoint.Endpoint {
+				current[1].RecordTTL = 60
+				return current
+			},
+		},
+		{
+			name: "owner changed",
+			modify: func(current []*endpoint.Endpoint) []*endpoint.Endpoint {
+				current[1].Labels[endpoint.OwnerLabelKey] = "other"
+				return current
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := []*endpoint.Endpoint{current[0].DeepCopy(), current[1].DeepCopy()}
+			assert.Equal(t, changes.Delete, changes.StaleEndpoints(tt.modify(current)))
+		})
+	}
+}
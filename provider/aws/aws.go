@@ -394,6 +394,20 @@ func (p *AWSProvider) Zones(ctx context.Context) (map[string]*route53types.Hoste
 	return result, nil
 }
 
+// ZoneIDNames returns the names of the hosted zones by zone ID.
+func (p *AWSProvider) ZoneIDNames(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := provider.ZoneIDName{}
+	for id, zone := range zones {
+		names.Add(id, strings.TrimSuffix(*zone.zone.Name, "."))
+	}
+	return names, nil
+}
+
 // zones returns the list of zones per AWS profile
 func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, error) {
 	if !p.zonesCache.Expired() {
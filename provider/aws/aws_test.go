@@ -348,6 +348,16 @@ func TestAWSZones(t *testing.T) {
 	}
 }
 
+func TestAWSZoneIDNames(t *testing.T) {
+	provider, _ := newAWSProviderWithTagFilter(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter("private"), provider.NewZoneTagFilter([]string{}), defaultEvaluateTargetHealth, false, false, nil)
+
+	zones, err := provider.ZoneIDNames(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do.": "zone-3.ext-dns-test-2.teapot.zalan.do",
+	}, map[string]string(zones))
+}
+
 func TestAWSZonesWithTagFilterError(t *testing.T) {
 	client := NewRoute53APIStub(t)
 	provider := &AWSProvider{
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -58,6 +59,8 @@ type CachedProvider struct {
 	RefreshDelay time.Duration
 	lastRead     time.Time
 	cache        []*endpoint.Endpoint
+	// mutex guards the cache against changes applied concurrently per zone
+	mutex sync.Mutex
 }
 
 func NewCachedProvider(provider Provider, refreshDelay time.Duration) *CachedProvider {
@@ -68,6 +71,8 @@ func NewCachedProvider(provider Provider, refreshDelay time.Duration) *CachedPro
 }
 
 func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.needRefresh() {
 		log.Info("Records cache provider: refreshing records list cache")
 		records, err := c.Provider.Records(ctx)
@@ -95,6 +100,8 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 }
 
 func (c *CachedProvider) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	c.cache = nil
 	c.lastRead = time.Time{}
 }
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ProviderSpec configures one provider of a multi-provider configuration.
+// Unset fields are taken from the global configuration.
+type ProviderSpec struct {
+	// Name identifies the provider in logs
+	Name            string   `yaml:"name"`
+	Provider        string   `yaml:"provider"`
+	DomainFilter    []string `yaml:"domainFilter"`
+	ExcludeDomains  []string `yaml:"excludeDomains"`
+	Registry        string   `yaml:"registry"`
+	TXTOwnerID      string   `yaml:"txtOwnerId"`
+	TXTPrefix       string   `yaml:"txtPrefix"`
+	TXTSuffix       string   `yaml:"txtSuffix"`
+	TXTNameTemplate string   `yaml:"txtNameTemplate"`
+}
+
+// providersConfig is the format of the file given by --providers-config.
+type providersConfig struct {
+	Providers []ProviderSpec `yaml:"providers"`
+}
+
+// Instance is a provider selected for a ProviderSpec, together with the configuration
+// and domain filter it was created with.
+type Instance struct {
+	Name         string
+	Config       *externaldns.Config
+	DomainFilter *endpoint.DomainFilter
+	Provider     provider.Provider
+}
+
+// LoadProviderSpecs reads and validates the multi-provider configuration file at the given path.
+func LoadProviderSpecs(path string) ([]ProviderSpec, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading providers config file %q: %w", path, err)
+	}
+
+	cfg := providersConfig{}
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing providers config file %q: %w", path, err)
+	}
+	if len(cfg.Providers) == 0 {
+		return nil, fmt.Errorf("providers config file %q does not contain any provider", path)
+	}
+
+	names := map[string]bool{}
+	for i, spec := range cfg.Providers {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("provider %d in %q has no name", i, path)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("provider name %q in %q is not unique", spec.Name, path)
+		}
+		names[spec.Name] = true
+		if len(spec.DomainFilter) == 0 {
+			return nil, fmt.Errorf("provider %q in %q has no domainFilter", spec.Name, path)
+		}
+	}
+	return cfg.Providers, nil
+}
+
+// SelectAll creates a provider for every spec, each with a copy of cfg overridden by the spec.
+func SelectAll(ctx context.Context, cfg *externaldns.Config, specs []ProviderSpec) ([]Instance, error) {
+	instances := make([]Instance, 0, len(specs))
+	for _, spec := range specs {
+		specCfg := spec.apply(cfg)
+		domainFilter := endpoint.NewDomainFilterWithOptions(
+			endpoint.WithDomainFilter(specCfg.DomainFilter),
+			endpoint.WithDomainExclude(specCfg.DomainExclude),
+		)
+		p, err := Select(ctx, specCfg, domainFilter)
+		if err != nil {
+			return nil, fmt.Errorf("provider %q: %w", spec.Name, err)
+		}
+		instances = append(instances, Instance{
+			Name:         spec.Name,
+			Config:       specCfg,
+			DomainFilter: domainFilter,
+			Provider:     p,
+		})
+	}
+	if len(instances) == 0 {
+		return nil, errors.New("no provider configured")
+	}
+	return instances, nil
+}
+
+// apply returns a copy of cfg with the fields set in the spec overridden.
+// Regex domain filters are global only and are not applied to the copy.
+func (s ProviderSpec) apply(cfg *externaldns.Config) *externaldns.Config {
+	c := *cfg
+	if s.Provider != "" {
+		c.Provider = s.Provider
+	}
+	c.DomainFilter = s.DomainFilter
+	c.DomainExclude = s.ExcludeDomains
+	c.RegexDomainFilter = nil
+	c.RegexDomainExclude = nil
+	if s.Registry != "" {
+		c.Registry = s.Registry
+	}
+	if s.TXTOwnerID != "" {
+		c.TXTOwnerID = s.TXTOwnerID
+	}
+	if s.TXTPrefix != "" || s.TXTSuffix != "" || s.TXTNameTemplate != "" {
+		c.TXTPrefix = s.TXTPrefix
+		c.TXTSuffix = s.TXTSuffix
+		c.TXTNameTemplate = s.TXTNameTemplate
+	}
+	return &c
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func writeProvidersConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestLoadProviderSpecs(t *testing.T) {
+	path := writeProvidersConfig(t, `
+providers:
+  - name: public
+    provider: aws
+    domainFilter: [example.com]
+    txtOwnerId: public
+  - name: internal
+    provider: rfc2136
+    domainFilter: [internal.example.org, lab.example.org]
+    excludeDomains: [legacy.internal.example.org]
+    registry: noop
+`)
+
+	specs, err := LoadProviderSpecs(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ProviderSpec{
+		{Name: "public", Provider: "aws", DomainFilter: []string{"example.com"}, TXTOwnerID: "public"},
+		{
+			Name:           "internal",
+			Provider:       "rfc2136",
+			DomainFilter:   []string{"internal.example.org", "lab.example.org"},
+			ExcludeDomains: []string{"legacy.internal.example.org"},
+			Registry:       "noop",
+		},
+	}, specs)
+}
+
+func TestLoadProviderSpecsErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name:     "no providers",
+			contents: "providers: []",
+			wantErr:  "does not contain any provider",
+		},
+		{
+			name:     "missing name",
+			contents: "providers: [{domainFilter: [example.com]}]",
+			wantErr:  "provider 0 in",
+		},
+		{
+			name:     "duplicate name",
+			contents: "providers: [{name: a, domainFilter: [example.com]}, {name: a, domainFilter: [example.org]}]",
+			wantErr:  `provider name "a"`,
+		},
+		{
+			name:     "missing domain filter",
+			contents: "providers: [{name: a}]",
+			wantErr:  `provider "a" in`,
+		},
+		{
+			name:     "invalid yaml",
+			contents: "providers: {",
+			wantErr:  "parsing providers config file",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadProviderSpecs(writeProvidersConfig(t, tt.contents))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := LoadProviderSpecs(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "reading providers config file")
+}
+
+func TestSelectAll(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.Provider = externaldns.ProviderInMemory
+	cfg.Registry = externaldns.RegistryTXT
+	cfg.TXTOwnerID = "default"
+	cfg.TXTPrefix = "prefix-"
+
+	instances, err := SelectAll(t.Context(), cfg, []ProviderSpec{
+		{Name: "public", DomainFilter: []string{"example.com"}},
+		{Name: "internal", DomainFilter: []string{"example.org"}, Registry: externaldns.RegistryNoop, TXTOwnerID: "internal", TXTSuffix: "-suffix"},
+		{Name: "templated", DomainFilter: []string{"example.net"}, TXTNameTemplate: "%{record_type}.%{host}._owner.%{domain}"},
+	})
+	require.NoError(t, err)
+	require.Len(t, instances, 3)
+
+	public := instances[0]
+	assert.Equal(t, "public", public.Name)
+	mw, ok := public.Provider.(*AliasNormalizingMiddleware)
+	require.True(t, ok, "expected outer *AliasNormalizingMiddleware, got %T", public.Provider)
+	assert.Equal(t, "*inmemory.InMemoryProvider", reflect.TypeOf(mw.Provider).String())
+	assert.True(t, public.DomainFilter.Match("foo.example.com"))
+	assert.False(t, public.DomainFilter.Match("foo.example.org"))
+	assert.Equal(t, externaldns.RegistryTXT, public.Config.Registry)
+	assert.Equal(t, "default", public.Config.TXTOwnerID)
+	assert.Equal(t, "prefix-", public.Config.TXTPrefix)
+
+	internal := instances[1]
+	assert.True(t, internal.DomainFilter.Match("foo.example.org"))
+	assert.Equal(t, externaldns.RegistryNoop, internal.Config.Registry)
+	assert.Equal(t, "internal", internal.Config.TXTOwnerID)
+	assert.Empty(t, internal.Config.TXTPrefix)
+	assert.Equal(t, "-suffix", internal.Config.TXTSuffix)
+
+	templated := instances[2]
+	assert.Empty(t, templated.Config.TXTPrefix)
+	assert.Equal(t, "%{record_type}.%{host}._owner.%{domain}", templated.Config.TXTNameTemplate)
+
+	assert.Equal(t, "default", cfg.TXTOwnerID, "the global configuration is not modified")
+}
+
+func TestSelectAllUnknownProvider(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	_, err := SelectAll(t.Context(), cfg, []ProviderSpec{{Name: "broken", Provider: "unknown", DomainFilter: []string{"example.com"}}})
+	require.EqualError(t, err, `provider "broken": unknown dns provider: unknown`)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
@@ -386,6 +387,20 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 	return changes
 }
 
+// ZoneIDNames returns the DNS names of the managed zones by zone name.
+func (p *GoogleProvider) ZoneIDNames(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := provider.ZoneIDName{}
+	for name, zone := range zones {
+		names.Add(name, strings.TrimSuffix(zone.DnsName, "."))
+	}
+	return names, nil
+}
+
 // separateChange separates a multi-zone change into a single change per zone.
 func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[string]*dns.Change {
 	changes := make(map[string]*dns.Change)
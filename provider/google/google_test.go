@@ -218,6 +218,14 @@ func TestGoogleZonesIDFilter(t *testing.T) {
 	})
 }
 
+func TestGoogleZoneIDNames(t *testing.T) {
+	provider := newGoogleProviderZoneOverlap(t, endpoint.NewDomainFilter([]string{"cluster.local."}), provider.NewZoneIDFilter([]string{"10002"}), provider.NewZoneTypeFilter(""), []*endpoint.Endpoint{})
+
+	zones, err := provider.ZoneIDNames(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"internal-2": "cluster.local"}, map[string]string(zones))
+}
+
 func TestGoogleZonesNameFilter(t *testing.T) {
 	provider := newGoogleProviderZoneOverlap(t, endpoint.NewDomainFilter([]string{"cluster.local."}), provider.NewZoneIDFilter([]string{"internal-2"}), provider.NewZoneTypeFilter(""), []*endpoint.Endpoint{})
 
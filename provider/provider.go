@@ -58,6 +58,13 @@ type Provider interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 }
 
+// ZoneLister is implemented by providers that can list the zones they manage.
+// It allows the controller to apply changes per zone.
+type ZoneLister interface {
+	// ZoneIDNames returns the names of the managed zones by zone ID, without trailing dot.
+	ZoneIDNames(ctx context.Context) (ZoneIDName, error)
+}
+
 type BaseProvider struct{}
 
 // AdjustEndpoints returns the endpoints unchanged. Providers that need to
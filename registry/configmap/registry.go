@@ -0,0 +1,400 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	kubeclient "sigs.k8s.io/external-dns/pkg/client"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+const (
+	// registryLabel is set on the ConfigMaps of the registry to the registry name
+	registryLabel = "externaldns.k8s.io/registry"
+	// recordsKey is the ConfigMap data key holding the records of a shard
+	recordsKey = "records.json"
+	// maxShardSize keeps a shard below the 1 MiB limit of Kubernetes objects, leaving room for its metadata
+	maxShardSize = 900 * 1024
+)
+
+// ConfigMapRegistry implements the registry interface with ownership stored in ConfigMaps.
+// The records are spread over a number of ConfigMaps, the shards, by a hash of their key, and every
+// shard is updated with the resourceVersion it was read with, so that concurrent writers fail
+// instead of overwriting each other.
+type ConfigMapRegistry struct {
+	client    kubernetes.Interface
+	provider  provider.Provider
+	namespace string
+	name      string
+	shards    int
+	ownerID   string
+
+	applyMutex sync.Mutex
+	// configMaps are the shards read last, by name
+	configMaps map[string]*corev1.ConfigMap
+	// labels are the labels of the owned records, read from the shards
+	labels map[endpoint.EndpointKey]endpoint.Labels
+	// orphanedLabels are the labels of records missing from the provider, deleted on the next ApplyChanges
+	orphanedLabels sets.Set[endpoint.EndpointKey]
+}
+
+// record is the stored form of the labels of a record. The owner is implied by the ConfigMap.
+type record struct {
+	DNSName       string          `json:"dnsName"`
+	RecordType    string          `json:"recordType"`
+	SetIdentifier string          `json:"setIdentifier,omitempty"`
+	Labels        endpoint.Labels `json:"labels,omitempty"`
+}
+
+var _ registry.Registry = &ConfigMapRegistry{}
+
+// New creates a ConfigMapRegistry from the given configuration.
+func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
+	restConfig, err := kubeclient.InstrumentedRESTConfig(cfg.KubeConfig, cfg.APIServerURL, cfg.KubeAPIRequestTimeout, cfg.KubeAPIQPS, cfg.KubeAPIBurst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build rest config: %w", err)
+	}
+	client, err := kubeclient.NewKubeClient(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newRegistry(p, client, kubeclient.InClusterNamespace(cfg.ConfigMapRegistryNamespace),
+		cfg.ConfigMapRegistryName, cfg.ConfigMapRegistryShards, cfg.TXTOwnerID)
+}
+
+// newRegistry returns a new ConfigMapRegistry object.
+func newRegistry(provider provider.Provider, client kubernetes.Interface, namespace, name string, shards int, ownerID string) (*ConfigMapRegistry, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner id cannot be empty")
+	}
+	if name == "" {
+		return nil, errors.New("ConfigMap name cannot be empty")
+	}
+	if shards < 1 {
+		return nil, errors.New("number of shards must be at least 1")
+	}
+	return &ConfigMapRegistry{
+		client:    client,
+		provider:  provider,
+		namespace: namespace,
+		name:      name,
+		shards:    shards,
+		ownerID:   ownerID,
+	}, nil
+}
+
+// GetDomainFilter returns the domain filter from the underlying provider.
+func (im *ConfigMapRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return im.provider.GetDomainFilter()
+}
+
+// OwnerID returns the owner ID of this instance.
+func (im *ConfigMapRegistry) OwnerID() string {
+	return im.ownerID
+}
+
+// Records returns the current records from the provider, with the labels stored in the ConfigMaps.
+func (im *ConfigMapRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	im.applyMutex.Lock()
+	defer im.applyMutex.Unlock()
+
+	if err := im.readLabels(ctx); err != nil {
+		return nil, err
+	}
+
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orphanedLabels := sets.NewFromMapKeys(im.labels)
+	for _, r := range records {
+		if labels := im.labels[r.Key()]; labels != nil {
+			r.Labels = maps.Clone(labels)
+			orphanedLabels.Delete(r.Key())
+		} else {
+			r.Labels = endpoint.NewLabels()
+		}
+	}
+	im.orphanedLabels = orphanedLabels
+	return records, nil
+}
+
+// ApplyChanges stores the labels of created and updated records, applies the changes to the provider,
+// then deletes the labels of deleted and orphaned records.
+func (im *ConfigMapRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	im.applyMutex.Lock()
+	defer im.applyMutex.Unlock()
+
+	filteredChanges := &plan.Changes{
+		Create:    changes.Create,
+		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
+		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
+		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
+	}
+
+	if im.labels == nil {
+		if err := im.readLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	newLabels := maps.Clone(im.labels)
+	for _, r := range filteredChanges.Create {
+		if r.Labels == nil {
+			r.Labels = endpoint.NewLabels()
+		}
+		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		newLabels[r.Key()] = maps.Clone(r.Labels)
+		im.orphanedLabels.Delete(r.Key())
+	}
+	for _, r := range filteredChanges.UpdateNew {
+		newLabels[r.Key()] = maps.Clone(r.Labels)
+	}
+	if err := im.writeLabels(ctx, newLabels); err != nil {
+		im.reset()
+		return err
+	}
+
+	if err := im.provider.ApplyChanges(ctx, filteredChanges); err != nil {
+		im.reset()
+		return err
+	}
+
+	for _, r := range filteredChanges.Delete {
+		delete(newLabels, r.Key())
+	}
+	for key := range im.orphanedLabels {
+		delete(newLabels, key)
+	}
+	im.orphanedLabels = nil
+	if err := im.writeLabels(ctx, newLabels); err != nil {
+		im.reset()
+		return err
+	}
+	return nil
+}
+
+// AdjustEndpoints modifies the endpoints as needed by the specific provider.
+func (im *ConfigMapRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return im.provider.AdjustEndpoints(endpoints)
+}
+
+// reset forgets the shards, so that they are read again with their current resourceVersion.
+func (im *ConfigMapRegistry) reset() {
+	im.configMaps = nil
+	im.labels = nil
+}
+
+// readLabels reads the labels of the owned records from all shards, including shards beyond
+// the configured number of shards, whose records are moved on the next write. It fails when
+// a ConfigMap of the same name is owned by another owner ID, since the shards of both owners
+// would have the same names.
+func (im *ConfigMapRegistry) readLabels(ctx context.Context) error {
+	list, err := im.client.CoreV1().ConfigMaps(im.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{registryLabel: im.name}).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("listing ConfigMaps in %s: %w", im.namespace, err)
+	}
+
+	configMaps := make(map[string]*corev1.ConfigMap, len(list.Items))
+	allLabels := map[endpoint.EndpointKey]endpoint.Labels{}
+	for i := range list.Items {
+		cm := &list.Items[i]
+		if owner := cm.Labels[apiv1alpha1.RecordOwnerLabel]; owner != im.ownerID {
+			return fmt.Errorf("ConfigMap %s/%s is owned by %q instead of %q, deployments with different owner IDs must use different --configmap-registry-name", cm.Namespace, cm.Name, owner, im.ownerID)
+		}
+		var records []record
+		if data := cm.Data[recordsKey]; data != "" {
+			if err := json.Unmarshal([]byte(data), &records); err != nil {
+				return fmt.Errorf("parsing ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+			}
+		}
+		for _, r := range records {
+			l := endpoint.NewLabels()
+			maps.Copy(l, r.Labels)
+			l[endpoint.OwnerLabelKey] = im.ownerID
+			allLabels[endpoint.EndpointKey{DNSName: r.DNSName, RecordType: r.RecordType, SetIdentifier: r.SetIdentifier}] = l
+		}
+		configMaps[cm.Name] = cm
+	}
+
+	im.configMaps = configMaps
+	im.labels = allLabels
+	return nil
+}
+
+// writeLabels writes the shards whose records changed. Shards beyond the configured number
+// of shards are emptied and deleted.
+func (im *ConfigMapRegistry) writeLabels(ctx context.Context, allLabels map[endpoint.EndpointKey]endpoint.Labels) error {
+	shards := map[string][]record{}
+	for i := range im.shards {
+		shards[im.shardName(i)] = []record{}
+	}
+	for name := range im.configMaps {
+		if _, ok := shards[name]; !ok {
+			shards[name] = nil
+		}
+	}
+	for key, l := range allLabels {
+		stored := maps.Clone(l)
+		delete(stored, endpoint.OwnerLabelKey)
+		name := im.shardFor(key)
+		shards[name] = append(shards[name], record{
+			DNSName:       key.DNSName,
+			RecordType:    key.RecordType,
+			SetIdentifier: key.SetIdentifier,
+			Labels:        stored,
+		})
+	}
+
+	var extraShards []*corev1.ConfigMap
+	for _, name := range slices.Sorted(maps.Keys(shards)) {
+		records := shards[name]
+		existing := im.configMaps[name]
+		if records == nil {
+			extraShards = append(extraShards, existing)
+			continue
+		}
+
+		slices.SortFunc(records, func(a, b record) int {
+			return cmp.Or(
+				cmp.Compare(a.DNSName, b.DNSName),
+				cmp.Compare(a.RecordType, b.RecordType),
+				cmp.Compare(a.SetIdentifier, b.SetIdentifier),
+			)
+		})
+		data, err := json.Marshal(records)
+		if err != nil {
+			return fmt.Errorf("serializing ConfigMap %s/%s: %w", im.namespace, name, err)
+		}
+		if len(data) > maxShardSize {
+			return fmt.Errorf("ConfigMap %s/%s would exceed %d bytes, increase --configmap-registry-shards", im.namespace, name, maxShardSize)
+		}
+		if existing == nil && len(records) == 0 {
+			continue
+		}
+		if existing != nil && bytes.Equal([]byte(existing.Data[recordsKey]), data) {
+			continue
+		}
+		if err := im.writeShard(ctx, name, existing, string(data)); err != nil {
+			return err
+		}
+	}
+	// the records of the extra shards were written to the configured shards above
+	for _, cm := range extraShards {
+		if err := im.deleteShard(ctx, cm); err != nil {
+			return err
+		}
+	}
+	im.labels = allLabels
+	return nil
+}
+
+// writeShard creates the shard, or updates it when it was read, failing if it changed since.
+func (im *ConfigMapRegistry) writeShard(ctx context.Context, name string, existing *corev1.ConfigMap, data string) error {
+	configMaps := im.client.CoreV1().ConfigMaps(im.namespace)
+	if existing == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: im.namespace,
+				Labels:    im.configMapLabels(),
+			},
+			Data: map[string]string{recordsKey: data},
+		}
+		created, err := configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				return fmt.Errorf("ConfigMap %s/%s already exists, it is either written concurrently or not owned by %q: %w", im.namespace, name, im.ownerID, err)
+			}
+			return fmt.Errorf("creating ConfigMap %s/%s: %w", im.namespace, name, err)
+		}
+		log.Debugf("Created ConfigMap %s/%s", im.namespace, name)
+		im.configMaps[name] = created
+		return nil
+	}
+
+	cm := existing.DeepCopy()
+	cm.Data = map[string]string{recordsKey: data}
+	updated, err := configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		if k8sErrors.IsConflict(err) {
+			return fmt.Errorf("ConfigMap %s/%s was modified concurrently, retrying on the next synchronization: %w", im.namespace, name, err)
+		}
+		return fmt.Errorf("updating ConfigMap %s/%s: %w", im.namespace, name, err)
+	}
+	log.Debugf("Updated ConfigMap %s/%s", im.namespace, name)
+	im.configMaps[name] = updated
+	return nil
+}
+
+// deleteShard deletes a shard beyond the configured number of shards, once its records were moved.
+func (im *ConfigMapRegistry) deleteShard(ctx context.Context, cm *corev1.ConfigMap) error {
+	err := im.client.CoreV1().ConfigMaps(im.namespace).Delete(ctx, cm.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &cm.ResourceVersion},
+	})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("deleting ConfigMap %s/%s: %w", im.namespace, cm.Name, err)
+	}
+	log.Infof("Deleted ConfigMap %s/%s beyond the configured number of shards", im.namespace, cm.Name)
+	delete(im.configMaps, cm.Name)
+	return nil
+}
+
+// configMapLabels are the labels of the shards, which are listed by them.
+func (im *ConfigMapRegistry) configMapLabels() map[string]string {
+	return map[string]string{
+		registryLabel:                im.name,
+		apiv1alpha1.RecordOwnerLabel: im.ownerID,
+	}
+}
+
+func (im *ConfigMapRegistry) shardName(i int) string {
+	return fmt.Sprintf("%s-%d", im.name, i)
+}
+
+// shardFor returns the name of the shard the labels of the record with the given key are stored in.
+func (im *ConfigMapRegistry) shardFor(key endpoint.EndpointKey) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.DNSName + "#" + key.RecordType + "#" + key.SetIdentifier))
+	return im.shardName(int(h.Sum32() % uint32(im.shards)))
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+const testNamespace = "external-dns"
+
+func newTestRegistry(t *testing.T, p *inmemory.InMemoryProvider, client kubernetes.Interface, shards int) *ConfigMapRegistry {
+	t.Helper()
+	r, err := newRegistry(p, client, testNamespace, "registry", shards, "owner")
+	require.NoError(t, err)
+	return r
+}
+
+func newTestProvider(t *testing.T) *inmemory.InMemoryProvider {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+	return p
+}
+
+func listConfigMaps(t *testing.T, client kubernetes.Interface) map[string]*corev1.ConfigMap {
+	t.Helper()
+	list, err := client.CoreV1().ConfigMaps(testNamespace).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	configMaps := map[string]*corev1.ConfigMap{}
+	for i := range list.Items {
+		configMaps[list.Items[i].Name] = &list.Items[i]
+	}
+	return configMaps
+}
+
+func recordsByName(records []*endpoint.Endpoint) map[string]*endpoint.Endpoint {
+	byName := map[string]*endpoint.Endpoint{}
+	for _, r := range records {
+		byName[r.DNSName] = r
+	}
+	return byName
+}
+
+func TestNewRegistry(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	client := fake.NewClientset()
+
+	_, err := newRegistry(p, client, testNamespace, "registry", 1, "")
+	require.EqualError(t, err, "owner id cannot be empty")
+
+	_, err = newRegistry(p, client, testNamespace, "", 1, "owner")
+	require.EqualError(t, err, "ConfigMap name cannot be empty")
+
+	_, err = newRegistry(p, client, testNamespace, "registry", 0, "owner")
+	require.EqualError(t, err, "number of shards must be at least 1")
+
+	r, err := newRegistry(p, client, testNamespace, "registry", 2, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, "owner", r.OwnerID())
+}
+
+func TestConfigMapRegistryApplyChanges(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	r := newTestRegistry(t, p, client, 1)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, "service/default/a"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "a.example.org").
+				WithSetIdentifier("blue"),
+		},
+	}))
+
+	configMaps := listConfigMaps(t, client)
+	require.Len(t, configMaps, 1)
+	cm := configMaps["registry-0"]
+	require.NotNil(t, cm)
+	assert.Equal(t, map[string]string{registryLabel: "registry", "externaldns.k8s.io/owner": "owner"}, cm.Labels)
+	assert.JSONEq(t, `[
+		{"dnsName": "a.example.org", "recordType": "A", "labels": {"resource": "service/default/a"}},
+		{"dnsName": "b.example.org", "recordType": "CNAME", "setIdentifier": "blue"}
+	]`, cm.Data[recordsKey])
+
+	// a new instance reads the ownership back
+	records, err := newTestRegistry(t, p, client, 1).Records(ctx)
+	require.NoError(t, err)
+	byName := recordsByName(records)
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "owner", endpoint.ResourceLabelKey: "service/default/a"}, byName["a.example.org"].Labels)
+	assert.Equal(t, endpoint.Labels{endpoint.OwnerLabelKey: "owner"}, byName["b.example.org"].Labels)
+
+	// updates and deletions are stored
+	updated := byName["a.example.org"].DeepCopy()
+	updated.Labels[endpoint.ResourceLabelKey] = "service/default/a2"
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{byName["a.example.org"]},
+		UpdateNew: []*endpoint.Endpoint{updated},
+		Delete:    []*endpoint.Endpoint{byName["b.example.org"]},
+	}))
+	assert.JSONEq(t, `[
+		{"dnsName": "a.example.org", "recordType": "A", "labels": {"resource": "service/default/a2"}}
+	]`, listConfigMaps(t, client)["registry-0"].Data[recordsKey])
+}
+
+func TestConfigMapRegistryIgnoresOtherOwners(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+	client := fake.NewClientset()
+	other, err := newRegistry(p, client, testNamespace, "other-registry", 1, "other")
+	require.NoError(t, err)
+	_, err = other.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, other.writeLabels(ctx, map[endpoint.EndpointKey]endpoint.Labels{
+		{DNSName: "other.example.org", RecordType: endpoint.RecordTypeA}: {endpoint.OwnerLabelKey: "other"},
+	}))
+
+	records, err := newTestRegistry(t, p, client, 1).Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Labels[endpoint.OwnerLabelKey])
+}
+
+func TestConfigMapRegistryOtherOwnerSameName(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	r := newTestRegistry(t, p, client, 2)
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+	before := listConfigMaps(t, client)
+
+	other, err := newRegistry(p, client, testNamespace, "registry", 2, "other")
+	require.NoError(t, err)
+	_, err = other.Records(ctx)
+	require.ErrorContains(t, err, `is owned by "owner" instead of "other"`)
+	err = other.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.ErrorContains(t, err, `is owned by "owner" instead of "other"`)
+
+	assert.Equal(t, before, listConfigMaps(t, client))
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+}
+
+func TestConfigMapRegistryDeletesOrphanedLabels(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	r := newTestRegistry(t, p, client, 1)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.writeLabels(ctx, map[endpoint.EndpointKey]endpoint.Labels{
+		{DNSName: "gone.example.org", RecordType: endpoint.RecordTypeA}:    {endpoint.OwnerLabelKey: "owner"},
+		{DNSName: "created.example.org", RecordType: endpoint.RecordTypeA}: {endpoint.OwnerLabelKey: "owner"},
+	}))
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("created.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+
+	assert.JSONEq(t, `[
+		{"dnsName": "created.example.org", "recordType": "A"}
+	]`, listConfigMaps(t, client)["registry-0"].Data[recordsKey])
+}
+
+func TestConfigMapRegistrySharding(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	r := newTestRegistry(t, p, client, 1)
+
+	var created []*endpoint.Endpoint
+	for i := range 20 {
+		created = append(created, endpoint.NewEndpoint(fmt.Sprintf("record-%d.example.org", i), endpoint.RecordTypeA, "1.2.3.4"))
+	}
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: created}))
+	require.Len(t, listConfigMaps(t, client), 1)
+
+	// the records are moved to the new shards on the next write
+	resharded := newTestRegistry(t, p, client, 3)
+	records, err := resharded.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey], record.DNSName)
+	}
+	require.NoError(t, resharded.ApplyChanges(ctx, &plan.Changes{}))
+
+	configMaps := listConfigMaps(t, client)
+	assert.Len(t, configMaps, 3)
+	total := 0
+	for name, cm := range configMaps {
+		assert.True(t, strings.HasPrefix(name, "registry-"))
+		assert.NotEqual(t, `[]`, cm.Data[recordsKey], name)
+		total += strings.Count(cm.Data[recordsKey], `"dnsName"`)
+	}
+	assert.Equal(t, 20, total)
+
+	// shrinking deletes the shards beyond the configured number
+	shrunk := newTestRegistry(t, p, client, 1)
+	records, err = shrunk.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 20)
+	require.NoError(t, shrunk.ApplyChanges(ctx, &plan.Changes{}))
+	configMaps = listConfigMaps(t, client)
+	assert.Len(t, configMaps, 1)
+	assert.Equal(t, 20, strings.Count(configMaps["registry-0"].Data[recordsKey], `"dnsName"`))
+}
+
+func TestConfigMapRegistryShardSizeLimit(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	r := newTestRegistry(t, p, fake.NewClientset(), 1)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	err = r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("big.example.org", endpoint.RecordTypeA, "1.2.3.4").
+				WithLabel(endpoint.ResourceLabelKey, strings.Repeat("x", maxShardSize)),
+		},
+	})
+	require.ErrorContains(t, err, "increase --configmap-registry-shards")
+}
+
+func TestConfigMapRegistryConflict(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	r := newTestRegistry(t, p, client, 1)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+
+	// the fake clientset does not check resourceVersion, so a concurrent update is simulated
+	client.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		cm := action.(k8stesting.UpdateAction).GetObject().(*corev1.ConfigMap)
+		return true, nil, k8sErrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, cm.Name, fmt.Errorf("resourceVersion %s is outdated", cm.ResourceVersion))
+	})
+	err = r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.ErrorContains(t, err, "was modified concurrently")
+	assert.Nil(t, r.labels, "shards are read again after a conflict")
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "the provider is not changed when the ownership cannot be stored")
+}
+
+// failingProvider fails to apply any change.
+type failingProvider struct {
+	provider.Provider
+}
+
+func (p *failingProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return errors.New("failed to apply changes")
+}
+
+func TestConfigMapRegistryProviderFailure(t *testing.T) {
+	ctx := t.Context()
+	p := newTestProvider(t)
+	client := fake.NewClientset()
+	failing, err := newRegistry(&failingProvider{Provider: p}, client, testNamespace, "registry", 1, "owner")
+	require.NoError(t, err)
+
+	_, err = failing.Records(ctx)
+	require.NoError(t, err)
+	err = failing.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.EqualError(t, err, "failed to apply changes")
+	assert.Nil(t, failing.labels, "shards are read again after a failure")
+	assert.Contains(t, listConfigMaps(t, client)["registry-0"].Data[recordsKey], "a.example.org")
+
+	// the ownership written before the failure is orphaned and deleted on the next synchronization
+	r := newTestRegistry(t, p, client, 1)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{}))
+	assert.Equal(t, `[]`, listConfigMaps(t, client)["registry-0"].Data[recordsKey])
+}
@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"maps"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -31,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -57,10 +60,29 @@ type CRDRegistry struct {
 	namespace string
 	provider  provider.Provider
 	ownerID   string // refers to the owner id of the current instance
+
+	// verifyInterval is the interval between the verifications of the DNSRecord
+	// objects against the provider records, 0 when they are not verified.
+	verifyInterval time.Duration
+	lastVerified   time.Time
+	// verified holds the DNSRecord objects with the status observed by the last
+	// verification, by name, and stale the names of the objects whose record is
+	// still missing since the previous verification. Records() only reads, so
+	// the status is written, and the stale objects deleted, by Cleanup.
+	verified    map[string]*apiv1alpha1.DNSRecord
+	stale       sets.Set[string]
+	verifyMutex sync.Mutex
+	// now returns the current time, time.Now is used when nil
+	now func() time.Time
 }
 
 func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
-	return NewCRDRegistry(p, cfg.KubeConfig, cfg.APIServerURL, cfg.Namespace, cfg.TXTOwnerID, cfg.RequestTimeout)
+	r, err := NewCRDRegistry(p, cfg.KubeConfig, cfg.APIServerURL, cfg.Namespace, cfg.TXTOwnerID, cfg.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	r.verifyInterval = cfg.CRDRegistryVerifyInterval
+	return r, nil
 }
 
 // NewCRDRegistry returns new CRDRegistry object backed by a controller-runtime
@@ -146,20 +168,209 @@ func (cr *CRDRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		return []*endpoint.Endpoint{}, err
 	}
 
-	endpoints := make([]*endpoint.Endpoint, 0, len(records.Items))
-	for i := range records.Items {
+	items := cr.withVerifiedStatus(records.Items)
+	if cr.verifyInterval > 0 && cr.clock().Sub(cr.lastVerified) >= cr.verifyInterval {
+		if err := cr.verify(ctx, items); err != nil {
+			return []*endpoint.Endpoint{}, err
+		}
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0, len(items))
+	for i := range items {
 		// Only records confirmed applied to the provider represent current
 		// state. An un-programmed record — one left Accepted by a provider
-		// failure — is skipped so the plan re-applies it on the next reconcile
-		// instead of mistaking it for a record that already exists.
-		if !meta.IsStatusConditionTrue(records.Items[i].Status.Conditions, apiv1alpha1.ReadyCondition) {
+		// failure, or found Missing by a verification — is skipped so the plan
+		// re-applies it on the next reconcile instead of mistaking it for a
+		// record that already exists.
+		if !meta.IsStatusConditionTrue(items[i].Status.Conditions, apiv1alpha1.ReadyCondition) {
 			continue
 		}
-		endpoints = append(endpoints, &records.Items[i].Spec.Endpoint)
+		endpoints = append(endpoints, currentEndpoint(&items[i]))
 	}
 	return endpoints, nil
 }
 
+// currentEndpoint returns the endpoint of the DNSRecord as it stands in the
+// provider: a record which drifted from its spec is reported with the targets
+// and TTL observed in the provider, so that the plan restores it.
+func currentEndpoint(dnsrecord *apiv1alpha1.DNSRecord) *endpoint.Endpoint {
+	cond := meta.FindStatusCondition(dnsrecord.Status.Conditions, apiv1alpha1.InSyncCondition)
+	if cond == nil || cond.Reason != apiv1alpha1.DriftedReason {
+		return &dnsrecord.Spec.Endpoint
+	}
+	current := dnsrecord.Spec.Endpoint.DeepCopy()
+	current.Targets = dnsrecord.Status.ObservedTargets
+	if current.RecordTTL.IsConfigured() {
+		current.RecordTTL = dnsrecord.Status.ObservedTTL
+	}
+	return current
+}
+
+// verify compares the DNSRecord objects with the provider records, and records
+// what was observed in their status, in place. The objects are only written by
+// the next Cleanup.
+//
+// A Programmed record missing from the provider was removed out-of-band: its
+// object is marked Missing, so that the plan creates the record again if it is
+// still desired. A record still missing on the next verification is no longer
+// desired, and its object is stale.
+func (cr *CRDRegistry) verify(ctx context.Context, items []apiv1alpha1.DNSRecord) error {
+	records, err := cr.provider.Records(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get records from provider: %w", err)
+	}
+	byName := recordsByObjectName(records)
+	now := metav1.NewTime(cr.clock())
+
+	verified := make(map[string]*apiv1alpha1.DNSRecord, len(items))
+	stale := sets.New[string]()
+	for i := range items {
+		dnsrecord := &items[i]
+		ready := meta.FindStatusCondition(dnsrecord.Status.Conditions, apiv1alpha1.ReadyCondition)
+		record, found := byName[dnsrecord.Name]
+		switch {
+		case found:
+			cr.observe(dnsrecord, record, now)
+			if ready != nil && ready.Reason == apiv1alpha1.MissingReason {
+				cr.setReady(dnsrecord, apiv1alpha1.ProgrammedReason, "Endpoint found in the DNS provider")
+			}
+		case ready != nil && ready.Reason == apiv1alpha1.MissingReason:
+			stale.Insert(dnsrecord.Name)
+		case ready != nil && ready.Reason == apiv1alpha1.ProgrammedReason:
+			log.Warnf("The record of DNSRecord %s in %s is missing from the DNS provider", dnsrecord.Name, cr.namespace)
+			message := "Record removed from the DNS provider out-of-band"
+			cr.setReady(dnsrecord, apiv1alpha1.MissingReason, message)
+			meta.SetStatusCondition(&dnsrecord.Status.Conditions, metav1.Condition{
+				Type:    apiv1alpha1.InSyncCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  apiv1alpha1.MissingReason,
+				Message: message,
+			})
+			dnsrecord.Status.LastVerifiedTime = &now
+		default:
+			// the record of an un-programmed object is re-applied by the plan
+			continue
+		}
+		verified[dnsrecord.Name] = dnsrecord.DeepCopy()
+	}
+
+	cr.verifyMutex.Lock()
+	cr.verified = verified
+	cr.stale = stale
+	cr.verifyMutex.Unlock()
+	cr.lastVerified = now.Time
+	return nil
+}
+
+// withVerifiedStatus returns the DNSRecord objects with the status observed by
+// the last verification, until Cleanup writes it. The status is dropped
+// when the spec of the object changed since.
+func (cr *CRDRegistry) withVerifiedStatus(items []apiv1alpha1.DNSRecord) []apiv1alpha1.DNSRecord {
+	cr.verifyMutex.Lock()
+	defer cr.verifyMutex.Unlock()
+	for i := range items {
+		if dnsrecord, ok := cr.verified[items[i].Name]; ok && dnsrecord.Generation == items[i].Generation {
+			items[i].Status = *dnsrecord.Status.DeepCopy()
+		}
+	}
+	return items
+}
+
+// skipVerified forgets the objects of the records applied by the changes, which
+// ApplyChanges writes itself: the objects of the records created again are
+// Programmed again, and the objects of the deleted records are deleted.
+func (cr *CRDRegistry) skipVerified(changes *plan.Changes) {
+	cr.verifyMutex.Lock()
+	defer cr.verifyMutex.Unlock()
+	if len(cr.verified) == 0 {
+		return
+	}
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew, changes.Delete) {
+		name := recordObjectName(ep)
+		delete(cr.verified, name)
+		cr.stale.Delete(name)
+	}
+}
+
+// Cleanup writes the status observed by the last verification, and deletes the
+// stale objects, once. It is called by the controller on every run after the
+// changes are applied, so the objects are written even when there are no
+// changes. The writes are conditioned on the resource version observed by the
+// verification, so that an object applied since is not overwritten.
+func (cr *CRDRegistry) Cleanup(ctx context.Context) error {
+	cr.verifyMutex.Lock()
+	verified, stale := cr.verified, cr.stale
+	cr.verified, cr.stale = nil, nil
+	cr.verifyMutex.Unlock()
+
+	for _, name := range slices.Sorted(maps.Keys(verified)) {
+		dnsrecord := verified[name]
+		if !stale.Has(name) {
+			cr.updateStatus(ctx, dnsrecord)
+			continue
+		}
+		log.Infof("Deleting DNSRecord %s in %s: its record is still missing from the DNS provider", dnsrecord.Name, cr.namespace)
+		err := cr.crWriter.Delete(ctx, dnsrecord, client.Preconditions{ResourceVersion: &dnsrecord.ResourceVersion})
+		if err != nil && !k8sErrors.IsNotFound(err) && !k8sErrors.IsConflict(err) {
+			return fmt.Errorf("unable to delete DNSRecord %s in %s: %w", dnsrecord.Name, cr.namespace, err)
+		}
+	}
+	return nil
+}
+
+// observe records the state of the record observed in the provider in the
+// status of its DNSRecord, and whether it drifted from the spec.
+func (cr *CRDRegistry) observe(dnsrecord *apiv1alpha1.DNSRecord, record *endpoint.Endpoint, now metav1.Time) {
+	dnsrecord.Status.ObservedTargets = record.Targets
+	dnsrecord.Status.ObservedTTL = record.RecordTTL
+	dnsrecord.Status.LastVerifiedTime = &now
+
+	cond := metav1.Condition{
+		Type:    apiv1alpha1.InSyncCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  apiv1alpha1.InSyncReason,
+		Message: "Record matches the DNS provider",
+	}
+	if drift := recordDrift(&dnsrecord.Spec.Endpoint, record); drift != "" {
+		log.Warnf("The record of DNSRecord %s in %s drifted in the DNS provider: %s", dnsrecord.Name, cr.namespace, drift)
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = apiv1alpha1.DriftedReason
+		cond.Message = "Record changed out-of-band: " + drift
+	}
+	meta.SetStatusCondition(&dnsrecord.Status.Conditions, cond)
+}
+
+// recordDrift describes how the record observed in the provider differs from
+// the spec, or returns an empty string when it does not. The TTL is only
+// compared when the spec sets it, as providers apply their own default.
+func recordDrift(spec, observed *endpoint.Endpoint) string {
+	var drift []string
+	if !spec.Targets.Same(observed.Targets) {
+		drift = append(drift, fmt.Sprintf("targets %s instead of %s", observed.Targets, spec.Targets))
+	}
+	if spec.RecordTTL.IsConfigured() && spec.RecordTTL != observed.RecordTTL {
+		drift = append(drift, fmt.Sprintf("TTL %d instead of %d", observed.RecordTTL, spec.RecordTTL))
+	}
+	return strings.Join(drift, ", ")
+}
+
+// recordsByObjectName indexes the provider records by the name of the DNSRecord
+// object backing them.
+func recordsByObjectName(records []*endpoint.Endpoint) map[string]*endpoint.Endpoint {
+	byName := make(map[string]*endpoint.Endpoint, len(records))
+	for _, record := range records {
+		byName[recordObjectName(record)] = record
+	}
+	return byName
+}
+
+func (cr *CRDRegistry) clock() time.Time {
+	if cr.now != nil {
+		return cr.now()
+	}
+	return time.Now()
+}
+
 // ApplyChanges updates dns provider with the changes and creates/updates/delete a DNSRecord accordingly.
 //
 // Intent is recorded first: the DNSRecord objects for created and updated
@@ -183,6 +394,8 @@ func (cr *CRDRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		Delete:    endpoint.FilterEndpointsByOwnerID(cr.ownerID, changes.Delete),
 	}
 
+	cr.skipVerified(filteredChanges)
+
 	// Stamp the owner label before applying so the provider sees owner-labeled
 	// endpoints, matching the TXT registry behavior.
 	for _, r := range filteredChanges.Create {
@@ -204,6 +417,15 @@ func (cr *CRDRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	// Update existing DNS records to reflect the newest change.
 	for i, e := range filteredChanges.UpdateNew {
 		old := filteredChanges.UpdateOld[i]
+		if old.IsAdopted() {
+			// records without owner adopted by a resource have no DNSRecord yet
+			dnsrecord, err := cr.ensureDNSRecord(ctx, e)
+			if err != nil {
+				return err
+			}
+			applied = append(applied, dnsrecord)
+			continue
+		}
 		dnsrecord, err := cr.getDNSRecord(ctx, old)
 		if err != nil {
 			return fmt.Errorf("unable to get DNSRecord of %s: %w", old, err)
@@ -236,7 +458,9 @@ func (cr *CRDRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	}
 
 	// Provider accepted the changes; mark the records Programmed.
+	appliedAt := metav1.NewTime(cr.clock())
 	for _, dnsrecord := range applied {
+		dnsrecord.Status.LastAppliedTime = &appliedAt
 		cr.setStatus(ctx, dnsrecord, apiv1alpha1.ProgrammedReason, "Endpoint applied to the DNS provider")
 	}
 
@@ -356,22 +580,35 @@ func (cr *CRDRegistry) ensureDNSRecord(ctx context.Context, record *endpoint.End
 }
 
 // setStatus sets the Ready condition on the DNSRecord with the given reason and
-// message, and persists the status subresource. Ready is True only for
-// ProgrammedReason (the endpoint is live in the provider); every other reason
-// leaves it False. Status is best-effort observability: a failure to write it is
-// logged but never fails reconciliation, since the DNS record itself is already
-// applied (or its failure already surfaced through the apply error).
+// message, and persists the status subresource.
 func (cr *CRDRegistry) setStatus(ctx context.Context, dnsrecord *apiv1alpha1.DNSRecord, reason, message string) {
+	cr.setReady(dnsrecord, reason, message)
+	cr.updateStatus(ctx, dnsrecord)
+}
+
+// setReady sets the Ready condition on the DNSRecord with the given reason and
+// message. Ready is True only for ProgrammedReason (the endpoint is live in the
+// provider); every other reason leaves it False.
+func (cr *CRDRegistry) setReady(dnsrecord *apiv1alpha1.DNSRecord, reason, message string) {
 	status := metav1.ConditionFalse
 	if reason == apiv1alpha1.ProgrammedReason {
 		status = metav1.ConditionTrue
 	}
 	meta.SetStatusCondition(&dnsrecord.Status.Conditions, metav1.Condition{
-		Type:    apiv1alpha1.ReadyCondition,
-		Status:  status,
-		Reason:  reason,
-		Message: message,
+		Type:               apiv1alpha1.ReadyCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: dnsrecord.Generation,
 	})
+}
+
+// updateStatus persists the status subresource of the DNSRecord. Status is
+// best-effort observability: a failure to write it is logged but never fails
+// reconciliation, since the DNS record itself is already applied (or its
+// failure already surfaced through the apply error).
+func (cr *CRDRegistry) updateStatus(ctx context.Context, dnsrecord *apiv1alpha1.DNSRecord) {
+	dnsrecord.Status.ObservedGeneration = dnsrecord.Generation
 	if err := cr.crWriter.Status().Update(ctx, dnsrecord); err != nil {
 		log.Warnf("unable to update status of DNSRecord %s in %s: %v", dnsrecord.Name, cr.namespace, err)
 	}
@@ -379,7 +616,8 @@ func (cr *CRDRegistry) setStatus(ctx context.Context, dnsrecord *apiv1alpha1.DNS
 
 // adjustLabelsFromProvider reconciles the labels of the records applied this
 // reconcile with the labels the provider ended up storing (some providers, e.g.
-// coredns, rewrite them). Only the just-applied records are considered: records
+// coredns, rewrite them), and records the state observed in the provider in
+// their status. Only the just-applied records are considered: records
 // untouched this round were not changed by the provider either. It is a no-op
 // when nothing was created or updated, avoiding an extra provider read on
 // delete-only or empty reconciles. It should be called after applyChanges.
@@ -395,10 +633,8 @@ func (cr *CRDRegistry) adjustLabelsFromProvider(ctx context.Context, applied []*
 
 	// Index the provider records by the deterministic DNSRecord object name so
 	// each applied record is matched in memory, without a per-record API read.
-	byName := make(map[string]*endpoint.Endpoint, len(records))
-	for _, record := range records {
-		byName[recordObjectName(record)] = record
-	}
+	byName := recordsByObjectName(records)
+	now := metav1.NewTime(cr.clock())
 
 	for _, dnsrecord := range applied {
 		record, ok := byName[dnsrecord.Name]
@@ -412,6 +648,8 @@ func (cr *CRDRegistry) adjustLabelsFromProvider(ctx context.Context, applied []*
 				return err
 			}
 		}
+		cr.observe(dnsrecord, record, now)
+		cr.updateStatus(ctx, dnsrecord)
 	}
 
 	return nil
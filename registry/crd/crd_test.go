@@ -92,6 +92,15 @@ func inMemoryProviderWithEntries(t *testing.T, ctx context.Context, zone string,
 	return p
 }
 
+func findEndpoint(endpoints []*endpoint.Endpoint, dnsName string) *endpoint.Endpoint {
+	for _, ep := range endpoints {
+		if ep.DNSName == dnsName {
+			return ep
+		}
+	}
+	return nil
+}
+
 func TestCRDRegistryImplementsRegistry(t *testing.T) {
 	require.Implements(t, (*registry.Registry)(nil), new(CRDRegistry))
 }
@@ -581,6 +590,238 @@ func TestCRDApplyChangesSetsProgrammedStatus(t *testing.T) {
 	assert.Equal(t, apiv1alpha1.ProgrammedReason, cond.Reason)
 }
 
+// An adopted record without owner has no DNSRecord yet, so it is created
+// rather than updated.
+func TestCRDApplyChangesAdoptsRecord(t *testing.T) {
+	ctx := t.Context()
+	old := endpoint.NewEndpoint("adopt.mytestdomain.io", "A", "127.0.0.1")
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io", old.DeepCopy())
+
+	adopted := old.DeepCopy().WithLabel(endpoint.OwnerLabelKey, "test").WithLabel(endpoint.AdoptedLabelKey, "true")
+	ep := endpoint.NewEndpoint("adopt.mytestdomain.io", "A", "127.0.0.2").WithLabel(endpoint.OwnerLabelKey, "test")
+
+	reg, c := newTestRegistry(t, prov, "test")
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{adopted},
+		UpdateNew: []*endpoint.Endpoint{ep},
+	}))
+
+	got := &apiv1alpha1.DNSRecord{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(ep)}, got))
+	assert.Equal(t, "test", got.Labels[apiv1alpha1.RecordOwnerLabel])
+	assert.Equal(t, endpoint.NewTargets("127.0.0.2"), got.Spec.Endpoint.Targets)
+	cond := meta.FindStatusCondition(got.Status.Conditions, apiv1alpha1.ReadyCondition)
+	require.NotNil(t, cond, "expected a Ready condition")
+	assert.Equal(t, apiv1alpha1.ProgrammedReason, cond.Reason)
+}
+
+// A successful apply records the state observed in the provider in the status.
+func TestCRDApplyChangesRecordsObservedState(t *testing.T) {
+	ctx := t.Context()
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io")
+	now := time.Date(2026, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	ep := endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 300, "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	reg, c := newTestRegistry(t, prov, "test")
+	reg.now = func() time.Time { return now }
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+
+	got := &apiv1alpha1.DNSRecord{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(ep)}, got))
+	assert.Equal(t, endpoint.NewTargets("127.0.0.1"), got.Status.ObservedTargets)
+	assert.Equal(t, endpoint.TTL(300), got.Status.ObservedTTL)
+	assert.Equal(t, got.Generation, got.Status.ObservedGeneration)
+	require.NotNil(t, got.Status.LastAppliedTime)
+	assert.True(t, now.Equal(got.Status.LastAppliedTime.Time))
+	require.NotNil(t, got.Status.LastVerifiedTime)
+	assert.True(t, now.Equal(got.Status.LastVerifiedTime.Time))
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, apiv1alpha1.InSyncCondition))
+}
+
+// A verification flags the records changed or removed out-of-band, reports the
+// drifted records as observed so that the plan restores them, and deletes the
+// objects of records which are still missing on the next verification. Records
+// only reads: the objects are written by the next Cleanup.
+func TestCRDRegistryVerify(t *testing.T) {
+	ctx := t.Context()
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io")
+	now := time.Date(2026, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	drifted := endpoint.NewEndpoint("drifted.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	removed := endpoint.NewEndpoint("removed.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	kept := endpoint.NewEndpoint("kept.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	reg, c := newTestRegistry(t, prov, "test")
+	reg.now = func() time.Time { return now }
+	reg.verifyInterval = time.Hour
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{drifted, removed, kept}}))
+
+	changed := drifted.DeepCopy()
+	changed.Targets = endpoint.NewTargets("10.0.0.1")
+	require.NoError(t, prov.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{drifted.DeepCopy()},
+		UpdateNew: []*endpoint.Endpoint{changed},
+		Delete:    []*endpoint.Endpoint{removed.DeepCopy()},
+	}))
+
+	getStatus := func(ep *endpoint.Endpoint) *apiv1alpha1.DNSRecordStatus {
+		t.Helper()
+		got := &apiv1alpha1.DNSRecord{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(ep)}, got))
+		return &got.Status
+	}
+	assertCurrent := func() {
+		t.Helper()
+		endpoints, err := reg.Records(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, endpoint.NewTargets("10.0.0.1"), findEndpoint(endpoints, "drifted.mytestdomain.io").Targets)
+		assert.Equal(t, endpoint.NewTargets("127.0.0.1"), findEndpoint(endpoints, "kept.mytestdomain.io").Targets)
+		assert.Nil(t, findEndpoint(endpoints, "removed.mytestdomain.io"), "the missing record is not current state")
+	}
+
+	assertCurrent()
+	// the observed state is not written by Records
+	assert.Equal(t, apiv1alpha1.InSyncReason, meta.FindStatusCondition(getStatus(drifted).Conditions, apiv1alpha1.InSyncCondition).Reason)
+	assert.Equal(t, apiv1alpha1.ProgrammedReason, meta.FindStatusCondition(getStatus(removed).Conditions, apiv1alpha1.ReadyCondition).Reason)
+
+	// the objects are not verified again before the interval elapses
+	assertCurrent()
+
+	require.NoError(t, reg.Cleanup(ctx))
+
+	status := getStatus(drifted)
+	cond := meta.FindStatusCondition(status.Conditions, apiv1alpha1.InSyncCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, apiv1alpha1.DriftedReason, cond.Reason)
+	assert.Contains(t, cond.Message, "targets 10.0.0.1 instead of 127.0.0.1")
+	assert.Equal(t, endpoint.NewTargets("10.0.0.1"), status.ObservedTargets)
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, apiv1alpha1.ReadyCondition))
+
+	status = getStatus(removed)
+	assert.Equal(t, apiv1alpha1.MissingReason, meta.FindStatusCondition(status.Conditions, apiv1alpha1.ReadyCondition).Reason)
+	assert.Equal(t, apiv1alpha1.MissingReason, meta.FindStatusCondition(status.Conditions, apiv1alpha1.InSyncCondition).Reason)
+
+	// the object of the record still missing is deleted by the next cleanup
+	now = now.Add(time.Hour)
+	assertCurrent()
+	getStatus(removed)
+	require.NoError(t, reg.Cleanup(ctx))
+	err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(removed)}, &apiv1alpha1.DNSRecord{})
+	assert.True(t, k8sErrors.IsNotFound(err), "expected the DNSRecord to be deleted, got %v", err)
+	assert.Equal(t, apiv1alpha1.DriftedReason, meta.FindStatusCondition(getStatus(drifted).Conditions, apiv1alpha1.InSyncCondition).Reason)
+}
+
+// The verifications never write the objects when the registry is not cleaned
+// up, as with --audit, --plan-output or --dry-run.
+func TestCRDRegistryVerifyRecordsReadOnly(t *testing.T) {
+	ctx := t.Context()
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io")
+	now := time.Date(2026, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	removed := endpoint.NewEndpoint("removed.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	reg, c := newTestRegistry(t, prov, "test")
+	reg.now = func() time.Time { return now }
+	reg.verifyInterval = time.Hour
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{removed}}))
+	require.NoError(t, prov.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{removed.DeepCopy()}}))
+
+	before := &apiv1alpha1.DNSRecord{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(removed)}, before))
+	for range 3 {
+		endpoints, err := reg.Records(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, endpoints)
+		now = now.Add(time.Hour)
+	}
+
+	after := &apiv1alpha1.DNSRecord{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(removed)}, after))
+	assert.Equal(t, before.ResourceVersion, after.ResourceVersion)
+}
+
+// A missing record created again by the changes keeps its object, which is
+// Programmed again.
+func TestCRDRegistryVerifyMissingRecordCreatedAgain(t *testing.T) {
+	ctx := t.Context()
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io")
+	now := time.Date(2026, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	removed := endpoint.NewEndpoint("removed.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	reg, c := newTestRegistry(t, prov, "test")
+	reg.now = func() time.Time { return now }
+	reg.verifyInterval = time.Hour
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{removed}}))
+	require.NoError(t, prov.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{removed.DeepCopy()}}))
+
+	for range 2 {
+		endpoints, err := reg.Records(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, endpoints)
+		now = now.Add(time.Hour)
+	}
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{removed.DeepCopy()}}))
+	require.NoError(t, reg.Cleanup(ctx))
+
+	got := &apiv1alpha1.DNSRecord{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: recordObjectName(removed)}, got))
+	assert.Equal(t, apiv1alpha1.ProgrammedReason, meta.FindStatusCondition(got.Status.Conditions, apiv1alpha1.ReadyCondition).Reason)
+	endpoints, err := reg.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+}
+
+// A record found again after being marked Missing, for instance recreated by
+// the plan, is Programmed and in sync.
+func TestCRDRegistryVerifyRecordFoundAgain(t *testing.T) {
+	ctx := t.Context()
+	ep := endpoint.NewEndpoint("sub.mytestdomain.io", "A", "127.0.0.1").WithLabel(endpoint.OwnerLabelKey, "test")
+	prov := inMemoryProviderWithEntries(t, ctx, "mytestdomain.io", ep.DeepCopy())
+	seeded := &apiv1alpha1.DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      recordObjectName(ep),
+			Namespace: "default",
+			Labels:    map[string]string{apiv1alpha1.RecordOwnerLabel: "test"},
+		},
+		Spec: apiv1alpha1.DNSRecordSpec{Endpoint: *ep},
+		Status: apiv1alpha1.DNSRecordStatus{
+			Conditions: []metav1.Condition{{
+				Type:   apiv1alpha1.ReadyCondition,
+				Status: metav1.ConditionFalse,
+				Reason: apiv1alpha1.MissingReason,
+			}},
+		},
+	}
+
+	reg, _ := newTestRegistry(t, prov, "test", seeded)
+	reg.verifyInterval = time.Hour
+	endpoints, err := reg.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, endpoint.NewTargets("127.0.0.1"), endpoints[0].Targets)
+}
+
+func TestCRDRegistryVerifyProviderError(t *testing.T) {
+	reg, _ := newTestRegistry(t, &mockProvider{recordsErr: assert.AnError}, "test")
+	reg.verifyInterval = time.Hour
+
+	endpoints, err := reg.Records(t.Context())
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []*endpoint.Endpoint{}, endpoints)
+}
+
+func TestRecordDrift(t *testing.T) {
+	spec := endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 300, "127.0.0.1", "127.0.0.2")
+
+	assert.Empty(t, recordDrift(spec, endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 300, "127.0.0.2", "127.0.0.1")))
+	assert.Equal(t, "TTL 60 instead of 300",
+		recordDrift(spec, endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 60, "127.0.0.1", "127.0.0.2")))
+	assert.Equal(t, "targets 127.0.0.1 instead of 127.0.0.1;127.0.0.2, TTL 60 instead of 300",
+		recordDrift(spec, endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 60, "127.0.0.1")))
+
+	// the TTL applied by the provider is not a drift when the spec does not set it
+	assert.Empty(t, recordDrift(endpoint.NewEndpoint("sub.mytestdomain.io", "A", "127.0.0.1"),
+		endpoint.NewEndpointWithTTL("sub.mytestdomain.io", "A", 300, "127.0.0.1")))
+}
+
 // When the provider rejects the changes, the DNSRecord is persisted with a
 // Ready=False/Failed condition, so the failure is visible on the object while
 // Records() still excludes it from current state (it is not Ready) and the plan
@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamodb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var conflictsTotal = metrics.NewCounterWithOpts(
+	prometheus.CounterOpts{
+		Subsystem: "registry",
+		Name:      "dynamodb_conflicts_total",
+		Help:      "Number of DynamoDB registry changes skipped because the item was changed by another owner or instance.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(conflictsTotal)
+}
@@ -18,11 +18,13 @@ package dynamodb
 
 import (
 	"context"
-	b64 "encoding/base64"
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -61,16 +63,24 @@ type DynamoDBRegistry struct {
 	wildcardReplacement string
 	managedRecordTypes  []string
 	excludeRecordTypes  []string
-	txtEncryptAESKey    []byte
+	// txtEncryptAESKeys holds the keys TXT records migrated from the TXT registry are decrypted with
+	txtEncryptAESKeys [][]byte
 
 	// cache the dynamodb records owned by us.
 	labels         map[endpoint.EndpointKey]endpoint.Labels
 	orphanedLabels sets.Set[endpoint.EndpointKey]
+	// versions holds the version of the dynamodb records owned by us, the records written
+	// before versioning have none. Updates only apply when the version is unchanged.
+	versions map[endpoint.EndpointKey]int64
 
 	// cache the records in memory and update on an interval instead.
 	recordsCache            []*endpoint.Endpoint
 	recordsCacheRefreshTime time.Time
 	cacheInterval           time.Duration
+
+	// applyMutex serializes ApplyChanges, which updates the caches above,
+	// when changes are applied concurrently per zone.
+	applyMutex sync.Mutex
 }
 
 const dynamodbAttributeMigrate = "dynamodb/needs-migration"
@@ -81,9 +91,19 @@ var dynamodbMaxBatchSize uint8 = 25
 // New creates a DynamoDBRegistry from the given configuration.
 func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
 	client := awsdynamodb.NewFromConfig(provideraws.CreateDefaultV2Config(cfg), WithRegion(cfg.AWSDynamoDBRegion))
-	return newRegistry(p, cfg.TXTOwnerID, client,
+	r, err := newRegistry(p, cfg.TXTOwnerID, client,
 		cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement,
 		cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.withPreviousAESKeys(cfg.TXTEncryptPreviousAESKeys); err != nil {
+		return nil, err
+	}
+	if err := r.withNameTemplate(cfg.TXTNameTemplate); err != nil {
+		return nil, err
+	}
+	return r, nil
 }
 
 // newRegistry returns a new DynamoDBRegistry object.
@@ -100,14 +120,13 @@ func newRegistry(
 		return nil, errors.New("table cannot be empty")
 	}
 
-	// TODO: encryption logic duplicated in TXT registry; refactor into common utility function.
-	if len(txtEncryptAESKey) == 0 {
-		txtEncryptAESKey = nil
-	} else if len(txtEncryptAESKey) != 32 {
-		var err error
-		if txtEncryptAESKey, err = b64.StdEncoding.DecodeString(string(txtEncryptAESKey)); err != nil || len(txtEncryptAESKey) != 32 {
-			return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
-		}
+	txtEncryptAESKey, err := endpoint.ParseAESKey(string(txtEncryptAESKey))
+	if err != nil {
+		return nil, err
+	}
+	var txtEncryptAESKeys [][]byte
+	if txtEncryptAESKey != nil {
+		txtEncryptAESKeys = [][]byte{txtEncryptAESKey}
 	}
 	if len(txtPrefix) > 0 && len(txtSuffix) > 0 {
 		return nil, errors.New("txt-prefix and txt-suffix are mutually exclusive")
@@ -122,11 +141,39 @@ func newRegistry(
 		wildcardReplacement: txtWildcardReplacement,
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
-		txtEncryptAESKey:    txtEncryptAESKey,
+		txtEncryptAESKeys:   txtEncryptAESKeys,
 		cacheInterval:       cacheInterval,
 	}, nil
 }
 
+// withNameTemplate sets the template of the names of the TXT records which are migrated, if any.
+func (im *DynamoDBRegistry) withNameTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	m, err := mapper.NewTemplateNameMapper(template, im.wildcardReplacement)
+	if err != nil {
+		return err
+	}
+	im.mapper = m
+	return nil
+}
+
+// withPreviousAESKeys adds the keys TXT records encrypted before a key rotation are decrypted with
+// when they are migrated.
+func (im *DynamoDBRegistry) withPreviousAESKeys(keys []string) error {
+	for _, key := range keys {
+		aesKey, err := endpoint.ParseAESKey(key)
+		if err != nil {
+			return fmt.Errorf("previous key: %w", err)
+		}
+		if aesKey != nil {
+			im.txtEncryptAESKeys = append(im.txtEncryptAESKeys, aesKey)
+		}
+	}
+	return nil
+}
+
 func (im *DynamoDBRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
@@ -159,6 +206,7 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 	endpoints := make([]*endpoint.Endpoint, 0, len(records))
 	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
 	txtRecordsMap := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	mapper.IndexEndpoints(im.mapper, records)
 	for _, record := range records {
 		key := record.Key()
 		if labels := im.labels[key]; labels != nil {
@@ -169,7 +217,7 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 			if record.RecordType == endpoint.RecordTypeTXT {
 				// We simply assume that TXT records for the TXT registry will always have only one target.
-				if labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey); err == nil {
+				if labels, err := endpoint.NewLabelsFromStringWithKeys(record.Targets[0], im.txtEncryptAESKeys); err == nil {
 					endpointName, recordType := im.mapper.ToEndpointName(record.DNSName)
 					key := endpoint.EndpointKey{
 						DNSName:       endpointName,
@@ -238,6 +286,9 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 // ApplyChanges updates the DNS provider and DynamoDB table with the changes.
 func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	im.applyMutex.Lock()
+	defer im.applyMutex.Unlock()
+
 	filteredChanges := &plan.Changes{
 		Create:    changes.Create,
 		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
@@ -269,6 +320,7 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 
 	for _, r := range filteredChanges.Delete {
 		delete(im.labels, r.Key())
+		delete(im.versions, r.Key())
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
 		}
@@ -276,12 +328,17 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 
 	oldLabels := make(map[endpoint.EndpointKey]endpoint.Labels, len(filteredChanges.UpdateOld))
 	needMigration := sets.New[endpoint.EndpointKey]()
+	adopted := sets.New[endpoint.EndpointKey]()
 	for _, r := range filteredChanges.UpdateOld {
 		oldLabels[r.Key()] = r.Labels
 
 		if _, ok := r.GetProviderSpecificProperty(dynamodbAttributeMigrate); ok {
 			needMigration.Insert(r.Key())
 		}
+		// records without owner adopted by a resource have no item to update yet
+		if r.IsAdopted() {
+			adopted.Insert(r.Key())
+		}
 
 		// remove old version of record from cache
 		if im.cacheInterval > 0 {
@@ -295,6 +352,8 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 			statements = im.appendInsert(statements, key, r.Labels)
 			// Invalidate the records cache so the next sync deletes the TXT ownership record
 			im.recordsCache = nil
+		} else if adopted.Has(key) {
+			statements = im.appendInsert(statements, key, r.Labels)
 		} else {
 			statements = im.appendUpdate(statements, key, oldLabels[key], r.Labels)
 		}
@@ -306,45 +365,45 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 		}
 	}
 
+	duplicates := sets.New[endpoint.EndpointKey]()
+	stale := sets.New[endpoint.EndpointKey]()
 	err := im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
-		var context string
-		if strings.HasPrefix(*request.Statement, "INSERT") {
-			if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem {
-				// We lost a race with a different owner or another owner has an orphaned ownership record.
-				key, err := fromDynamoKey(request.Parameters[0])
-				if err != nil {
-					return err
-				}
-				for i, ep := range filteredChanges.Create {
-					if ep.Key() == key {
-						log.Infof("Skipping endpoint %v because owner does not match", ep)
-						filteredChanges.Create = append(filteredChanges.Create[:i], filteredChanges.Create[i+1:]...)
-						// The dynamodb insertion failed; remove from our cache.
-						im.removeFromCache(ep)
-						delete(im.labels, key)
-						return nil
-					}
-				}
-			}
-			var record string
-			if err := attributevalue.Unmarshal(request.Parameters[0], &record); err != nil {
-				return fmt.Errorf("inserting dynamodb record: %w", err)
+		insert := strings.HasPrefix(*request.Statement, "INSERT")
+		switch {
+		case insert && response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem:
+			// We lost a race with a different owner or another owner has an orphaned ownership record.
+			key, err := fromDynamoKey(statementKey(request))
+			if err != nil {
+				return err
 			}
-			context = fmt.Sprintf("inserting dynamodb record %q", record)
-		} else {
-			var record string
-			if err := attributevalue.Unmarshal(request.Parameters[1], &record); err != nil {
-				return fmt.Errorf("inserting dynamodb record: %w", err)
+			duplicates.Insert(key)
+			return nil
+		case !insert && response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed:
+			// The record was changed or taken over by another owner or instance since we read it.
+			key, err := fromDynamoKey(statementKey(request))
+			if err != nil {
+				return err
 			}
-			context = fmt.Sprintf("updating dynamodb record %q", record)
+			stale.Insert(key)
+			return nil
 		}
-		return fmt.Errorf("%s: %s: %s", context, response.Error.Code, *response.Error.Message)
+
+		operation := "updating"
+		if insert {
+			operation = "inserting"
+		}
+		var record string
+		if err := attributevalue.Unmarshal(statementKey(request), &record); err != nil {
+			return fmt.Errorf("%s dynamodb record: %w", operation, err)
+		}
+		return fmt.Errorf("%s dynamodb record %q: %s: %s", operation, record, response.Error.Code, *response.Error.Message)
 	})
 	if err != nil {
 		im.recordsCache = nil
 		im.labels = nil
 		return err
 	}
+	im.skipConflicts(filteredChanges, duplicates, stale)
 
 	// When caching is enabled, disable the provider from using the cache.
 	if im.cacheInterval > 0 {
@@ -364,14 +423,22 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 	for r := range im.orphanedLabels {
 		statements = im.appendDelete(statements, r)
 		delete(im.labels, r)
+		delete(im.versions, r)
 	}
 	im.orphanedLabels = nil
 	return im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
-		im.labels = nil
 		record, err := fromDynamoKey(request.Parameters[0])
 		if err != nil {
+			im.labels = nil
 			return fmt.Errorf("deleting dynamodb record: %w", err)
 		}
+		if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed {
+			// The record was taken over by another owner, which now owns it.
+			log.Warnf("Skipping deletion of dynamodb record %v because owner does not match", record)
+			conflictsTotal.Counter.Inc()
+			return nil
+		}
+		im.labels = nil
 		return fmt.Errorf("deleting dynamodb record %v: %s: %s", record, response.Error.Code, *response.Error.Message)
 	})
 }
@@ -410,13 +477,14 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 	}
 
 	labels := map[endpoint.EndpointKey]endpoint.Labels{}
+	versions := map[endpoint.EndpointKey]int64{}
 	scanPaginator := awsdynamodb.NewScanPaginator(im.dynamodbAPI, &awsdynamodb.ScanInput{
 		TableName:        aws.String(im.table),
 		FilterExpression: aws.String("o = :ownerval"),
 		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
 			":ownerval": &dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
 		},
-		ProjectionExpression: aws.String("k,l"),
+		ProjectionExpression: aws.String("k,l,v"),
 		ConsistentRead:       aws.Bool(true),
 	})
 	for scanPaginator.HasMorePages() {
@@ -435,10 +503,18 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 			}
 
 			labels[k] = l
+			if v, ok := item["v"]; ok {
+				var version int64
+				if err := attributevalue.Unmarshal(v, &version); err != nil {
+					return fmt.Errorf("querying dynamodb for version: %w", err)
+				}
+				versions[k] = version
+			}
 		}
 	}
 
 	im.labels = labels
+	im.versions = versions
 	return nil
 }
 
@@ -481,9 +557,23 @@ func toDynamoLabels(labels endpoint.Labels) dynamodbtypes.AttributeValue {
 	return &dynamodbtypes.AttributeValueMemberM{Value: labelMap}
 }
 
+func toDynamoVersion(version int64) dynamodbtypes.AttributeValue {
+	return &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)}
+}
+
+// statementKey returns the parameter holding the key of the record of the statement.
+func statementKey(request dynamodbtypes.BatchStatementRequest) dynamodbtypes.AttributeValue {
+	if strings.HasPrefix(*request.Statement, "UPDATE") {
+		return request.Parameters[2]
+	}
+	return request.Parameters[0]
+}
+
+// appendInsert appends the insertion of a record, which fails when the record already exists.
 func (im *DynamoDBRegistry) appendInsert(statements []dynamodbtypes.BatchStatementRequest, key endpoint.EndpointKey, newL endpoint.Labels) []dynamodbtypes.BatchStatementRequest {
+	im.setVersion(key, 1)
 	return append(statements, dynamodbtypes.BatchStatementRequest{
-		Statement:      aws.String(fmt.Sprintf("INSERT INTO %q VALUE {'k':?, 'o':?, 'l':?}", im.table)),
+		Statement:      aws.String(fmt.Sprintf("INSERT INTO %q VALUE {'k':?, 'o':?, 'l':?, 'v':?}", im.table)),
 		ConsistentRead: aws.Bool(true),
 		Parameters: []dynamodbtypes.AttributeValue{
 			toDynamoKey(key),
@@ -491,6 +581,7 @@ func (im *DynamoDBRegistry) appendInsert(statements []dynamodbtypes.BatchStateme
 				Value: im.ownerID,
 			},
 			toDynamoLabels(newL),
+			toDynamoVersion(1),
 		},
 	})
 }
@@ -509,13 +600,32 @@ func (im *DynamoDBRegistry) appendUpdate(statements []dynamodbtypes.BatchStateme
 		}
 	}
 
-	return append(statements, dynamodbtypes.BatchStatementRequest{
-		Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? WHERE \"k\"=?", im.table)),
+	// The update only applies when the record is still ours and has not been changed since we read it.
+	version := im.versions[key]
+	request := dynamodbtypes.BatchStatementRequest{
+		Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? SET \"v\"=? WHERE \"k\"=? AND \"o\"=? AND \"v\"=?", im.table)),
 		Parameters: []dynamodbtypes.AttributeValue{
 			toDynamoLabels(newE),
+			toDynamoVersion(version + 1),
 			toDynamoKey(key),
+			&dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
+			toDynamoVersion(version),
 		},
-	})
+	}
+	if version == 0 {
+		// records written before versioning have no version yet
+		request.Statement = aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? SET \"v\"=? WHERE \"k\"=? AND \"o\"=? AND \"v\" IS MISSING", im.table))
+		request.Parameters = request.Parameters[:4]
+	}
+	im.setVersion(key, version+1)
+	return append(statements, request)
+}
+
+func (im *DynamoDBRegistry) setVersion(key endpoint.EndpointKey, version int64) {
+	if im.versions == nil {
+		im.versions = map[endpoint.EndpointKey]int64{}
+	}
+	im.versions[key] = version
 }
 
 func (im *DynamoDBRegistry) appendDelete(statements []dynamodbtypes.BatchStatementRequest, key endpoint.EndpointKey) []dynamodbtypes.BatchStatementRequest {
@@ -551,14 +661,8 @@ func (im *DynamoDBRegistry) executeStatements(ctx context.Context, statements []
 			if response.Error == nil {
 				op, _, _ := strings.Cut(*request.Statement, " ")
 				var key string
-				if op == "UPDATE" {
-					if err := attributevalue.Unmarshal(request.Parameters[1], &key); err != nil {
-						return err
-					}
-				} else {
-					if err := attributevalue.Unmarshal(request.Parameters[0], &key); err != nil {
-						return err
-					}
+				if err := attributevalue.Unmarshal(statementKey(request), &key); err != nil {
+					return err
 				}
 				log.Infof("%s dynamodb record %q", op, key)
 			} else {
@@ -571,6 +675,46 @@ func (im *DynamoDBRegistry) executeStatements(ctx context.Context, statements []
 	return nil
 }
 
+// skipConflicts removes the changes of the records whose insertion or update failed because another owner
+// or instance wrote them first, so that the provider is left untouched. The records with a duplicate insertion
+// belong to another owner, while the stale ones are read again with the whole table on the next synchronization.
+func (im *DynamoDBRegistry) skipConflicts(changes *plan.Changes, duplicates, stale sets.Set[endpoint.EndpointKey]) {
+	if len(duplicates) == 0 && len(stale) == 0 {
+		return
+	}
+	skip := func(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+		kept := make([]*endpoint.Endpoint, 0, len(endpoints))
+		for _, ep := range endpoints {
+			if !duplicates.Has(ep.Key()) && !stale.Has(ep.Key()) {
+				kept = append(kept, ep)
+			}
+		}
+		return kept
+	}
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew) {
+		key := ep.Key()
+		if duplicates.Has(key) {
+			log.Infof("Skipping endpoint %v because owner does not match", ep)
+			delete(im.labels, key)
+			delete(im.versions, key)
+		} else if stale.Has(key) {
+			log.Warnf("Skipping endpoint %v because its dynamodb record was changed by another owner or instance", ep)
+		} else {
+			continue
+		}
+		conflictsTotal.Counter.Inc()
+	}
+	changes.Create = skip(changes.Create)
+	changes.UpdateOld = skip(changes.UpdateOld)
+	changes.UpdateNew = skip(changes.UpdateNew)
+
+	// The cached records hold the skipped changes.
+	im.recordsCache = nil
+	if len(stale) > 0 {
+		im.labels = nil
+	}
+}
+
 func (im *DynamoDBRegistry) addToCache(ep *endpoint.Endpoint) {
 	if im.recordsCache != nil {
 		im.recordsCache = append(im.recordsCache, ep)
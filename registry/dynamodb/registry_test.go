@@ -106,11 +106,22 @@ func TestDynamoDBRegistryNew_EncryptionConfig(t *testing.T) {
 			require.Error(t, err)
 		} else {
 			require.NoError(t, err)
-			assert.Equal(t, test.aesKeySanitized, actual.txtEncryptAESKey)
+			assert.Equal(t, [][]byte{test.aesKeySanitized}, actual.txtEncryptAESKeys)
 		}
 	}
 }
 
+func TestDynamoDBRegistryWithPreviousAESKeys(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, nil)
+
+	r, err := newRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte("01234567890123456789012345678901"), time.Hour)
+	require.NoError(t, err)
+	require.ErrorContains(t, r.withPreviousAESKeys([]string{"too-short"}), "previous key: ")
+
+	require.NoError(t, r.withPreviousAESKeys([]string{"passphrasewhichneedstobe32bytes!"}))
+	assert.Equal(t, [][]byte{[]byte("01234567890123456789012345678901"), []byte("passphrasewhichneedstobe32bytes!")}, r.txtEncryptAESKeys)
+}
+
 func TestDynamoDBRegistryRecordsBadTable(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -946,6 +957,79 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update adopt",
+			changes: plan.Changes{
+				UpdateOld: []*endpoint.Endpoint{
+					{
+						DNSName:    "foo.test-zone.example.org",
+						Targets:    endpoint.Targets{"foo.loadbalancer.com"},
+						RecordType: endpoint.RecordTypeCNAME,
+						Labels: map[string]string{
+							endpoint.OwnerLabelKey:   "test-owner",
+							endpoint.AdoptedLabelKey: "true",
+						},
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{
+					{
+						DNSName:    "foo.test-zone.example.org",
+						Targets:    endpoint.Targets{"new.loadbalancer.com"},
+						RecordType: endpoint.RecordTypeCNAME,
+						Labels: map[string]string{
+							endpoint.OwnerLabelKey:    "test-owner",
+							endpoint.ResourceLabelKey: "ingress/default/foo-ingress",
+						},
+					},
+				},
+			},
+			stubConfig: DynamoDBStubConfig{
+				ExpectDelete: sets.New("quux.test-zone.example.org#A#set-2"),
+				ExpectInsert: map[string]map[string]string{
+					"foo.test-zone.example.org#CNAME#": {endpoint.ResourceLabelKey: "ingress/default/foo-ingress"},
+				},
+			},
+			expectedRecords: []*endpoint.Endpoint{
+				{
+					DNSName:    "foo.test-zone.example.org",
+					Targets:    endpoint.Targets{"new.loadbalancer.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/foo-ingress",
+					},
+				},
+				{
+					DNSName:    "bar.test-zone.example.org",
+					Targets:    endpoint.Targets{"my-domain.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/my-ingress",
+					},
+				},
+				{
+					DNSName:       "baz.test-zone.example.org",
+					Targets:       endpoint.Targets{"1.1.1.1"},
+					RecordType:    endpoint.RecordTypeA,
+					SetIdentifier: "set-1",
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/my-ingress",
+					},
+				},
+				{
+					DNSName:       "baz.test-zone.example.org",
+					Targets:       endpoint.Targets{"2.2.2.2"},
+					RecordType:    endpoint.RecordTypeA,
+					SetIdentifier: "set-2",
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/other-ingress",
+					},
+				},
+			},
+		},
 		{
 			name: "update error",
 			changes: plan.Changes{
@@ -1192,7 +1276,7 @@ func (r *DynamoDBStub) Scan(ctx context.Context, input *dynamodb.ScanInput, _ ..
 	var owner string
 	assert.NoError(r.t, attributevalue.Unmarshal(input.ExpressionAttributeValues[":ownerval"], &owner))
 	assert.Equal(r.t, "test-owner", owner)
-	assert.Equal(r.t, "k,l", *input.ProjectionExpression)
+	assert.Equal(r.t, "k,l,v", *input.ProjectionExpression)
 	assert.True(r.t, *input.ConsistentRead)
 	return &dynamodb.ScanOutput{
 		Items: []map[string]dynamodbtypes.AttributeValue{
@@ -1248,7 +1332,7 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
-		case "INSERT INTO \"test-table\" VALUE {'k':?, 'o':?, 'l':?}":
+		case "INSERT INTO \"test-table\" VALUE {'k':?, 'o':?, 'l':?, 'v':?}":
 			assert.False(r.t, r.changesApplied, "unexpected insert after provider changes")
 
 			var key string
@@ -1287,13 +1371,17 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 				r.t.Errorf("insert for key %q did not get expected label %q", key, label)
 			}
 
+			var version int64
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[3], &version))
+			assert.Equal(r.t, int64(1), version, "insert for key %q version", key)
+
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
-		case "UPDATE \"test-table\" SET \"l\"=? WHERE \"k\"=?":
+		case "UPDATE \"test-table\" SET \"l\"=? SET \"v\"=? WHERE \"k\"=? AND \"o\"=? AND \"v\" IS MISSING":
 			assert.False(r.t, r.changesApplied, "unexpected update after provider changes")
 
 			var key string
-			assert.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[1], &key))
+			assert.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[2], &key))
 			if code, exists := r.stubConfig.ExpectUpdateError[key]; exists {
 				delete(r.stubConfig.ExpectInsertError, key)
 				responses = append(responses, dynamodbtypes.BatchStatementResponse{
@@ -1323,6 +1411,13 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 				r.t.Errorf("update for key %q did not get expected label %q", key, label)
 			}
 
+			var version int64
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[1], &version))
+			assert.Equal(r.t, int64(1), version, "update for key %q version", key)
+			var testOwner string
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[3], &testOwner))
+			assert.Equal(r.t, "test-owner", testOwner)
+
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
 		default:
@@ -1379,3 +1474,283 @@ func TestWithRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestDynamoDBRegistryCreateConflict(t *testing.T) {
+	ctx := t.Context()
+	table, p := newMemTable(t)
+	east := newMemTableRegistry(t, table, p, "east")
+	west := newMemTableRegistry(t, table, p, "west")
+	for _, r := range []*DynamoDBRegistry{east, west} {
+		_, err := r.Records(ctx)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, east.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+	}))
+	// the other owner lost the race, its record is skipped
+	require.NoError(t, west.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "2.2.2.2")},
+	}))
+
+	assert.Equal(t, "east", table.items["api.test-zone.example.org#A#"].owner)
+	records, err := west.Records(ctx)
+	require.NoError(t, err)
+	record := findRecord(records, "api.test-zone.example.org")
+	require.NotNil(t, record)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, record.Targets)
+	assert.Empty(t, record.Labels[endpoint.OwnerLabelKey])
+}
+
+func TestDynamoDBRegistryUpdateConflict(t *testing.T) {
+	ctx := t.Context()
+	table, p := newMemTable(t)
+	// two instances with the same owner
+	first := newMemTableRegistry(t, table, p, "test-owner")
+	second := newMemTableRegistry(t, table, p, "test-owner")
+	_, err := first.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, first.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/api")},
+	}))
+
+	firstChanges := updateRecord(t, first, "api.test-zone.example.org", "2.2.2.2", "service/default/first")
+	secondChanges := updateRecord(t, second, "api.test-zone.example.org", "3.3.3.3", "service/default/second")
+	require.NoError(t, first.ApplyChanges(ctx, firstChanges))
+	assert.Equal(t, int64(2), *table.items["api.test-zone.example.org#A#"].version)
+
+	// the second instance read the record before it was changed, its update is skipped
+	require.NoError(t, second.ApplyChanges(ctx, secondChanges))
+	item := table.items["api.test-zone.example.org#A#"]
+	assert.Equal(t, int64(2), *item.version)
+	assert.Equal(t, "service/default/first", item.labels[endpoint.ResourceLabelKey])
+	assert.Nil(t, second.labels, "the table is read again on the next synchronization")
+
+	// the next synchronization applies the update
+	require.NoError(t, second.ApplyChanges(ctx, updateRecord(t, second, "api.test-zone.example.org", "3.3.3.3", "service/default/second")))
+	item = table.items["api.test-zone.example.org#A#"]
+	assert.Equal(t, int64(3), *item.version)
+	assert.Equal(t, "service/default/second", item.labels[endpoint.ResourceLabelKey])
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"3.3.3.3"}, findRecord(records, "api.test-zone.example.org").Targets)
+}
+
+func TestDynamoDBRegistryUpdateTakenOver(t *testing.T) {
+	ctx := t.Context()
+	table, p := newMemTable(t)
+	r := newMemTableRegistry(t, table, p, "test-owner")
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+	}))
+
+	changes := updateRecord(t, r, "api.test-zone.example.org", "2.2.2.2", "service/default/api")
+	table.items["api.test-zone.example.org#A#"].owner = "other-owner"
+	require.NoError(t, r.ApplyChanges(ctx, changes))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, findRecord(records, "api.test-zone.example.org").Targets)
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, findRecord(records, "api.test-zone.example.org").Labels[endpoint.OwnerLabelKey])
+}
+
+func TestDynamoDBRegistryDeleteTakenOver(t *testing.T) {
+	ctx := t.Context()
+	table, p := newMemTable(t)
+	r := newMemTableRegistry(t, table, p, "test-owner")
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	table.items["api.test-zone.example.org#A#"].owner = "other-owner"
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{findRecord(records, "api.test-zone.example.org")},
+	}))
+	assert.Contains(t, table.items, "api.test-zone.example.org#A#", "the record of the other owner is kept")
+}
+
+func TestDynamoDBRegistryUpdateUnversioned(t *testing.T) {
+	ctx := t.Context()
+	table, p := newMemTable(t)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.test-zone.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+	}))
+	// a record written before versioning
+	table.items["api.test-zone.example.org#A#"] = &memTableItem{owner: "test-owner", labels: map[string]string{}}
+	r := newMemTableRegistry(t, table, p, "test-owner")
+
+	require.NoError(t, r.ApplyChanges(ctx, updateRecord(t, r, "api.test-zone.example.org", "2.2.2.2", "service/default/api")))
+	assert.Equal(t, int64(1), *table.items["api.test-zone.example.org#A#"].version)
+
+	require.NoError(t, r.ApplyChanges(ctx, updateRecord(t, r, "api.test-zone.example.org", "3.3.3.3", "service/default/other")))
+	item := table.items["api.test-zone.example.org#A#"]
+	assert.Equal(t, int64(2), *item.version)
+	assert.Equal(t, "service/default/other", item.labels[endpoint.ResourceLabelKey])
+}
+
+func newMemTableRegistry(t *testing.T, table *memTable, p provider.Provider, ownerID string) *DynamoDBRegistry {
+	t.Helper()
+	r, err := newRegistry(p, ownerID, table, "test-table", "", "", "", []string{endpoint.RecordTypeA}, []string{}, nil, 0)
+	require.NoError(t, err)
+	return r
+}
+
+// updateRecord returns the changes updating the targets and the resource of a record owned by the registry.
+func updateRecord(t *testing.T, r *DynamoDBRegistry, dnsName, target, resource string) *plan.Changes {
+	t.Helper()
+	records, err := r.Records(t.Context())
+	require.NoError(t, err)
+	old := findRecord(records, dnsName)
+	require.NotNil(t, old)
+	updated := old.DeepCopy()
+	updated.Targets = endpoint.Targets{target}
+	updated.Labels[endpoint.ResourceLabelKey] = resource
+	return &plan.Changes{UpdateOld: []*endpoint.Endpoint{old}, UpdateNew: []*endpoint.Endpoint{updated}}
+}
+
+func findRecord(records []*endpoint.Endpoint, dnsName string) *endpoint.Endpoint {
+	for _, record := range records {
+		if record.DNSName == dnsName {
+			return record
+		}
+	}
+	return nil
+}
+
+type memTableItem struct {
+	owner  string
+	labels map[string]string
+	// version is nil for the items written before versioning
+	version *int64
+}
+
+// memTable is an in-memory implementation of DynamoDBAPI, evaluating the statements of the registry
+// along with their conditions like DynamoDB does.
+type memTable struct {
+	t     *testing.T
+	items map[string]*memTableItem
+}
+
+func newMemTable(t *testing.T) (*memTable, provider.Provider) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	return &memTable{t: t, items: map[string]*memTableItem{}}, p
+}
+
+func (m *memTable) DescribeTable(_ context.Context, _ *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return &dynamodb.DescribeTableOutput{
+		Table: &dynamodbtypes.TableDescription{
+			AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
+				{AttributeName: aws.String("k"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+			},
+			KeySchema: []dynamodbtypes.KeySchemaElement{
+				{AttributeName: aws.String("k"), KeyType: dynamodbtypes.KeyTypeHash},
+			},
+		},
+	}, nil
+}
+
+func (m *memTable) Scan(_ context.Context, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	var owner string
+	require.NoError(m.t, attributevalue.Unmarshal(input.ExpressionAttributeValues[":ownerval"], &owner))
+	output := &dynamodb.ScanOutput{}
+	for key, item := range m.items {
+		if item.owner != owner {
+			continue
+		}
+		labels, err := attributevalue.Marshal(item.labels)
+		require.NoError(m.t, err)
+		attributes := map[string]dynamodbtypes.AttributeValue{
+			"k": &dynamodbtypes.AttributeValueMemberS{Value: key},
+			"l": labels,
+		}
+		if item.version != nil {
+			attributes["v"] = toDynamoVersion(*item.version)
+		}
+		output.Items = append(output.Items, attributes)
+	}
+	return output, nil
+}
+
+func (m *memTable) BatchExecuteStatement(_ context.Context, input *dynamodb.BatchExecuteStatementInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error) {
+	output := &dynamodb.BatchExecuteStatementOutput{}
+	for _, statement := range input.Statements {
+		var code dynamodbtypes.BatchStatementErrorCodeEnum
+		switch *statement.Statement {
+		case `INSERT INTO "test-table" VALUE {'k':?, 'o':?, 'l':?, 'v':?}`:
+			key := m.unmarshalString(statement.Parameters[0])
+			if _, ok := m.items[key]; ok {
+				code = dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem
+				break
+			}
+			m.items[key] = &memTableItem{
+				owner:   m.unmarshalString(statement.Parameters[1]),
+				labels:  m.unmarshalLabels(statement.Parameters[2]),
+				version: m.unmarshalVersion(statement.Parameters[3]),
+			}
+		case `UPDATE "test-table" SET "l"=? SET "v"=? WHERE "k"=? AND "o"=? AND "v"=?`,
+			`UPDATE "test-table" SET "l"=? SET "v"=? WHERE "k"=? AND "o"=? AND "v" IS MISSING`:
+			item, ok := m.items[m.unmarshalString(statement.Parameters[2])]
+			if !ok || item.owner != m.unmarshalString(statement.Parameters[3]) {
+				code = dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed
+				break
+			}
+			if len(statement.Parameters) == 5 {
+				if item.version == nil || *item.version != *m.unmarshalVersion(statement.Parameters[4]) {
+					code = dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed
+					break
+				}
+			} else if item.version != nil {
+				code = dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed
+				break
+			}
+			item.labels = m.unmarshalLabels(statement.Parameters[0])
+			item.version = m.unmarshalVersion(statement.Parameters[1])
+		case `DELETE FROM "test-table" WHERE "k"=? AND "o"=?`:
+			key := m.unmarshalString(statement.Parameters[0])
+			item, ok := m.items[key]
+			if !ok || item.owner != m.unmarshalString(statement.Parameters[1]) {
+				code = dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed
+				break
+			}
+			delete(m.items, key)
+		default:
+			m.t.Errorf("unexpected statement: %s", *statement.Statement)
+			code = dynamodbtypes.BatchStatementErrorCodeEnumValidationError
+		}
+
+		response := dynamodbtypes.BatchStatementResponse{}
+		if code != "" {
+			response.Error = &dynamodbtypes.BatchStatementError{Code: code, Message: aws.String("condition failed")}
+		}
+		output.Responses = append(output.Responses, response)
+	}
+	return output, nil
+}
+
+func (m *memTable) unmarshalString(value dynamodbtypes.AttributeValue) string {
+	var s string
+	require.NoError(m.t, attributevalue.Unmarshal(value, &s))
+	return s
+}
+
+func (m *memTable) unmarshalLabels(value dynamodbtypes.AttributeValue) map[string]string {
+	labels := map[string]string{}
+	require.NoError(m.t, attributevalue.Unmarshal(value, &labels))
+	return labels
+}
+
+func (m *memTable) unmarshalVersion(value dynamodbtypes.AttributeValue) *int64 {
+	var version int64
+	require.NoError(m.t, attributevalue.Unmarshal(value, &version))
+	return &version
+}
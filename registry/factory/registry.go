@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/registry/awssd"
+	"sigs.k8s.io/external-dns/registry/configmap"
 	"sigs.k8s.io/external-dns/registry/crd"
 	"sigs.k8s.io/external-dns/registry/dynamodb"
 	"sigs.k8s.io/external-dns/registry/noop"
@@ -44,11 +45,12 @@ func Select(cfg *externaldns.Config, p provider.Provider) (registry.Registry, er
 // registries looks up the constructor for the named registry.
 func registries(selector string) (RegistryConstructor, bool) {
 	m := map[string]RegistryConstructor{
-		externaldns.RegistryDynamoDB: dynamodb.New,
-		externaldns.RegistryNoop:     noop.New,
-		externaldns.RegistryTXT:      txt.New,
-		externaldns.RegistryAWSSD:    awssd.New,
-		externaldns.RegistryCRD:      crd.New,
+		externaldns.RegistryDynamoDB:  dynamodb.New,
+		externaldns.RegistryNoop:      noop.New,
+		externaldns.RegistryTXT:       txt.New,
+		externaldns.RegistryAWSSD:     awssd.New,
+		externaldns.RegistryCRD:       crd.New,
+		externaldns.RegistryConfigMap: configmap.New,
 	}
 	c, ok := m[selector]
 	return c, ok
@@ -94,6 +94,13 @@ func TestAffixNameMapper_ToEndpointName(t *testing.T) {
 			wantEndpointName: "foo.example.com",
 			wantRecordType:   endpoint.RecordTypeNAPTR,
 		},
+		{
+			name:             "prefix with unsupported record type in affix",
+			mapper:           NewAffixNameMapper("%{record_type}-", "", ""),
+			input:            "any-foo.example.com",
+			wantEndpointName: "",
+			wantRecordType:   "",
+		},
 		{
 			name:             "suffix with A record type in affix",
 			mapper:           NewAffixNameMapper("", "-%{record_type}", ""),
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapper
+
+import (
+	"cmp"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	hostTemplate   = "%{host}"
+	domainTemplate = "%{domain}"
+
+	// maxLabelLength is the maximum length of a DNS label, see RFC 1035 section 2.3.4.
+	maxLabelLength = 63
+	// hashedHostLength is the length of the hexadecimal FNV-64a hash replacing hosts too long for their label
+	hashedHostLength = 16
+)
+
+// EndpointNameIndexer is implemented by name mappers which need the names of the endpoints
+// to map some TXT names back to them, such as the hashes of long hosts.
+type EndpointNameIndexer interface {
+	IndexEndpointNames([]string)
+}
+
+// IndexEndpoints gives the names of the endpoints which are not TXT records to the mapper,
+// when it is an EndpointNameIndexer. It must be called before mapping the TXT names of the endpoints back.
+func IndexEndpoints(m NameMapper, endpoints []*endpoint.Endpoint) {
+	indexer, ok := m.(EndpointNameIndexer)
+	if !ok {
+		return
+	}
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			names = append(names, ep.DNSName)
+		}
+	}
+	indexer.IndexEndpointNames(names)
+}
+
+// TemplateNameMapper is a name mapper based on a template of the TXT name, such as
+// "%{record_type}.%{host}._owner.%{domain}". %{host} is the first label of the endpoint name
+// and %{domain} the rest of it.
+//
+// When the label holding %{host} would exceed 63 characters, the host is replaced by its hash,
+// which is mapped back with the names given to IndexEndpointNames.
+type TemplateNameMapper struct {
+	template            string
+	wildcardReplacement string
+	// hostLabel is the index of the label of the template holding %{host}
+	hostLabel int
+	pattern   *regexp.Regexp
+
+	mutex sync.RWMutex
+	// hashedHosts maps the hashes of the hosts too long for their label back to them
+	hashedHosts map[string]string
+}
+
+// NewTemplateNameMapper returns a new TemplateNameMapper, after checking that the TXT names
+// of sample endpoint names map back to them.
+func NewTemplateNameMapper(template, wildcardReplacement string) (*TemplateNameMapper, error) {
+	template = strings.ToLower(template)
+	head, ok := strings.CutSuffix(template, "."+domainTemplate)
+	if !ok {
+		return nil, fmt.Errorf("TXT name template %q must end with %q", template, "."+domainTemplate)
+	}
+	if strings.Count(head, hostTemplate) != 1 || strings.Count(head, recordTemplate) != 1 {
+		return nil, fmt.Errorf("TXT name template %q must contain %s and %s exactly once", template, hostTemplate, recordTemplate)
+	}
+	if strings.Contains(strings.NewReplacer(hostTemplate, "", recordTemplate, "").Replace(head), "%{") {
+		return nil, fmt.Errorf("TXT name template %q contains an unknown placeholder", template)
+	}
+
+	m := &TemplateNameMapper{
+		template:            template,
+		wildcardReplacement: strings.ToLower(wildcardReplacement),
+		hashedHosts:         map[string]string{},
+	}
+	labels := strings.Split(head, ".")
+	for i, label := range labels {
+		if label == "" {
+			return nil, fmt.Errorf("TXT name template %q contains an empty label", template)
+		}
+		if strings.Contains(label, hostTemplate) {
+			m.hostLabel = i
+		}
+	}
+
+	// longer record types come first, so that "aaaa" is not matched as "a"
+	recordTypes := make([]string, 0, len(supportedRecords))
+	for _, t := range supportedRecords {
+		recordTypes = append(recordTypes, strings.ToLower(t))
+	}
+	slices.SortStableFunc(recordTypes, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	pattern := strings.NewReplacer(
+		regexp.QuoteMeta(hostTemplate), `(?P<host>[^.]+)`,
+		regexp.QuoteMeta(recordTemplate), `(?P<type>`+strings.Join(recordTypes, "|")+`)`,
+	).Replace(regexp.QuoteMeta(head))
+	m.pattern = regexp.MustCompile(`^` + pattern + `(?:\.(?P<domain>.+))?$`)
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *TemplateNameMapper) ToEndpointName(txtDNSName string) (string, string) {
+	match := m.pattern.FindStringSubmatch(strings.ToLower(txtDNSName))
+	if match == nil {
+		log.Debugf("skipping TXT record %q: it does not match the TXT name template %q", txtDNSName, m.template)
+		return "", ""
+	}
+	host := match[m.pattern.SubexpIndex("host")]
+	if len(host) == hashedHostLength {
+		m.mutex.RLock()
+		if unhashed, ok := m.hashedHosts[host]; ok {
+			host = unhashed
+		}
+		m.mutex.RUnlock()
+	}
+	recordType := match[m.pattern.SubexpIndex("type")]
+	for _, t := range supportedRecords {
+		if strings.EqualFold(t, recordType) {
+			recordType = t
+			break
+		}
+	}
+	if domain := match[m.pattern.SubexpIndex("domain")]; domain != "" {
+		return host + "." + domain, recordType
+	}
+	return host, recordType
+}
+
+func (m *TemplateNameMapper) ToTXTName(endpointDNSName, recordType string) string {
+	host, domain, _ := strings.Cut(endpointDNSName, ".")
+	recordType = strings.ToLower(recordType)
+
+	// If specified, replace a leading asterisk in the generated txt record name with some other string
+	if m.wildcardReplacement != "" && host == "*" {
+		host = m.wildcardReplacement
+	}
+	if m.hostLabelLength(host, recordType) > maxLabelLength {
+		host = m.hashHost(strings.ToLower(host))
+	}
+
+	name := strings.NewReplacer(hostTemplate, host, recordTemplate, recordType).
+		Replace(strings.TrimSuffix(m.template, "."+domainTemplate))
+	if domain == "" {
+		return name
+	}
+	return name + "." + domain
+}
+
+// IndexEndpointNames records the hashes of the hosts of the given endpoint names which are
+// too long for their label, for ToEndpointName to map them back. The hashes recorded before
+// are dropped, so that the index does not keep the hosts of the deleted endpoints.
+func (m *TemplateNameMapper) IndexEndpointNames(names []string) {
+	hashedHosts := map[string]string{}
+	for _, name := range names {
+		host, _, _ := strings.Cut(name, ".")
+		host = strings.ToLower(host)
+		for _, t := range supportedRecords {
+			if m.hostLabelLength(host, strings.ToLower(t)) > maxLabelLength {
+				hashedHosts[hostHash(host)] = host
+				break
+			}
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hashedHosts = hashedHosts
+}
+
+// hostLabelLength returns the length of the label holding %{host} for the given host and record type.
+func (m *TemplateNameMapper) hostLabelLength(host, recordType string) int {
+	label := strings.Split(m.template, ".")[m.hostLabel]
+	return len(label) - len(hostTemplate) + len(host) +
+		strings.Count(label, recordTemplate)*(len(recordType)-len(recordTemplate))
+}
+
+// hashHost returns the hash of the host and records it to map it back, until the next IndexEndpointNames.
+func (m *TemplateNameMapper) hashHost(host string) string {
+	hashed := hostHash(host)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hashedHosts[hashed] = host
+	return hashed
+}
+
+// hostHash returns the hash replacing the host in the TXT names.
+func hostHash(host string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(host))
+	return fmt.Sprintf("%0*x", hashedHostLength, h.Sum64())
+}
+
+// validate checks that the TXT names of sample endpoint names are valid and map back to them.
+func (m *TemplateNameMapper) validate() error {
+	wildcard := "*"
+	if m.wildcardReplacement != "" {
+		wildcard = m.wildcardReplacement
+	}
+	longName := strings.Repeat("a", maxLabelLength) + ".example.com"
+	samples := []struct{ name, want string }{
+		{"example.com", "example.com"},
+		{"www.example.com", "www.example.com"},
+		{"aaaa.example.com", "aaaa.example.com"},
+		{"api.eu-west-1.example.com", "api.eu-west-1.example.com"},
+		{longName, longName},
+		{"*.example.com", wildcard + ".example.com"},
+	}
+	for _, sample := range samples {
+		name, want := sample.name, sample.want
+		for _, recordType := range supportedRecords {
+			txtName := m.ToTXTName(name, recordType)
+			for label := range strings.SplitSeq(txtName, ".") {
+				if len(label) > maxLabelLength {
+					return fmt.Errorf("TXT name template %q maps %q (%s) to %q, whose label %q exceeds %d characters",
+						m.template, name, recordType, txtName, label, maxLabelLength)
+				}
+			}
+			if gotName, gotType := m.ToEndpointName(txtName); gotName != want || gotType != recordType {
+				return fmt.Errorf("TXT name template %q maps %q (%s) to %q, which maps back to %q (%s)",
+					m.template, name, recordType, txtName, gotName, gotType)
+			}
+		}
+	}
+	return nil
+}
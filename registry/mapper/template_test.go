@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+var (
+	_ NameMapper          = &TemplateNameMapper{}
+	_ EndpointNameIndexer = &TemplateNameMapper{}
+)
+
+func TestNewTemplateNameMapperErrors(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  string
+	}{
+		{
+			template: "%{record_type}-%{host}",
+			wantErr:  `must end with ".%{domain}"`,
+		},
+		{
+			template: "%{host}._owner.%{domain}",
+			wantErr:  "must contain %{host} and %{record_type} exactly once",
+		},
+		{
+			template: "%{record_type}.%{host}.%{host}.%{domain}",
+			wantErr:  "must contain %{host} and %{record_type} exactly once",
+		},
+		{
+			template: "%{record_type}.%{host}.%{domain}.%{domain}",
+			wantErr:  "contains an unknown placeholder",
+		},
+		{
+			template: "%{record_type}.%{owner}.%{host}.%{domain}",
+			wantErr:  "contains an unknown placeholder",
+		},
+		{
+			template: "%{record_type}..%{host}.%{domain}",
+			wantErr:  "contains an empty label",
+		},
+		{
+			// "a" followed by the host "aaaa" cannot be told apart from "aaaa" followed by "a"
+			template: "%{record_type}%{host}.%{domain}",
+			wantErr:  "which maps back to",
+		},
+		{
+			template: strings.Repeat("x", 64) + ".%{record_type}.%{host}.%{domain}",
+			wantErr:  "exceeds 63 characters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := NewTemplateNameMapper(tt.template, "")
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTemplateNameMapper(t *testing.T) {
+	tests := []struct {
+		name                string
+		template            string
+		wildcardReplacement string
+		endpointName        string
+		recordType          string
+		txtName             string
+		wantEndpointName    string
+	}{
+		{
+			name:         "owner subzone",
+			template:     "%{record_type}.%{host}._owner.%{domain}",
+			endpointName: "www.example.com",
+			recordType:   endpoint.RecordTypeA,
+			txtName:      "a.www._owner.example.com",
+		},
+		{
+			name:         "prefix and suffix",
+			template:     "owner-%{record_type}-%{host}-txt.%{domain}",
+			endpointName: "www.example.com",
+			recordType:   endpoint.RecordTypeAAAA,
+			txtName:      "owner-aaaa-www-txt.example.com",
+		},
+		{
+			name:         "apex",
+			template:     "_owner.%{record_type}-%{host}.%{domain}",
+			endpointName: "example.com",
+			recordType:   endpoint.RecordTypeCNAME,
+			txtName:      "_owner.cname-example.com",
+		},
+		{
+			name:         "single label",
+			template:     "%{record_type}.%{host}._owner.%{domain}",
+			endpointName: "localhost",
+			recordType:   endpoint.RecordTypeA,
+			txtName:      "a.localhost._owner",
+		},
+		{
+			name:                "wildcard replacement",
+			template:            "%{record_type}.%{host}._owner.%{domain}",
+			wildcardReplacement: "Wildcard",
+			endpointName:        "*.example.com",
+			recordType:          endpoint.RecordTypeA,
+			txtName:             "a.wildcard._owner.example.com",
+			wantEndpointName:    "wildcard.example.com",
+		},
+		{
+			name:         "upper case record type",
+			template:     "%{record_type}.%{host}._owner.%{domain}",
+			endpointName: "www.example.com",
+			recordType:   "MX",
+			txtName:      "mx.www._owner.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewTemplateNameMapper(tt.template, tt.wildcardReplacement)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.txtName, m.ToTXTName(tt.endpointName, tt.recordType))
+
+			wantEndpointName := tt.wantEndpointName
+			if wantEndpointName == "" {
+				wantEndpointName = tt.endpointName
+			}
+			endpointName, recordType := m.ToEndpointName(strings.ToUpper(tt.txtName))
+			assert.Equal(t, wantEndpointName, endpointName)
+			assert.Equal(t, strings.ToUpper(tt.recordType), recordType)
+		})
+	}
+}
+
+func TestTemplateNameMapperToEndpointNameNoMatch(t *testing.T) {
+	m, err := NewTemplateNameMapper("%{record_type}.%{host}._owner.%{domain}", "")
+	require.NoError(t, err)
+
+	for _, name := range []string{"", "www.example.com", "a-www.example.com", "spf.www._owner.example.com"} {
+		endpointName, recordType := m.ToEndpointName(name)
+		assert.Empty(t, endpointName, name)
+		assert.Empty(t, recordType, name)
+	}
+}
+
+func TestTemplateNameMapperHashesLongHosts(t *testing.T) {
+	template := "%{record_type}-owner-%{host}.%{domain}"
+	// fits the label with "a-owner-" but not with "cname-owner-"
+	host := strings.Repeat("h", 54)
+	name := host + ".example.com"
+
+	m, err := NewTemplateNameMapper(template, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a-owner-"+host+".example.com", m.ToTXTName(name, endpoint.RecordTypeA))
+	txtName := m.ToTXTName(name, endpoint.RecordTypeCNAME)
+	assert.Regexp(t, `^cname-owner-[0-9a-f]{16}\.example\.com$`, txtName)
+
+	endpointName, recordType := m.ToEndpointName(txtName)
+	assert.Equal(t, name, endpointName)
+	assert.Equal(t, endpoint.RecordTypeCNAME, recordType)
+
+	// a new mapper needs the names of the endpoints to map the hash back
+	m, err = NewTemplateNameMapper(template, "")
+	require.NoError(t, err)
+	endpointName, _ = m.ToEndpointName(txtName)
+	assert.NotEqual(t, name, endpointName)
+
+	IndexEndpoints(m, []*endpoint.Endpoint{
+		endpoint.NewEndpoint(strings.ToUpper(name), endpoint.RecordTypeCNAME, "target.example.com"),
+		endpoint.NewEndpoint(txtName, endpoint.RecordTypeTXT, "heritage=external-dns"),
+	})
+	endpointName, recordType = m.ToEndpointName(txtName)
+	assert.Equal(t, name, endpointName)
+	assert.Equal(t, endpoint.RecordTypeCNAME, recordType)
+
+	// the index is rebuilt, without the hosts of the deleted endpoints
+	IndexEndpoints(m, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+	assert.Empty(t, m.hashedHosts)
+	endpointName, _ = m.ToEndpointName(txtName)
+	assert.NotEqual(t, name, endpointName)
+}
+
+func TestIndexEndpointsIgnoresOtherMappers(t *testing.T) {
+	assert.NotPanics(t, func() {
+		IndexEndpoints(NewAffixNameMapper("txt-", "", ""), []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		})
+	})
+}
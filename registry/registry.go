@@ -36,3 +36,11 @@ type Registry interface {
 	// OwnerID returns the owner identifier used to claim DNS records.
 	OwnerID() string
 }
+
+// Cleaner is implemented by the registries which clean up their own state independently of the
+// changes, such as the ownership records of this owner whose DNS record no longer exists.
+// The controller calls Cleanup on every run once the changes are applied, whether or not there
+// were any, and never with --audit, --plan-output or --dry-run.
+type Cleaner interface {
+	Cleanup(ctx context.Context) error
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	// consolidatedRecordType is the pseudo record type the consolidated TXT records are named for.
+	// ANY is a query type, so no record has it, and the name mappers do not map it back.
+	consolidatedRecordType = "ANY"
+
+	// consolidatedRecordTypeKey and consolidatedSetIdentifierKey are the labels identifying
+	// the record of each string of a consolidated TXT record
+	consolidatedRecordTypeKey    = "record-type"
+	consolidatedSetIdentifierKey = "set-identifier"
+)
+
+// consolidatedTXT is the consolidated TXT record of a DNS name, holding the ownership
+// of all the records of the name with one string per record.
+type consolidatedTXT struct {
+	// record is the TXT record as read from the provider, nil when it does not exist yet
+	record *endpoint.Endpoint
+	// entries are the labels of the records of the name, as they should be written
+	entries map[consolidatedKey]endpoint.Labels
+	// pending is the TXT record being written by ApplyChanges
+	pending *endpoint.Endpoint
+	// foreign is set when the TXT record is owned by another owner, in which case
+	// the records of this owner keep their TXT record per record type
+	foreign bool
+}
+
+type consolidatedKey struct {
+	recordType    string
+	setIdentifier string
+}
+
+func newConsolidatedKey(ep *endpoint.Endpoint) consolidatedKey {
+	return consolidatedKey{recordType: ep.RecordType, setIdentifier: ep.SetIdentifier}
+}
+
+// consolidatedName returns the name of the consolidated TXT record of the DNS name.
+func (im *TXTRegistry) consolidatedName(dnsName string) string {
+	return im.mapper.ToTXTName(dnsName, consolidatedRecordType)
+}
+
+// consolidatedNames maps the names of the consolidated TXT records of the records back to the names
+// of the records, as the name mapper maps the other TXT names back.
+func (im *TXTRegistry) consolidatedNames(records []*endpoint.Endpoint) map[string]string {
+	names := make(map[string]string, len(records))
+	for _, r := range records {
+		dnsName := r.DNSName
+		if host, domain, ok := strings.Cut(dnsName, "."); ok && host == "*" && im.wildcardReplacement != "" {
+			dnsName = im.wildcardReplacement + "." + domain
+		}
+		names[strings.ToLower(im.consolidatedName(r.DNSName))] = strings.ToLower(dnsName)
+	}
+	return names
+}
+
+// isConsolidatedTXT returns whether the TXT record is a consolidated TXT record, whose strings hold
+// the record type of their record. The per record type TXT records never hold it.
+func (im *TXTRegistry) isConsolidatedTXT(record *endpoint.Endpoint) bool {
+	for _, target := range record.Targets {
+		labels, err := endpoint.NewLabelsFromStringWithKeys(target, im.txtEncryptAESKeys)
+		if err == nil {
+			_, ok := labels[consolidatedRecordTypeKey]
+			return ok
+		}
+	}
+	return false
+}
+
+// readConsolidatedTXT parses a consolidated TXT record and returns the labels of the records of its strings.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) readConsolidatedTXT(record *endpoint.Endpoint, endpointName string) map[endpoint.EndpointKey]endpoint.Labels {
+	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	txt := &consolidatedTXT{record: record, entries: map[consolidatedKey]endpoint.Labels{}}
+	for _, target := range record.Targets {
+		labels, err := endpoint.NewLabelsFromStringWithKeys(target, im.txtEncryptAESKeys)
+		if err != nil {
+			log.Warnf("Skipping invalid string of the consolidated TXT record %s: %v", record.DNSName, err)
+			continue
+		}
+		key := consolidatedKey{recordType: labels[consolidatedRecordTypeKey], setIdentifier: labels[consolidatedSetIdentifierKey]}
+		delete(labels, consolidatedRecordTypeKey)
+		delete(labels, consolidatedSetIdentifierKey)
+		if owner := labels[endpoint.OwnerLabelKey]; owner != im.ownerID && (im.oldOwnerID == "" || owner != im.oldOwnerID) {
+			txt.foreign = true
+		}
+		txt.entries[key] = labels
+		labelMap[endpoint.EndpointKey{DNSName: endpointName, RecordType: key.recordType, SetIdentifier: key.setIdentifier}] = labels
+	}
+	im.consolidatedTXTs[record.DNSName] = txt
+	return labelMap
+}
+
+// hasConsolidatedEntry returns whether the ownership of the endpoint is stored in the consolidated TXT record of its name.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) hasConsolidatedEntry(ep *endpoint.Endpoint) bool {
+	txt, ok := im.consolidatedTXTs[im.consolidatedName(ep.DNSName)]
+	if !ok {
+		return false
+	}
+	_, ok = txt.entries[newConsolidatedKey(ep)]
+	return ok
+}
+
+// consolidates returns whether the ownership of the endpoint is written to the consolidated TXT record of its name.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) consolidates(ep *endpoint.Endpoint) bool {
+	if !im.consolidated {
+		return false
+	}
+	txt, ok := im.consolidatedTXTs[im.consolidatedName(ep.DNSName)]
+	return !ok || !txt.foreign
+}
+
+// setConsolidatedEntry stores the ownership of the endpoint in the consolidated TXT record of its name,
+// or removes it when labels is nil. It must be called with consolidatedMutex held.
+func (im *TXTRegistry) setConsolidatedEntry(ep *endpoint.Endpoint, labels endpoint.Labels, touched map[string]string) {
+	name := im.consolidatedName(ep.DNSName)
+	txt, ok := im.consolidatedTXTs[name]
+	if !ok {
+		if labels == nil {
+			return
+		}
+		txt = &consolidatedTXT{entries: map[consolidatedKey]endpoint.Labels{}}
+		im.consolidatedTXTs[name] = txt
+	}
+	if txt.foreign {
+		return
+	}
+	if labels == nil {
+		delete(txt.entries, newConsolidatedKey(ep))
+	} else {
+		txt.entries[newConsolidatedKey(ep)] = maps.Clone(labels)
+	}
+	touched[name] = ep.DNSName
+}
+
+// consolidatedChanges returns the changes of the consolidated TXT records of the touched names,
+// which map the names of the TXT records to the DNS names they belong to.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) consolidatedChanges(touched map[string]string) *plan.Changes {
+	changes := &plan.Changes{}
+	for _, name := range slices.Sorted(maps.Keys(touched)) {
+		txt := im.consolidatedTXTs[name]
+		var desired *endpoint.Endpoint
+		if len(txt.entries) > 0 {
+			desired = im.generateConsolidatedTXT(name, touched[name], txt.entries)
+		}
+		txt.pending = desired
+		switch {
+		case txt.record == nil && desired != nil:
+			changes.Create = append(changes.Create, desired)
+		case txt.record != nil && desired == nil:
+			changes.Delete = append(changes.Delete, txt.record)
+		case txt.record != nil && desired != nil && !txt.record.Targets.Same(desired.Targets):
+			changes.UpdateOld = append(changes.UpdateOld, txt.record)
+			changes.UpdateNew = append(changes.UpdateNew, desired)
+		}
+	}
+	return changes
+}
+
+// commitConsolidatedChanges records the consolidated TXT records of the touched names as written,
+// once the changes are applied. It must be called with consolidatedMutex held.
+func (im *TXTRegistry) commitConsolidatedChanges(touched map[string]string) {
+	for name := range touched {
+		txt := im.consolidatedTXTs[name]
+		if txt.pending == nil {
+			delete(im.consolidatedTXTs, name)
+			continue
+		}
+		txt.record, txt.pending = txt.pending, nil
+	}
+}
+
+func (im *TXTRegistry) generateConsolidatedTXT(name, dnsName string, entries map[consolidatedKey]endpoint.Labels) *endpoint.Endpoint {
+	targets := make([]string, 0, len(entries))
+	for key, labels := range entries {
+		// the labels are serialized in place, so that the encryption nonce generated for them is kept
+		labels[consolidatedRecordTypeKey] = key.recordType
+		if key.setIdentifier != "" {
+			labels[consolidatedSetIdentifierKey] = key.setIdentifier
+		}
+		targets = append(targets, labels.SerializeWithKeys(true, im.txtEncryptEnabled, im.txtEncryptAESKeys))
+		delete(labels, consolidatedRecordTypeKey)
+		delete(labels, consolidatedSetIdentifierKey)
+	}
+	slices.Sort(targets)
+	txt := endpoint.NewEndpoint(name, endpoint.RecordTypeTXT, targets...)
+	txt.Labels[endpoint.OwnedRecordLabelKey] = dnsName
+	return txt
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func newConsolidatedRegistry(t *testing.T, p *inmemory.InMemoryProvider, ownerID string, consolidated bool) *TXTRegistry {
+	t.Helper()
+	managedRecordTypes := []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+	r, err := newRegistry(p, "", "", ownerID, 0, "", managedRecordTypes, []string{}, false, nil, "")
+	require.NoError(t, err)
+	r.consolidated = consolidated
+	return r
+}
+
+func txtRecords(t *testing.T, p *inmemory.InMemoryProvider) map[string]*endpoint.Endpoint {
+	t.Helper()
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	txts := map[string]*endpoint.Endpoint{}
+	for _, record := range records {
+		if record.RecordType == endpoint.RecordTypeTXT {
+			txts[record.DNSName+"/"+record.SetIdentifier] = record
+		}
+	}
+	return txts
+}
+
+// desiredRecords returns copies of the records without the force-update set by the registry, as planned.
+func desiredRecords(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	desired := make([]*endpoint.Endpoint, 0, len(records))
+	for _, record := range records {
+		record = record.DeepCopy()
+		record.DeleteProviderSpecificProperty(providerSpecificForceUpdate)
+		desired = append(desired, record)
+	}
+	return desired
+}
+
+func TestConsolidatedTXTApplyChanges(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r := newConsolidatedRegistry(t, p, "owner", true)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwnerAndLabels("dual.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/dual"}),
+			newEndpointWithOwnerAndLabels("dual.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, "", endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/dual"}),
+			newEndpointWithOwner("weighted.test-zone.example.org", "lb-1.example.com", endpoint.RecordTypeCNAME, "").WithSetIdentifier("one"),
+			newEndpointWithOwner("weighted.test-zone.example.org", "lb-2.example.com", endpoint.RecordTypeCNAME, "").WithSetIdentifier("two"),
+		},
+	}))
+
+	txts := txtRecords(t, p)
+	require.Len(t, txts, 2)
+	assert.ElementsMatch(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=A,external-dns/resource=ingress/default/dual"`,
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=AAAA,external-dns/resource=ingress/default/dual"`,
+	}, txts["any-dual.test-zone.example.org/"].Targets)
+	assert.ElementsMatch(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=CNAME,external-dns/set-identifier=one"`,
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=CNAME,external-dns/set-identifier=two"`,
+	}, txts["any-weighted.test-zone.example.org/"].Targets)
+
+	// a new instance reads the ownership back, without updating anything
+	r = newConsolidatedRegistry(t, p, "owner", true)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	for _, record := range records {
+		assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey], record.DNSName)
+		assert.NotContains(t, record.Labels, consolidatedRecordTypeKey)
+		assert.NotContains(t, record.Labels, consolidatedSetIdentifierKey)
+		_, forceUpdate := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.False(t, forceUpdate, record.DNSName)
+	}
+	dualA := findEndpoint(records, "dual.test-zone.example.org", endpoint.RecordTypeA)
+	assert.Equal(t, "ingress/default/dual", dualA.Labels[endpoint.ResourceLabelKey])
+
+	// deleting one of the records updates the TXT record, deleting the last one deletes it
+	dualAAAA := findEndpoint(records, "dual.test-zone.example.org", endpoint.RecordTypeAAAA)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{dualAAAA}}))
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=A,external-dns/resource=ingress/default/dual"`,
+	}, txtRecords(t, p)["any-dual.test-zone.example.org/"].Targets)
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{dualA}}))
+	txts = txtRecords(t, p)
+	assert.NotContains(t, txts, "any-dual.test-zone.example.org/")
+	assert.Len(t, txts, 1)
+}
+
+func TestConsolidatedTXTUpdate(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r := newConsolidatedRegistry(t, p, "owner", true)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwnerAndLabels("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", endpoint.Labels{endpoint.ResourceLabelKey: "service/default/foo"}),
+		},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	current := findEndpoint(records, "foo.test-zone.example.org", endpoint.RecordTypeA)
+	desired := current.DeepCopy()
+	desired.Targets = endpoint.Targets{"5.6.7.8"}
+	desired.Labels[endpoint.ResourceLabelKey] = "service/default/bar"
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{current},
+		UpdateNew: []*endpoint.Endpoint{desired},
+	}))
+
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=A,external-dns/resource=service/default/bar"`,
+	}, txtRecords(t, p)["any-foo.test-zone.example.org/"].Targets)
+}
+
+func TestConsolidatedTXTMigration(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	perType := newConsolidatedRegistry(t, p, "owner", false)
+	require.NoError(t, perType.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("dual.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("dual.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, ""),
+		},
+	}))
+	assert.Len(t, txtRecords(t, p), 2)
+
+	// the ownership is kept and the records are updated to migrate their TXT records
+	r := newConsolidatedRegistry(t, p, "owner", true)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey])
+		forceUpdate, _ := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.Equal(t, "true", forceUpdate, record.RecordType)
+	}
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: records,
+		UpdateNew: desiredRecords(records),
+	}))
+
+	txts := txtRecords(t, p)
+	require.Len(t, txts, 1)
+	assert.ElementsMatch(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=A"`,
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=AAAA"`,
+	}, txts["any-dual.test-zone.example.org/"].Targets)
+
+	records, err = newConsolidatedRegistry(t, p, "owner", true).Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey])
+		_, forceUpdate := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.False(t, forceUpdate, record.RecordType)
+	}
+
+	// disabling the consolidated records migrates back to the TXT records per record type
+	perType = newConsolidatedRegistry(t, p, "owner", false)
+	records, err = perType.Records(ctx)
+	require.NoError(t, err)
+	for _, record := range records {
+		assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey])
+		forceUpdate, _ := record.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.Equal(t, "true", forceUpdate, record.RecordType)
+	}
+	require.NoError(t, perType.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: records,
+		UpdateNew: desiredRecords(records),
+	}))
+	txts = txtRecords(t, p)
+	assert.Len(t, txts, 2)
+	assert.Contains(t, txts, "a-dual.test-zone.example.org/")
+	assert.Contains(t, txts, "aaaa-dual.test-zone.example.org/")
+}
+
+func TestConsolidatedTXTOwnedByAnotherOwner(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	other := newConsolidatedRegistry(t, p, "other", true)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("shared.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	// the records of another owner at the same name keep their TXT record per record type
+	r := newConsolidatedRegistry(t, p, "owner", true)
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("shared.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, "")},
+	}))
+
+	txts := txtRecords(t, p)
+	require.Len(t, txts, 2)
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=other,external-dns/record-type=A"`,
+	}, txts["any-shared.test-zone.example.org/"].Targets)
+	assert.Contains(t, txts, "aaaa-shared.test-zone.example.org/")
+
+	records, err := newConsolidatedRegistry(t, p, "owner", true).Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "other", findEndpoint(records, "shared.test-zone.example.org", endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+	aaaa := findEndpoint(records, "shared.test-zone.example.org", endpoint.RecordTypeAAAA)
+	assert.Equal(t, "owner", aaaa.Labels[endpoint.OwnerLabelKey])
+	_, forceUpdate := aaaa.GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.False(t, forceUpdate)
+}
+
+func TestConsolidatedTXTEncrypted(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	newEncryptedRegistry := func() *TXTRegistry {
+		r, err := newRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA}, []string{}, true, []byte("passphrasewhichneedstobe32bytes!"), "")
+		require.NoError(t, err)
+		r.consolidated = true
+		return r
+	}
+
+	r := newEncryptedRegistry()
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwnerAndLabels("dual.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/dual"}),
+			newEndpointWithOwnerAndLabels("dual.test-zone.example.org", "2001:db8::1", endpoint.RecordTypeAAAA, "", endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/dual"}),
+		},
+	}))
+	txt := txtRecords(t, p)["any-dual.test-zone.example.org/"]
+	require.NotNil(t, txt)
+	require.Len(t, txt.Targets, 2)
+	for _, target := range txt.Targets {
+		assert.NotContains(t, target, "record-type")
+	}
+
+	// the strings are encrypted with stable nonces, so an update without changes leaves the record as is
+	r = newEncryptedRegistry()
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	a := findEndpoint(records, "dual.test-zone.example.org", endpoint.RecordTypeA)
+	assert.Equal(t, "ingress/default/dual", a.Labels[endpoint.ResourceLabelKey])
+	var applied *plan.Changes
+	p.OnApplyChanges = func(_ context.Context, changes *plan.Changes) { applied = changes }
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{a},
+		UpdateNew: []*endpoint.Endpoint{a.DeepCopy()},
+	}))
+	require.NotNil(t, applied)
+	assert.Len(t, applied.UpdateNew, 1, "only the record itself is updated")
+	assert.ElementsMatch(t, txt.Targets, txtRecords(t, p)["any-dual.test-zone.example.org/"].Targets)
+}
+
+func TestConsolidatedTXTProviderFailure(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r := newConsolidatedRegistry(t, p, "owner", true)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	// the record exists already, so the provider fails
+	err := r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	})
+	require.True(t, errors.Is(err, inmemory.ErrRecordAlreadyExists), err)
+	assert.NotContains(t, r.consolidatedTXTs, "any-foo.test-zone.example.org", "the consolidated record is read again")
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+	assert.Contains(t, r.consolidatedTXTs, "any-foo.test-zone.example.org")
+}
+
+func TestConsolidatedTXTRecordNamedAny(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("any-foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("any-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+	r := newConsolidatedRegistry(t, p, "owner", true)
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", findEndpoint(records, "any-foo.test-zone.example.org", endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+	assert.Empty(t, r.consolidatedTXTs, "the TXT record of the old format is not a consolidated TXT record")
+}
+
+func TestConsolidatedTXTRecordCreatedAgain(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r := newConsolidatedRegistry(t, p, "owner", true)
+	foo := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{foo.DeepCopy()}}))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{foo.DeepCopy()}}))
+
+	// the consolidated TXT record of the removed record is written again, not created
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{foo.DeepCopy()}}))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", findEndpoint(records, "foo.test-zone.example.org", endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=owner,external-dns/record-type=A"`,
+	}, txtRecords(t, p)["any-foo.test-zone.example.org/"].Targets)
+}
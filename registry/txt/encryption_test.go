@@ -18,6 +18,7 @@ package txt
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -66,7 +67,7 @@ func TestNewTXTRegistryEncryptionConfig(t *testing.T) {
 			require.Error(t, err)
 		} else {
 			require.NoError(t, err)
-			assert.Equal(t, test.aesKeySanitized, actual.txtEncryptAESKey)
+			assert.Equal(t, [][]byte{test.aesKeySanitized}, actual.txtEncryptAESKeys)
 		}
 	}
 }
@@ -124,7 +125,7 @@ func TestGenerateTXTGenerateTextRecordEncryptionWihDecryption(t *testing.T) {
 						encryptedText, errUnquote := strconv.Unquote(target)
 						assert.NoError(t, errUnquote, "Error unquoting the encrypted text")
 
-						actual, nonce, errDecrypt := endpoint.DecryptText(encryptedText, r.txtEncryptAESKey)
+						actual, nonce, errDecrypt := endpoint.DecryptText(encryptedText, r.txtEncryptAESKeys[0])
 						assert.NoError(t, errDecrypt, "Error decrypting the encrypted text")
 
 						assert.True(t, strings.HasPrefix(encryptedText, nonce),
@@ -297,3 +298,70 @@ func newEndpointWithOwnerAndOwnedRecordWithKeyIDLabel(dnsName, target, recordTyp
 	e.Labels["key-id"] = keyId
 	return e
 }
+
+func TestApplyRecordsWithPreviousEncryptionKey(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	_ = p.CreateZone("org")
+
+	managedRecordTypes := []string{endpoint.RecordTypeCNAME}
+	oldKey := "passphrasewhichneedstobe32bytes!"
+	newKey := "ZPitL0NGVQBZbTD6DwXJzD8RiStSazzYXQsdUowLURY="
+
+	old, err := newRegistry(p, "", "", "owner", 0, "", managedRecordTypes, []string{}, true, []byte(oldKey), "")
+	require.NoError(t, err)
+	require.NoError(t, old.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("rotated.org", "rotated.loadbalancer.com", endpoint.RecordTypeCNAME, "owner"),
+		},
+	}))
+
+	r, err := newRegistry(p, "", "", "owner", 0, "", managedRecordTypes, []string{}, true, []byte(newKey), "")
+	require.NoError(t, err)
+	require.NoError(t, r.withPreviousAESKeys([]string{oldKey}, true))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	current := records[0]
+	assert.Equal(t, "owner", current.Labels[endpoint.OwnerLabelKey])
+	assert.True(t, current.Labels.EncryptedWithPreviousKey())
+	forceUpdate, ok := current.GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.True(t, ok)
+	assert.Equal(t, "true", forceUpdate)
+
+	desired := newEndpointWithOwner("rotated.org", "rotated.loadbalancer.com", endpoint.RecordTypeCNAME, "owner")
+	desired.Labels = maps.Clone(current.Labels)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{current},
+		UpdateNew: []*endpoint.Endpoint{desired},
+	}))
+
+	// the record is owned when only the new key is known
+	rotated, err := newRegistry(p, "", "", "owner", 0, "", managedRecordTypes, []string{}, true, []byte(newKey), "")
+	require.NoError(t, err)
+	records, err = rotated.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+	assert.False(t, records[0].Labels.EncryptedWithPreviousKey())
+	_, ok = records[0].GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.False(t, ok)
+}
+
+func TestWithPreviousAESKeys(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+
+	r, err := newRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, "")
+	require.NoError(t, err)
+	require.EqualError(t, r.withPreviousAESKeys([]string{"passphrasewhichneedstobe32bytes!"}, false),
+		"the AES Encryption key must be set when previous keys are set")
+
+	r, err = newRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, true, []byte("01234567890123456789012345678901"), "")
+	require.NoError(t, err)
+	require.ErrorContains(t, r.withPreviousAESKeys([]string{"too-short"}, false), "previous key: ")
+
+	require.NoError(t, r.withPreviousAESKeys([]string{"passphrasewhichneedstobe32bytes!"}, true))
+	assert.Equal(t, [][]byte{[]byte("01234567890123456789012345678901"), []byte("passphrasewhichneedstobe32bytes!")}, r.txtEncryptAESKeys)
+	assert.True(t, r.txtEncryptReencrypt)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var previousKeyRecordsTotal = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Subsystem: "registry",
+		Name:      "txt_records_previous_aes_key",
+		Help:      "Number of TXT registry records still encrypted with a previous AES key.",
+	},
+)
+
+var orphanedRecordsTotal = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Subsystem: "registry",
+		Name:      "txt_records_orphaned",
+		Help:      "Number of TXT registry records of this owner whose record no longer exists.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(previousKeyRecordsTotal)
+	metrics.RegisterMetric.MustRegister(orphanedRecordsTotal)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/sets"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ownedTXT is a TXT record of this owner, along with the key of the record it belongs to.
+type ownedTXT struct {
+	record *endpoint.Endpoint
+	key    endpoint.EndpointKey
+}
+
+// withOrphanCleanup enables the cleanup of the TXT records of this owner whose record no longer exists,
+// once they have been orphaned for minAge. With dry-run, the TXT records are only reported.
+func (im *TXTRegistry) withOrphanCleanup(mode string, minAge time.Duration, dryRun bool) {
+	if mode == "" || mode == externaldns.TXTOrphanCleanupDisabled {
+		return
+	}
+	im.orphanCleanup = true
+	im.orphanDryRun = dryRun || mode == externaldns.TXTOrphanCleanupDryRun
+	im.orphanMinAge = minAge
+}
+
+// isOrphan returns whether the TXT record belongs to no record. The TXT records of record types which are not
+// managed are kept, since the provider may not report their records. The TXT records whose name does not map
+// to a record, such as those left by a previous prefix, are orphaned.
+func (im *TXTRegistry) isOrphan(txt ownedTXT, used sets.Set[endpoint.EndpointKey]) bool {
+	if used.Has(txt.key) {
+		return false
+	}
+	return txt.key.RecordType == "" || plan.IsManagedRecord(txt.key.RecordType, im.managedRecordTypes, im.excludeRecordTypes)
+}
+
+// detectOrphans records the orphaned TXT records which have been seen for the minimum age, to be deleted
+// by the next Cleanup, or only reports them in dry-run. Records() only reads, so the TXT records are
+// not deleted when the controller does not clean up the registry, such as with --audit or --plan-output.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) detectOrphans(txts []ownedTXT, used sets.Set[endpoint.EndpointKey]) {
+	now := im.clock()
+	seen := map[recordKey]time.Time{}
+	var orphans []ownedTXT
+	for _, txt := range txts {
+		if !im.isOrphan(txt, used) {
+			continue
+		}
+		key := recordKey{dnsName: txt.record.DNSName, setIdentifier: txt.record.SetIdentifier}
+		firstSeen, ok := im.orphansSeen[key]
+		if !ok {
+			firstSeen = now
+		}
+		seen[key] = firstSeen
+		if now.Sub(firstSeen) >= im.orphanMinAge {
+			orphans = append(orphans, txt)
+		}
+	}
+	im.orphansSeen = seen
+	im.orphans = nil
+	orphanedRecordsTotal.Gauge.Set(float64(len(seen)))
+
+	if len(orphans) == 0 {
+		return
+	}
+	if im.orphanDryRun {
+		for _, orphan := range orphans {
+			log.Infof("Would delete the orphaned TXT record %q (set identifier %q), its record no longer exists", orphan.record.DNSName, orphan.record.SetIdentifier)
+		}
+		log.Infof("Would delete %d orphaned TXT records", len(orphans))
+		return
+	}
+	im.orphans = orphans
+}
+
+// skipOrphans forgets the orphaned TXT records handled by the changes: the TXT records of the records
+// created or updated by the changes are kept, and the changes already delete the others of their names.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) skipOrphans(changes *plan.Changes) {
+	if len(im.orphans) == 0 {
+		return
+	}
+
+	planned := sets.New[endpoint.EndpointKey]()
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew) {
+		planned.Insert(ep.Key())
+	}
+	deleted := sets.New[recordKey]()
+	for _, ep := range changes.Delete {
+		deleted.Insert(recordKey{dnsName: ep.DNSName, setIdentifier: ep.SetIdentifier})
+	}
+	im.orphans = slices.DeleteFunc(im.orphans, func(orphan ownedTXT) bool {
+		return planned.Has(orphan.key) || deleted.Has(recordKey{dnsName: orphan.record.DNSName, setIdentifier: orphan.record.SetIdentifier})
+	})
+}
+
+// Cleanup deletes the orphaned TXT records found by the last Records(), once. It is called by the
+// controller on every run after the changes are applied, so the orphaned TXT records are deleted
+// even when there are no changes.
+func (im *TXTRegistry) Cleanup(ctx context.Context) error {
+	im.consolidatedMutex.Lock()
+	orphans := im.orphans
+	im.orphans = nil
+	im.consolidatedMutex.Unlock()
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	deletes := make([]*endpoint.Endpoint, 0, len(orphans))
+	for _, orphan := range orphans {
+		log.Infof("Deleting the orphaned TXT record %q (set identifier %q), its record no longer exists", orphan.record.DNSName, orphan.record.SetIdentifier)
+		deletes = append(deletes, orphan.record)
+	}
+	if im.cacheInterval > 0 {
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	}
+	if err := im.provider.ApplyChanges(ctx, &plan.Changes{Delete: deletes}); err != nil {
+		return err
+	}
+
+	im.consolidatedMutex.Lock()
+	defer im.consolidatedMutex.Unlock()
+	im.forgetOrphans(deletes)
+	return nil
+}
+
+// forgetOrphans forgets the orphaned TXT records once they are deleted.
+// It must be called with consolidatedMutex held.
+func (im *TXTRegistry) forgetOrphans(orphans []*endpoint.Endpoint) {
+	for _, orphan := range orphans {
+		delete(im.orphansSeen, recordKey{dnsName: orphan.DNSName, setIdentifier: orphan.SetIdentifier})
+		im.existingTXTs.remove(orphan)
+	}
+}
+
+func (im *TXTRegistry) clock() time.Time {
+	if im.now != nil {
+		return im.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	logtest "sigs.k8s.io/external-dns/internal/testutils/log"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+// newOrphanRegistry returns a registry cleaning up the orphaned TXT records with the given mode,
+// along with a pointer to its current time.
+func newOrphanRegistry(t *testing.T, p *inmemory.InMemoryProvider, txtPrefix, mode string) (*TXTRegistry, *time.Time) {
+	t.Helper()
+	managedRecordTypes := []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+	r, err := newRegistry(p, txtPrefix, "", "owner", 0, "", managedRecordTypes, []string{}, false, nil, "")
+	require.NoError(t, err)
+	r.withOrphanCleanup(mode, time.Hour, false)
+	now := time.Date(2026, 6, 4, 10, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestOrphanCleanupDeletesOrphanedTXT(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r, now := newOrphanRegistry(t, p, "", externaldns.TXTOrphanCleanupDelete)
+
+	kept := newEndpointWithOwner("kept.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")
+	removed := newEndpointWithOwner("removed.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{kept, removed}}))
+	// the record is deleted by hand, leaving its TXT record behind
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{newEndpointWithOwner("removed.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")},
+	}))
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, txtRecords(t, p), "a-removed.test-zone.example.org/", "the TXT record is kept until it reaches the minimum age")
+
+	*now = now.Add(30 * time.Minute)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, txtRecords(t, p), "a-removed.test-zone.example.org/")
+
+	*now = now.Add(30 * time.Minute)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, txtRecords(t, p), "a-removed.test-zone.example.org/", "the TXT records are not deleted by Records()")
+
+	// the orphaned TXT record is deleted by the cleanup, without changes
+	require.NoError(t, r.Cleanup(ctx))
+	txts := txtRecords(t, p)
+	assert.NotContains(t, txts, "a-removed.test-zone.example.org/")
+	assert.Contains(t, txts, "a-kept.test-zone.example.org/")
+	assert.Equal(t, "owner", findEndpoint(records, "kept.test-zone.example.org", endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+	assert.Empty(t, r.orphansSeen)
+	assert.Empty(t, r.orphans)
+
+	// the record created again gets a new TXT record
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("removed.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")},
+	}))
+	assert.Contains(t, txtRecords(t, p), "a-removed.test-zone.example.org/")
+}
+
+func TestOrphanCleanupRecordCreatedWithChanges(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newTXTEndpointWithOwnedRecord("a-back.test-zone.example.org", `"heritage=external-dns,external-dns/owner=owner"`, "")},
+	}))
+	r, now := newOrphanRegistry(t, p, "", externaldns.TXTOrphanCleanupDelete)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	*now = now.Add(time.Hour)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, r.orphans, 1)
+
+	// the orphaned TXT record is kept when the changes create its record
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("back.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+	}))
+	assert.Empty(t, r.orphans)
+	require.NoError(t, r.Cleanup(ctx))
+	assert.Contains(t, txtRecords(t, p), "a-back.test-zone.example.org/")
+}
+
+func TestOrphanCleanupDryRun(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newTXTEndpointWithOwnedRecord("a-removed.test-zone.example.org", `"heritage=external-dns,external-dns/owner=owner"`, "")},
+	}))
+	r, now := newOrphanRegistry(t, p, "", externaldns.TXTOrphanCleanupDryRun)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	*now = now.Add(time.Hour)
+	hook := logtest.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+
+	logtest.TestHelperLogContains(`Would delete the orphaned TXT record "a-removed.test-zone.example.org"`, hook, t)
+	logtest.TestHelperLogContains("Would delete 1 orphaned TXT records", hook, t)
+	assert.Empty(t, r.orphans)
+	require.NoError(t, r.Cleanup(ctx))
+	assert.Contains(t, txtRecords(t, p), "a-removed.test-zone.example.org/")
+}
+
+func TestOrphanCleanupKeepsTXTRecords(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			// TXT record of another owner
+			newTXTEndpointWithOwnedRecord("a-other.test-zone.example.org", `"heritage=external-dns,external-dns/owner=other"`, ""),
+			// TXT record of a record type which is not managed
+			newTXTEndpointWithOwnedRecord("mx-mail.test-zone.example.org", `"heritage=external-dns,external-dns/owner=owner"`, ""),
+			// TXT record of the old format, without record type
+			newEndpointWithOwner("legacy.test-zone.example.org", "lb.example.com", endpoint.RecordTypeCNAME, ""),
+			newTXTEndpointWithOwnedRecord("legacy.test-zone.example.org", `"heritage=external-dns,external-dns/owner=owner"`, ""),
+			// TXT record which is not an ownership record
+			newTXTEndpointWithOwnedRecord("a-unknown.test-zone.example.org", `"v=spf1 -all"`, ""),
+		},
+	}))
+	r, now := newOrphanRegistry(t, p, "", externaldns.TXTOrphanCleanupDelete)
+	before := txtRecords(t, p)
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	*now = now.Add(time.Hour)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, r.orphans)
+	require.NoError(t, r.Cleanup(ctx))
+
+	assert.Equal(t, before, txtRecords(t, p))
+}
+
+func TestOrphanCleanupRecordBack(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r, now := newOrphanRegistry(t, p, "", externaldns.TXTOrphanCleanupDelete)
+	ep := newEndpointWithOwner("back.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{ep.DeepCopy()}}))
+
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, r.orphansSeen, 1)
+
+	// the record is back before the minimum age, so the age starts over once it is removed again
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep.DeepCopy()}}))
+	*now = now.Add(50 * time.Minute)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, r.orphansSeen)
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{ep.DeepCopy()}}))
+	*now = now.Add(50 * time.Minute)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, txtRecords(t, p), "a-back.test-zone.example.org/")
+}
+
+// The TXT records left by a previous prefix no longer map to their record, and are orphaned.
+func TestOrphanCleanupAfterPrefixChange(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	old, _ := newOrphanRegistry(t, p, "old-", externaldns.TXTOrphanCleanupDisabled)
+	ep := newEndpointWithOwner("www.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")
+	require.NoError(t, old.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{ep}}))
+	require.Contains(t, txtRecords(t, p), "old-a-www.test-zone.example.org/")
+
+	r, now := newOrphanRegistry(t, p, "new-", externaldns.TXTOrphanCleanupDelete)
+	_, err := r.Records(ctx)
+	require.NoError(t, err)
+	*now = now.Add(time.Hour)
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.Cleanup(ctx))
+	assert.NotContains(t, txtRecords(t, p), "old-a-www.test-zone.example.org/")
+}
+
+func TestWithOrphanCleanup(t *testing.T) {
+	r := &TXTRegistry{}
+	r.withOrphanCleanup(externaldns.TXTOrphanCleanupDisabled, time.Hour, true)
+	assert.False(t, r.orphanCleanup)
+
+	r.withOrphanCleanup(externaldns.TXTOrphanCleanupDelete, time.Hour, false)
+	assert.True(t, r.orphanCleanup)
+	assert.False(t, r.orphanDryRun)
+	assert.Equal(t, time.Hour, r.orphanMinAge)
+
+	// the orphaned TXT records are only reported with --dry-run
+	r.withOrphanCleanup(externaldns.TXTOrphanCleanupDelete, time.Hour, true)
+	assert.True(t, r.orphanDryRun)
+}
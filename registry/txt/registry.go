@@ -18,10 +18,12 @@ package txt
 
 import (
 	"context"
-	b64 "encoding/base64"
 	"errors"
+	"fmt"
 	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -49,6 +51,8 @@ type TXTRegistry struct {
 	recordsCache            []*endpoint.Endpoint
 	recordsCacheRefreshTime time.Time
 	cacheInterval           time.Duration
+	// cacheMutex guards recordsCache against changes applied concurrently per zone
+	cacheMutex sync.RWMutex
 
 	// optional string to use to replace the asterisk in wildcard entries - without using this,
 	// registry TXT records corresponding to wildcard records will be invalid (and rejected by most providers), due to
@@ -60,7 +64,10 @@ type TXTRegistry struct {
 
 	// encrypt text records
 	txtEncryptEnabled bool
-	txtEncryptAESKey  []byte
+	// txtEncryptAESKeys holds the key records are encrypted with, followed by previous keys they are still decrypted with
+	txtEncryptAESKeys [][]byte
+	// txtEncryptReencrypt forces an update of owned records still encrypted with a previous key
+	txtEncryptReencrypt bool
 
 	// Handle Owner ID migration
 	oldOwnerID string
@@ -71,6 +78,32 @@ type TXTRegistry struct {
 
 	// obsoleteTXTWarned dedups the legacy "cname-" alias warning to once per record per process.
 	obsoleteTXTWarned sets.Set[string]
+
+	// consolidated stores the ownership of all the records of a name in a single TXT record
+	consolidated bool
+	// consolidatedTXTs are the consolidated TXT records by name, read by Records() and updated by ApplyChanges()
+	consolidatedTXTs map[string]*consolidatedTXT
+	// consolidatedMutex guards consolidatedTXTs against changes applied concurrently per zone
+	consolidatedMutex sync.Mutex
+
+	// shared shares the ownership of address records with their other owners, merging their targets
+	shared bool
+	// sharedTXTs are the TXT records of the shared records, read by Records()
+	sharedTXTs map[recordKey]*sharedTXT
+	// sharedMutex guards sharedTXTs against records read while changes are applied
+	sharedMutex sync.RWMutex
+
+	// orphanCleanup deletes the TXT records of this owner whose record no longer exists, or only reports them
+	// with orphanDryRun, once they have been orphaned for orphanMinAge
+	orphanCleanup bool
+	orphanDryRun  bool
+	orphanMinAge  time.Duration
+	// orphansSeen holds the time the orphaned TXT records were first seen
+	orphansSeen map[recordKey]time.Time
+	// orphans are the orphaned TXT records found by Records(), deleted by the next Cleanup
+	orphans []ownedTXT
+	// now returns the current time, time.Now is used when nil
+	now func() time.Time
 }
 
 // existingTXTs stores pre‑existing TXT records to avoid duplicate creation.
@@ -99,6 +132,10 @@ func (im *existingTXTs) add(r *endpoint.Endpoint) {
 	im.entries.Insert(key)
 }
 
+func (im *existingTXTs) remove(r *endpoint.Endpoint) {
+	im.entries.Delete(recordKey{dnsName: r.DNSName, setIdentifier: r.SetIdentifier})
+}
+
 // isAbsent returns true when there is no entry for the given name in the store.
 // This is intended for the "if absent -> create" pattern.
 func (im *existingTXTs) isAbsent(ep *endpoint.Endpoint) bool {
@@ -109,6 +146,11 @@ func (im *existingTXTs) isAbsent(ep *endpoint.Endpoint) bool {
 	return !im.entries.Has(key)
 }
 
+// exists returns true when there is an entry for the given name in the store.
+func (im *existingTXTs) exists(ep *endpoint.Endpoint) bool {
+	return !im.isAbsent(ep)
+}
+
 func (im *existingTXTs) reset() {
 	// Reset the existing TXT records for the next reconciliation loop.
 	// This is necessary because the existing TXT records are only relevant for the current reconciliation cycle.
@@ -117,10 +159,23 @@ func (im *existingTXTs) reset() {
 
 // New creates a TXTRegistry from the given configuration.
 func New(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
-	return newRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID,
+	r, err := newRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID,
 		cfg.TXTCacheInterval, cfg.TXTWildcardReplacement,
 		cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes,
 		cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTOwnerOld)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.withPreviousAESKeys(cfg.TXTEncryptPreviousAESKeys, cfg.TXTEncryptReencrypt); err != nil {
+		return nil, err
+	}
+	if err := r.withNameTemplate(cfg.TXTNameTemplate); err != nil {
+		return nil, err
+	}
+	r.consolidated = cfg.TXTConsolidated
+	r.shared = cfg.TXTSharedOwnership
+	r.withOrphanCleanup(cfg.TXTOrphanCleanup, cfg.TXTOrphanMinAge, cfg.DryRun)
+	return r, nil
 }
 
 // newRegistry returns a new TXTRegistry object. When newFormatOnly is true, it will only
@@ -135,14 +190,9 @@ func newRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID strin
 		return nil, errors.New("owner id cannot be empty")
 	}
 
-	// TODO: encryption logic duplicated in DynamoDB registry; refactor into common utility function.
-	if len(txtEncryptAESKey) == 0 {
-		txtEncryptAESKey = nil
-	} else if len(txtEncryptAESKey) != 32 {
-		var err error
-		if txtEncryptAESKey, err = b64.StdEncoding.DecodeString(string(txtEncryptAESKey)); err != nil || len(txtEncryptAESKey) != 32 {
-			return nil, errors.New("the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
-		}
+	txtEncryptAESKey, err := endpoint.ParseAESKey(string(txtEncryptAESKey))
+	if err != nil {
+		return nil, err
 	}
 
 	if txtEncryptEnabled && txtEncryptAESKey == nil {
@@ -153,6 +203,11 @@ func newRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID strin
 		return nil, errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	var txtEncryptAESKeys [][]byte
+	if txtEncryptAESKey != nil {
+		txtEncryptAESKeys = [][]byte{txtEncryptAESKey}
+	}
+
 	return &TXTRegistry{
 		provider:            provider,
 		ownerID:             ownerID,
@@ -162,13 +217,48 @@ func newRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID strin
 		managedRecordTypes:  managedRecordTypes,
 		excludeRecordTypes:  excludeRecordTypes,
 		txtEncryptEnabled:   txtEncryptEnabled,
-		txtEncryptAESKey:    txtEncryptAESKey,
+		txtEncryptAESKeys:   txtEncryptAESKeys,
 		oldOwnerID:          oldOwnerID,
 		existingTXTs:        newExistingTXTs(),
 		obsoleteTXTWarned:   sets.New[string](),
+		consolidatedTXTs:    map[string]*consolidatedTXT{},
+		sharedTXTs:          map[recordKey]*sharedTXT{},
+		orphansSeen:         map[recordKey]time.Time{},
 	}, nil
 }
 
+// withNameTemplate replaces the prefix and suffix of the TXT record names by the given template, if any.
+func (im *TXTRegistry) withNameTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	m, err := mapper.NewTemplateNameMapper(template, im.wildcardReplacement)
+	if err != nil {
+		return err
+	}
+	im.mapper = m
+	return nil
+}
+
+// withPreviousAESKeys adds the keys records encrypted before a key rotation are still decrypted with.
+// With reencrypt, owned records still encrypted with a previous key are updated to the current key.
+func (im *TXTRegistry) withPreviousAESKeys(keys []string, reencrypt bool) error {
+	if len(keys) > 0 && len(im.txtEncryptAESKeys) == 0 {
+		return errors.New("the AES Encryption key must be set when previous keys are set")
+	}
+	for _, key := range keys {
+		aesKey, err := endpoint.ParseAESKey(key)
+		if err != nil {
+			return fmt.Errorf("previous key: %w", err)
+		}
+		if aesKey != nil {
+			im.txtEncryptAESKeys = append(im.txtEncryptAESKeys, aesKey)
+		}
+	}
+	im.txtEncryptReencrypt = reencrypt
+	return nil
+}
+
 // GetDomainFilter returns the domain filter from the underlying provider.
 func (im *TXTRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
@@ -191,9 +281,9 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 	// If we have the zones cached AND we have refreshed the cache since the
 	// last given interval, then just use the cached results.
-	if im.recordsCache != nil && time.Since(im.recordsCacheRefreshTime) < im.cacheInterval {
+	if cached := im.cachedRecords(); cached != nil {
 		log.Debug("Using cached records.")
-		return im.recordsCache, nil
+		return cached, nil
 	}
 
 	records, err := im.provider.Records(ctx)
@@ -205,6 +295,23 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
 	txtRecordsSet := make(sets.Set[string], len(records))
+	previousKeyRecords := 0
+	mapper.IndexEndpoints(im.mapper, records)
+	consolidatedNames := im.consolidatedNames(records)
+
+	im.consolidatedMutex.Lock()
+	defer im.consolidatedMutex.Unlock()
+	im.consolidatedTXTs = map[string]*consolidatedTXT{}
+	consolidatedLabelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+
+	im.sharedMutex.Lock()
+	defer im.sharedMutex.Unlock()
+	im.sharedTXTs = map[recordKey]*sharedTXT{}
+
+	// ownedTXTs are the TXT records of this owner, and usedKeys the keys of the existing records,
+	// to find the orphaned TXT records
+	var ownedTXTs []ownedTXT
+	usedKeys := sets.New[endpoint.EndpointKey]()
 
 	for _, record := range records {
 		if record.RecordType != endpoint.RecordTypeTXT {
@@ -217,24 +324,66 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			log.Errorf("TXT record has no targets %s", record.DNSName)
 			continue
 		}
-		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
-		if errors.Is(err, endpoint.ErrInvalidHeritage) {
-			// if no heritage is found or it is invalid
-			// case when value of txt record cannot be identified
-			// record will not be removed as it will have empty owner
-			endpoints = append(endpoints, record)
+		if im.isConsolidatedTXT(record) {
+			// the name of a consolidated TXT record whose records no longer exist is not mapped back,
+			// it is only read to be written again
+			for key, labels := range im.readConsolidatedTXT(record, consolidatedNames[strings.ToLower(record.DNSName)]) {
+				consolidatedLabelMap[key] = labels
+				if labels.EncryptedWithPreviousKey() {
+					previousKeyRecords++
+				}
+			}
+			txtRecordsSet.Insert(record.DNSName)
+			im.existingTXTs.add(record)
 			continue
 		}
-
 		endpointName, recordType := im.mapper.ToEndpointName(record.DNSName)
 		key := endpoint.EndpointKey{
 			DNSName:       endpointName,
 			RecordType:    recordType,
 			SetIdentifier: record.SetIdentifier,
 		}
+		if im.shared {
+			if labels, ok := im.readSharedTXT(record); ok {
+				labelMap[key] = labels
+				if labels.EncryptedWithPreviousKey() {
+					previousKeyRecords++
+				}
+				txtRecordsSet.Insert(record.DNSName)
+				im.existingTXTs.add(record)
+				continue
+			}
+		} else if labels, ok := im.readForeignSharedTXT(record); ok {
+			labelMap[key] = labels
+			txtRecordsSet.Insert(record.DNSName)
+			im.existingTXTs.add(record)
+			continue
+		}
+		labels, err := endpoint.NewLabelsFromStringWithKeys(record.Targets[0], im.txtEncryptAESKeys)
+		if errors.Is(err, endpoint.ErrInvalidHeritage) {
+			// if no heritage is found or it is invalid
+			// case when value of txt record cannot be identified
+			// record will not be removed as it will have empty owner
+			endpoints = append(endpoints, record)
+			continue
+		}
+
 		labelMap[key] = labels
+		if labels.EncryptedWithPreviousKey() {
+			previousKeyRecords++
+		}
 		txtRecordsSet.Insert(record.DNSName)
 		im.existingTXTs.add(record)
+		if im.orphanCleanup && labels[endpoint.OwnerLabelKey] == im.ownerID {
+			ownedTXTs = append(ownedTXTs, ownedTXT{record: record, key: key})
+		}
+	}
+
+	// the consolidated records take precedence when they are written, the per record type ones otherwise
+	for key, labels := range consolidatedLabelMap {
+		if _, ok := labelMap[key]; !ok || im.consolidated {
+			labelMap[key] = labels
+		}
 	}
 
 	for _, ep := range endpoints {
@@ -254,12 +403,16 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 
 		labels, labelsExist := labelMap[key]
+		// the TXT records of the old format, without record type, belong to any record of the name
+		usedKeys.Insert(key)
+		usedKeys.Insert(endpoint.EndpointKey{DNSName: key.DNSName, SetIdentifier: key.SetIdentifier})
 
 		// A ALIAS records used the legacy "cname-" prefix. Fall back to it so ownership
 		// survives migration to "a-", and report the stale record once. See issue #2903.
 		if isAliasARecord(ep) {
 			legacyKey := key
 			legacyKey.RecordType = endpoint.RecordTypeCNAME
+			usedKeys.Insert(legacyKey)
 			if legacyLabels, ok := labelMap[legacyKey]; ok {
 				if !labelsExist {
 					labels, labelsExist = legacyLabels, true
@@ -281,26 +434,51 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			ep.Labels[endpoint.OwnerLabelKey] = im.ownerID
 		}
 
+		if im.txtEncryptReencrypt && ep.Labels.EncryptedWithPreviousKey() && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID &&
+			plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
+			ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+		}
+
 		// TODO: remove this migration logic in some future release
 		// Handle the migration of TXT records created before the new format (introduced in v0.12.0).
 		// The migration is done for the TXT records owned by this instance only.
 		if len(txtRecordsSet) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
 			if plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
-				// Get desired TXT records and detect the missing ones
-				desiredTXTs := im.generateTXTRecord(ep)
-				for _, desiredTXT := range desiredTXTs {
-					if !txtRecordsSet.Has(desiredTXT.DNSName) {
+				if im.consolidates(ep) {
+					// Migrate the TXT record per record type to the consolidated one
+					if !im.hasConsolidatedEntry(ep) || txtRecordsSet.Has(im.mapper.ToTXTName(ep.DNSName, ep.RecordType)) {
 						ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
 					}
+				} else if _, ok := ep.Labels[endpoint.SharedTargetsLabelKey]; im.shares(ep) && !ok {
+					// Migrate the TXT record to a shared one
+					ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+				} else {
+					// Get desired TXT records and detect the missing ones
+					desiredTXTs := im.generateTXTRecord(ep)
+					for _, desiredTXT := range desiredTXTs {
+						if !txtRecordsSet.Has(desiredTXT.DNSName) {
+							ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+						}
+					}
 				}
 			}
 		}
 	}
 
+	if len(im.txtEncryptAESKeys) > 1 {
+		previousKeyRecordsTotal.Gauge.Set(float64(previousKeyRecords))
+	}
+
+	if im.orphanCleanup {
+		im.detectOrphans(ownedTXTs, usedKeys)
+	}
+
 	// Update the cache.
 	if im.cacheInterval > 0 {
-		im.recordsCache = endpoints
+		im.cacheMutex.Lock()
+		im.recordsCache = slices.Clone(endpoints)
 		im.recordsCacheRefreshTime = time.Now()
+		im.cacheMutex.Unlock()
 	}
 
 	return endpoints, nil
@@ -341,7 +519,7 @@ func (im *TXTRegistry) generateTXTRecordWithFilter(r *endpoint.Endpoint, filter
 		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
 	}
 
-	txtNew := endpoint.NewEndpoint(im.mapper.ToTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
+	txtNew := endpoint.NewEndpoint(im.mapper.ToTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.SerializeWithKeys(true, im.txtEncryptEnabled, im.txtEncryptAESKeys))
 	if txtNew != nil {
 		txtNew.WithSetIdentifier(r.SetIdentifier)
 		txtNew.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
@@ -363,13 +541,25 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
 	}
 
+	// touched maps the names of the consolidated TXT records to update to the DNS names they belong to
+	touched := map[string]string{}
+	im.consolidatedMutex.Lock()
+	im.sharedMutex.RLock()
+	// sharedChanged is set when a shared TXT record is changed, so that it is read again
+	sharedChanged := false
+
 	for _, r := range filteredChanges.Create {
 		if r.Labels == nil {
 			r.Labels = make(map[string]string)
 		}
 		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		im.setSharedTargets(r)
 
-		filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecordWithFilter(r, im.existingTXTs.isAbsent)...)
+		if im.consolidates(r) {
+			im.setConsolidatedEntry(r, r.Labels, touched)
+		} else {
+			filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecordWithFilter(r, im.existingTXTs.isAbsent)...)
+		}
 
 		if im.cacheInterval > 0 {
 			im.addToCache(r)
@@ -380,18 +570,43 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 		// when we delete TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
 		// !!! After migration to the new TXT registry format we can drop records in old format here!!!
-		filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecord(r)...)
+		if im.consolidates(r) {
+			filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecordWithFilter(r, im.existingTXTs.exists)...)
+		} else if txt, ok := im.sharedTXTs[im.sharedKey(r)]; ok {
+			// the planner only deletes shared records without other owners
+			filteredChanges.Delete = append(filteredChanges.Delete, txt.record)
+			sharedChanged = true
+		} else {
+			filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecord(r)...)
+		}
+		im.setConsolidatedEntry(r, nil, touched)
 
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
 		}
 	}
 
+	// adopted holds the records without owner adopted by a resource, which have no TXT record to update yet
+	adopted := sets.New[endpoint.EndpointKey]()
+
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateOld {
 		// when we updateOld TXT records for which value has changed (due to new label) this would still work because
 		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
-		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		if r.IsAdopted() {
+			adopted.Insert(r.Key())
+		}
+		if im.consolidates(r) {
+			// the TXT record per record type is replaced by the consolidated one
+			filteredChanges.Delete = append(filteredChanges.Delete, im.generateTXTRecordWithFilter(r, im.existingTXTs.exists)...)
+		} else if txt, ok := im.sharedTXTs[im.sharedKey(r)]; ok {
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, txt.record)
+		} else if im.hasConsolidatedEntry(r) || r.IsAdopted() {
+			// the TXT record per record type replaces the consolidated one or is created for the adopted record, see below
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecordWithFilter(r, im.existingTXTs.exists)...)
+		} else {
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		}
 		// remove old version of record from cache
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
@@ -400,18 +615,69 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateNew {
-		filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		// re-encrypt records still encrypted with a previous key
+		r.Labels.ForgetPreviousKey()
+		txt, shared := im.sharedTXTs[im.sharedKey(r)]
+		if !shared {
+			im.setSharedTargets(r)
+		}
+		if im.consolidates(r) {
+			im.setConsolidatedEntry(r, r.Labels, touched)
+		} else if shared {
+			// this owner is removed from the shared record when its targets are not set
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateSharedTXT(r, txt))
+			sharedChanged = true
+		} else if im.hasConsolidatedEntry(r) || adopted.Has(r.Key()) {
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecordWithFilter(r, im.existingTXTs.exists)...)
+			filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecordWithFilter(r, im.existingTXTs.isAbsent)...)
+			im.setConsolidatedEntry(r, nil, touched)
+		} else {
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		}
 		// add new version of record to cache
 		if im.cacheInterval > 0 {
 			im.addToCache(r)
 		}
 	}
 
+	consolidatedChanges := im.consolidatedChanges(touched)
+	filteredChanges.Create = append(filteredChanges.Create, consolidatedChanges.Create...)
+	filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, consolidatedChanges.UpdateOld...)
+	filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, consolidatedChanges.UpdateNew...)
+	filteredChanges.Delete = append(filteredChanges.Delete, consolidatedChanges.Delete...)
+	im.skipOrphans(filteredChanges)
+	im.sharedMutex.RUnlock()
+	im.consolidatedMutex.Unlock()
+
+	// the shared records change with the other owners, so they are not kept in the cache
+	if sharedChanged && im.cacheInterval > 0 {
+		im.cacheMutex.Lock()
+		im.recordsCache = nil
+		im.cacheMutex.Unlock()
+	}
+
 	// when caching is enabled, disable the provider from using the cache
 	if im.cacheInterval > 0 {
 		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
 	}
-	return im.provider.ApplyChanges(ctx, filteredChanges)
+	err := im.provider.ApplyChanges(ctx, filteredChanges)
+
+	im.consolidatedMutex.Lock()
+	defer im.consolidatedMutex.Unlock()
+	if err != nil {
+		// the consolidated records are read again on the next synchronization
+		for name := range touched {
+			delete(im.consolidatedTXTs, name)
+		}
+		if len(touched) > 0 && im.cacheInterval > 0 {
+			im.cacheMutex.Lock()
+			im.recordsCache = nil
+			im.cacheMutex.Unlock()
+		}
+		return err
+	}
+	im.commitConsolidatedChanges(touched)
+	return nil
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
@@ -419,13 +685,28 @@ func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 	return im.provider.AdjustEndpoints(endpoints)
 }
 
+// cachedRecords returns a copy of the cached records, or nil when they are not cached or the cache expired.
+// The copy keeps the records returned from changing with the changes applied concurrently per zone.
+func (im *TXTRegistry) cachedRecords() []*endpoint.Endpoint {
+	im.cacheMutex.RLock()
+	defer im.cacheMutex.RUnlock()
+	if im.recordsCache == nil || time.Since(im.recordsCacheRefreshTime) >= im.cacheInterval {
+		return nil
+	}
+	return slices.Clone(im.recordsCache)
+}
+
 func (im *TXTRegistry) addToCache(ep *endpoint.Endpoint) {
+	im.cacheMutex.Lock()
+	defer im.cacheMutex.Unlock()
 	if im.recordsCache != nil {
 		im.recordsCache = append(im.recordsCache, ep)
 	}
 }
 
 func (im *TXTRegistry) removeFromCache(ep *endpoint.Endpoint) {
+	im.cacheMutex.Lock()
+	defer im.cacheMutex.Unlock()
 	if im.recordsCache == nil || ep == nil {
 		return
 	}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1432,6 +1433,38 @@ func TestCacheMethods(t *testing.T) {
 	}
 }
 
+func TestCachedRecords(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	registry, err := newRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, "")
+	require.NoError(t, err)
+	registry.recordsCache = []*endpoint.Endpoint{
+		newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner"),
+		newEndpointWithOwner("thing1.com", "1.2.3.6", "A", "owner"),
+	}
+	registry.recordsCacheRefreshTime = time.Now()
+
+	records, err := registry.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	// the records returned are not changed by the changes applied concurrently per zone
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			registry.removeFromCache(newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner"))
+			registry.addToCache(newEndpointWithOwner("thing2.com", "1.2.3.5", "A", "owner"))
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, "thing.com", records[0].DNSName)
+	assert.Equal(t, "thing1.com", records[1].DNSName)
+
+	records, err = registry.Records(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, records, 5)
+}
+
 func TestNewTXTScheme(t *testing.T) {
 	p := inmemory.NewInMemoryProvider()
 	err := p.CreateZone(testZone)
@@ -2297,3 +2330,98 @@ func TestTXTRegistryAliasARecordDeleteLeavesLegacyCNAME(t *testing.T) {
 	assert.NotNil(t, findEndpoint(applied.Delete, "a-alias.test-zone.example.org", endpoint.RecordTypeTXT), "the new a- ownership TXT must be deleted")
 	assert.Nil(t, findEndpoint(applied.Delete, "cname-alias.test-zone.example.org", endpoint.RecordTypeTXT), "the legacy cname- TXT should be kept")
 }
+
+func TestTXTRegistryWithNameTemplate(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	longName := strings.Repeat("l", 60) + ".test-zone.example.org"
+
+	newTemplateRegistry := func() *TXTRegistry {
+		r, err := newRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, "")
+		require.NoError(t, err)
+		require.NoError(t, r.withNameTemplate("%{record_type}-%{host}._owner.%{domain}"))
+		return r
+	}
+
+	r := newTemplateRegistry()
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+			newEndpointWithOwner(longName, "foo.test-zone.example.org", endpoint.RecordTypeCNAME, "owner"),
+		},
+	}))
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, findEndpoint(records, "a-foo._owner.test-zone.example.org", endpoint.RecordTypeTXT))
+	hashed := findEndpoint(records, r.mapper.ToTXTName(longName, endpoint.RecordTypeCNAME), endpoint.RecordTypeTXT)
+	require.NotNil(t, hashed)
+	assert.Regexp(t, `^cname-[0-9a-f]{16}\._owner\.`, hashed.DNSName)
+
+	// a new registry reads the ownership back, including that of the hashed name
+	records, err = newTemplateRegistry().Records(ctx)
+	require.NoError(t, err)
+	for _, name := range []string{"foo.test-zone.example.org", longName} {
+		var found bool
+		for _, record := range records {
+			if record.DNSName == name && record.RecordType != endpoint.RecordTypeTXT {
+				found = true
+				assert.Equal(t, "owner", record.Labels[endpoint.OwnerLabelKey], name)
+			}
+		}
+		assert.True(t, found, name)
+	}
+}
+
+func TestTXTRegistryWithInvalidNameTemplate(t *testing.T) {
+	r, err := newRegistry(inmemory.NewInMemoryProvider(), "", "", "owner", 0, "", []string{}, []string{}, false, nil, "")
+	require.NoError(t, err)
+	require.ErrorContains(t, r.withNameTemplate("%{host}.%{domain}"), "must contain %{host} and %{record_type} exactly once")
+	assert.IsType(t, mapper.AffixNameMapper{}, r.mapper)
+}
+
+func TestTXTRegistryAdoptsRecordWithoutOwner(t *testing.T) {
+	for _, consolidated := range []bool{false, true} {
+		t.Run(fmt.Sprintf("consolidated=%t", consolidated), func(t *testing.T) {
+			ctx := t.Context()
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+			require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+				Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+			}))
+			r := newConsolidatedRegistry(t, p, "owner", consolidated)
+
+			desired := newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "").
+				WithProviderSpecific(endpoint.ProviderSpecificAdopt, "true")
+			sync := func() *plan.Changes {
+				current, err := r.Records(ctx)
+				require.NoError(t, err)
+				changes := (&plan.Plan{
+					Policies:       []plan.Policy{&plan.SyncPolicy{}},
+					Current:        current,
+					Desired:        []*endpoint.Endpoint{desired},
+					ManagedRecords: []string{endpoint.RecordTypeA},
+					OwnerID:        r.OwnerID(),
+				}).Calculate().Changes
+				require.NoError(t, r.ApplyChanges(ctx, changes))
+				return changes
+			}
+
+			changes := sync()
+			require.Len(t, changes.UpdateOld, 1)
+			assert.True(t, changes.UpdateOld[0].IsAdopted())
+
+			records, err := r.Records(ctx)
+			require.NoError(t, err)
+			adopted := findEndpoint(records, "foo.test-zone.example.org", endpoint.RecordTypeA)
+			require.NotNil(t, adopted)
+			assert.Equal(t, "owner", adopted.Labels[endpoint.OwnerLabelKey])
+			assert.Equal(t, endpoint.Targets{"1.2.3.4"}, adopted.Targets)
+			assert.Empty(t, adopted.ProviderSpecific)
+
+			// the adopted record is then owned like any other
+			assert.False(t, sync().HasChanges())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// sharedTXT is the TXT record of a record shared by several owners, holding the ownership
+// and the targets of every owner with one string per owner.
+type sharedTXT struct {
+	// record is the TXT record as read from the provider
+	record *endpoint.Endpoint
+	// foreign are the strings of the other owners, kept as they are
+	foreign []string
+}
+
+// shares returns whether the ownership of the endpoint is shared with the other owners of the record.
+// Only address records are shared, their targets being merged.
+func (im *TXTRegistry) shares(ep *endpoint.Endpoint) bool {
+	return im.shared && (ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA)
+}
+
+// sharedKey returns the key of the shared TXT record of the endpoint.
+func (im *TXTRegistry) sharedKey(ep *endpoint.Endpoint) recordKey {
+	return recordKey{dnsName: im.mapper.ToTXTName(ep.DNSName, ep.RecordType), setIdentifier: ep.SetIdentifier}
+}
+
+// readSharedTXT parses a TXT record shared by several owners and returns the labels of the record it belongs to:
+// the labels of this owner, or those of another owner when this owner does not contribute to the record yet,
+// along with the targets contributed by the other owners. It returns false when the TXT record is not shared.
+// It must be called with sharedMutex held.
+func (im *TXTRegistry) readSharedTXT(record *endpoint.Endpoint) (endpoint.Labels, bool) {
+	txt := &sharedTXT{record: record}
+	var own endpoint.Labels
+	var foreignOwners []string
+	var foreignTargets endpoint.Targets
+	for _, target := range record.Targets {
+		labels, err := endpoint.NewLabelsFromStringWithKeys(target, im.txtEncryptAESKeys)
+		if err != nil {
+			log.Warnf("Keeping invalid string of the shared TXT record %s: %v", record.DNSName, err)
+			txt.foreign = append(txt.foreign, target)
+			continue
+		}
+		owner := labels[endpoint.OwnerLabelKey]
+		if owner == im.ownerID || (im.oldOwnerID != "" && owner == im.oldOwnerID) {
+			own = labels
+			continue
+		}
+		txt.foreign = append(txt.foreign, target)
+		if targets, ok := labels[endpoint.SharedTargetsLabelKey]; ok {
+			foreignOwners = append(foreignOwners, owner)
+			foreignTargets = append(foreignTargets, endpoint.NewTargetsFromString(targets)...)
+		}
+	}
+	if _, ok := own[endpoint.SharedTargetsLabelKey]; !ok && len(foreignOwners) == 0 {
+		return nil, false
+	}
+	if own == nil {
+		// the record is reported with one of its owners, this owner joins them by updating it
+		own = endpoint.Labels{endpoint.OwnerLabelKey: slices.Min(foreignOwners)}
+	}
+	own[endpoint.ForeignTargetsLabelKey] = endpoint.NewTargets(foreignTargets...).String()
+	im.sharedTXTs[recordKey{dnsName: record.DNSName, setIdentifier: record.SetIdentifier}] = txt
+	return own, true
+}
+
+// readForeignSharedTXT returns the labels of a TXT record shared by several owners, read by an owner which does
+// not share the ownership: the record is reported with one of the other owners, so that it is left alone,
+// since rewriting the TXT record with a single string would drop the ownership of the other owners.
+// It returns false when the TXT record is not shared, or holds no string of another owner.
+func (im *TXTRegistry) readForeignSharedTXT(record *endpoint.Endpoint) (endpoint.Labels, bool) {
+	shared := len(record.Targets) > 1
+	var foreignOwners []string
+	for _, target := range record.Targets {
+		labels, err := endpoint.NewLabelsFromStringWithKeys(target, im.txtEncryptAESKeys)
+		if err != nil {
+			continue
+		}
+		if _, ok := labels[endpoint.SharedTargetsLabelKey]; ok {
+			shared = true
+		}
+		if owner := labels[endpoint.OwnerLabelKey]; owner != im.ownerID && (im.oldOwnerID == "" || owner != im.oldOwnerID) {
+			foreignOwners = append(foreignOwners, owner)
+		}
+	}
+	if !shared || len(foreignOwners) == 0 {
+		return nil, false
+	}
+	log.Debugf("Skipping the TXT record %s shared by several owners, the ownership is not shared by this owner", record.DNSName)
+	return endpoint.Labels{endpoint.OwnerLabelKey: slices.Min(foreignOwners)}, true
+}
+
+// setSharedTargets records the targets of the endpoint as those contributed by this owner,
+// when they are not set by the planner.
+func (im *TXTRegistry) setSharedTargets(ep *endpoint.Endpoint) {
+	if _, ok := ep.Labels[endpoint.SharedTargetsLabelKey]; !ok && im.shares(ep) {
+		ep.Labels[endpoint.SharedTargetsLabelKey] = endpoint.NewTargets(ep.Targets...).String()
+	}
+}
+
+// generateSharedTXT returns the TXT record of a shared record, holding the strings of the other owners
+// and the labels of this owner, unless it no longer contributes to the record.
+func (im *TXTRegistry) generateSharedTXT(r *endpoint.Endpoint, txt *sharedTXT) *endpoint.Endpoint {
+	targets := slices.Clone(txt.foreign)
+	if _, ok := r.Labels[endpoint.SharedTargetsLabelKey]; ok {
+		targets = append(targets, r.Labels.SerializeWithKeys(true, im.txtEncryptEnabled, im.txtEncryptAESKeys))
+	}
+	slices.Sort(targets)
+	record := endpoint.NewEndpoint(txt.record.DNSName, endpoint.RecordTypeTXT, targets...).WithSetIdentifier(r.SetIdentifier)
+	record.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
+	record.ProviderSpecific = r.ProviderSpecific
+	return record
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func newSharedRegistry(t *testing.T, p *inmemory.InMemoryProvider, ownerID string, encrypted bool) *TXTRegistry {
+	t.Helper()
+	var aesKey []byte
+	if encrypted {
+		aesKey = []byte("passphrasewhichneedstobe32bytes!")
+	}
+	managedRecordTypes := []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME}
+	r, err := newRegistry(p, "", "", ownerID, 0, "", managedRecordTypes, []string{}, encrypted, aesKey, "")
+	require.NoError(t, err)
+	r.shared = true
+	return r
+}
+
+// syncShared runs a synchronization of the registry with the desired endpoints and returns the planned changes.
+func syncShared(t *testing.T, r *TXTRegistry, desired ...*endpoint.Endpoint) *plan.Changes {
+	t.Helper()
+	current, err := r.Records(t.Context())
+	require.NoError(t, err)
+	changes := (&plan.Plan{
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		OwnerID:        r.OwnerID(),
+	}).Calculate().Changes
+	require.NoError(t, r.ApplyChanges(t.Context(), changes))
+	return changes
+}
+
+func providerRecord(t *testing.T, p *inmemory.InMemoryProvider, dnsName, recordType string) *endpoint.Endpoint {
+	t.Helper()
+	records, err := p.Records(t.Context())
+	require.NoError(t, err)
+	return findEndpoint(records, dnsName, recordType)
+}
+
+func TestSharedTXTMergesTargets(t *testing.T) {
+	for _, encrypted := range []bool{false, true} {
+		t.Run(fmt.Sprintf("encrypted=%t", encrypted), func(t *testing.T) {
+			p := inmemory.NewInMemoryProvider()
+			require.NoError(t, p.CreateZone(testZone))
+			east := newSharedRegistry(t, p, "east", encrypted)
+			west := newSharedRegistry(t, p, "west", encrypted)
+			name := "api.test-zone.example.org"
+
+			syncShared(t, east, newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, ""))
+			assert.Equal(t, endpoint.Targets{"1.1.1.1"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+
+			// another owner joins the owners of the record, its targets being merged
+			changes := syncShared(t, west, newMultiTargetEndpointWithOwner(name, endpoint.Targets{"2.2.2.2", "3.3.3.3"}, endpoint.RecordTypeA, ""))
+			assert.Empty(t, changes.Create)
+			require.Len(t, changes.UpdateNew, 1)
+			assert.ElementsMatch(t, endpoint.Targets{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+			txt := txtRecords(t, p)["a-"+name+"/"]
+			require.NotNil(t, txt)
+			assert.Len(t, txt.Targets, 2, "one string per owner")
+
+			// both owners are in sync
+			assert.False(t, syncShared(t, east, newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, "")).HasChanges())
+			assert.False(t, syncShared(t, west, newMultiTargetEndpointWithOwner(name, endpoint.Targets{"2.2.2.2", "3.3.3.3"}, endpoint.RecordTypeA, "")).HasChanges())
+
+			records, err := east.Records(t.Context())
+			require.NoError(t, err)
+			record := findEndpoint(records, name, endpoint.RecordTypeA)
+			require.NotNil(t, record)
+			assert.Equal(t, "east", record.Labels[endpoint.OwnerLabelKey])
+			assert.Equal(t, "1.1.1.1", record.Labels[endpoint.SharedTargetsLabelKey])
+			assert.Equal(t, "2.2.2.2;3.3.3.3", record.Labels[endpoint.ForeignTargetsLabelKey])
+
+			// an owner changing its targets keeps those of the other owners
+			syncShared(t, east, newEndpointWithOwner(name, "4.4.4.4", endpoint.RecordTypeA, ""))
+			assert.ElementsMatch(t, endpoint.Targets{"2.2.2.2", "3.3.3.3", "4.4.4.4"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+
+			// an owner leaving removes its targets only
+			changes = syncShared(t, west)
+			assert.Empty(t, changes.Delete)
+			assert.Equal(t, endpoint.Targets{"4.4.4.4"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+			txt = txtRecords(t, p)["a-"+name+"/"]
+			require.NotNil(t, txt)
+			assert.Len(t, txt.Targets, 1)
+			records, err = west.Records(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, "east", findEndpoint(records, name, endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+
+			// the last owner deletes the record
+			changes = syncShared(t, east)
+			assert.Len(t, changes.Delete, 1)
+			assert.Nil(t, providerRecord(t, p, name, endpoint.RecordTypeA))
+			assert.Empty(t, txtRecords(t, p))
+		})
+	}
+}
+
+func TestSharedTXTMigration(t *testing.T) {
+	ctx := t.Context()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	name := "api.test-zone.example.org"
+
+	classic := newSharedRegistry(t, p, "east", false)
+	classic.shared = false
+	syncShared(t, classic, newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, ""))
+
+	// enabling the shared ownership records the targets of the owner
+	east := newSharedRegistry(t, p, "east", false)
+	records, err := east.Records(ctx)
+	require.NoError(t, err)
+	forceUpdate, _ := findEndpoint(records, name, endpoint.RecordTypeA).GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.Equal(t, "true", forceUpdate)
+	syncShared(t, east, newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, ""))
+	assert.Equal(t, endpoint.Targets{
+		`"heritage=external-dns,external-dns/owner=east,external-dns/shared-targets=1.1.1.1"`,
+	}, txtRecords(t, p)["a-"+name+"/"].Targets)
+
+	// another owner can then join
+	west := newSharedRegistry(t, p, "west", false)
+	syncShared(t, west, newEndpointWithOwner(name, "2.2.2.2", endpoint.RecordTypeA, ""))
+	assert.ElementsMatch(t, endpoint.Targets{"1.1.1.1", "2.2.2.2"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+}
+
+func TestSharedTXTIgnoresOtherRecordTypes(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	name := "www.test-zone.example.org"
+
+	east := newSharedRegistry(t, p, "east", false)
+	syncShared(t, east, newEndpointWithOwner(name, "lb.example.com", endpoint.RecordTypeCNAME, ""))
+	assert.NotContains(t, txtRecords(t, p)["cname-"+name+"/"].Targets[0], endpoint.SharedTargetsLabelKey)
+
+	// CNAME records keep a single owner
+	west := newSharedRegistry(t, p, "west", false)
+	assert.False(t, syncShared(t, west, newEndpointWithOwner(name, "other.example.com", endpoint.RecordTypeCNAME, "")).HasChanges())
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, providerRecord(t, p, name, endpoint.RecordTypeCNAME).Targets)
+}
+
+func TestSharedTXTLeftAloneWithoutSharedOwnership(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	name := "api.test-zone.example.org"
+
+	syncShared(t, newSharedRegistry(t, p, "east", false), newEndpointWithOwner(name, "1.1.1.1", endpoint.RecordTypeA, ""))
+	syncShared(t, newSharedRegistry(t, p, "west", false), newEndpointWithOwner(name, "2.2.2.2", endpoint.RecordTypeA, ""))
+	txt := txtRecords(t, p)["a-"+name+"/"]
+	require.NotNil(t, txt)
+	require.Len(t, txt.Targets, 2)
+
+	// an owner of the record without the shared ownership leaves the record of the other owners alone
+	classic := newSharedRegistry(t, p, "east", false)
+	classic.shared = false
+	records, err := classic.Records(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "west", findEndpoint(records, name, endpoint.RecordTypeA).Labels[endpoint.OwnerLabelKey])
+	assert.False(t, syncShared(t, classic, newEndpointWithOwner(name, "3.3.3.3", endpoint.RecordTypeA, "")).HasChanges())
+
+	assert.ElementsMatch(t, endpoint.Targets{"1.1.1.1", "2.2.2.2"}, providerRecord(t, p, name, endpoint.RecordTypeA).Targets)
+	assert.ElementsMatch(t, txt.Targets, txtRecords(t, p)["a-"+name+"/"].Targets)
+}
@@ -51,6 +51,8 @@ var (
 	AliasKey         = AnnotationKeyPrefix + "alias"
 	RecordTypeKey    = AnnotationKeyPrefix + "record-type"
 	TargetKey        = AnnotationKeyPrefix + "target"
+	// AdoptKey The annotation used for adopting existing records without owner of the same name and type
+	AdoptKey = AnnotationKeyPrefix + "adopt"
 	// ControllerKey The annotation used for figuring out which controller is responsible
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	// HostnameKey The annotation used for defining the desired hostname
@@ -59,6 +61,10 @@ var (
 	AccessKey = AnnotationKeyPrefix + "access"
 	// EndpointsTypeKey The annotation used for specifying the type of endpoints to use for headless services
 	EndpointsTypeKey = AnnotationKeyPrefix + "endpoints-type"
+	// SRVRecordsKey The annotation used for publishing an SRV record for each named port of a service
+	SRVRecordsKey = AnnotationKeyPrefix + "srv-records"
+	// TopologyAwareKey The annotation used for publishing a record per topology zone of the endpoints of a service
+	TopologyAwareKey = AnnotationKeyPrefix + "topology-aware"
 	// Ingress the annotation used to determine if the gateway is implemented by an Ingress object
 	Ingress = AnnotationKeyPrefix + "ingress"
 	// IngressHostnameSourceKey The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
@@ -101,10 +107,13 @@ func SetAnnotationPrefix(prefix string) {
 	AliasKey = AnnotationKeyPrefix + "alias"
 	RecordTypeKey = AnnotationKeyPrefix + "record-type"
 	TargetKey = AnnotationKeyPrefix + "target"
+	AdoptKey = AnnotationKeyPrefix + "adopt"
 	ControllerKey = AnnotationKeyPrefix + "controller"
 	HostnameKey = AnnotationKeyPrefix + "hostname"
 	AccessKey = AnnotationKeyPrefix + "access"
 	EndpointsTypeKey = AnnotationKeyPrefix + "endpoints-type"
+	SRVRecordsKey = AnnotationKeyPrefix + "srv-records"
+	TopologyAwareKey = AnnotationKeyPrefix + "topology-aware"
 	Ingress = AnnotationKeyPrefix + "ingress"
 	IngressHostnameSourceKey = AnnotationKeyPrefix + "ingress-hostname-source"
 	InternalHostnameKey = AnnotationKeyPrefix + "internal-hostname"
@@ -34,6 +34,9 @@ func TestSetAnnotationPrefix(t *testing.T) {
 	assert.Equal(t, "custom.io/internal-hostname", InternalHostnameKey)
 	assert.Equal(t, "custom.io/ttl", TtlKey)
 	assert.Equal(t, "custom.io/target", TargetKey)
+	assert.Equal(t, "custom.io/adopt", AdoptKey)
+	assert.Equal(t, "custom.io/srv-records", SRVRecordsKey)
+	assert.Equal(t, "custom.io/topology-aware", TopologyAwareKey)
 	assert.Equal(t, "custom.io/controller", ControllerKey)
 	assert.Equal(t, "custom.io/cloudflare-proxied", CloudflareProxiedKey)
 	assert.Equal(t, "custom.io/cloudflare-custom-hostname", CloudflareCustomHostnameKey)
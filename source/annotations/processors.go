@@ -34,6 +34,23 @@ func hasAliasFromAnnotations(annotations map[string]string) bool {
 	return ok && aliasAnnotation == "true"
 }
 
+func hasAdoptFromAnnotations(annotations map[string]string) bool {
+	adoptAnnotation, ok := annotations[AdoptKey]
+	return ok && adoptAnnotation == "true"
+}
+
+// SRVRecordsFromAnnotations returns whether an SRV record is published for each named port of the resource.
+func SRVRecordsFromAnnotations(annotations map[string]string) bool {
+	srvAnnotation, ok := annotations[SRVRecordsKey]
+	return ok && srvAnnotation == "true"
+}
+
+// TopologyAwareFromAnnotations returns whether a record is published per topology zone of the endpoints of the resource.
+func TopologyAwareFromAnnotations(annotations map[string]string) bool {
+	topologyAnnotation, ok := annotations[TopologyAwareKey]
+	return ok && topologyAnnotation == "true"
+}
+
 // TTLFromAnnotations extracts the TTL from the annotations of the given resource.
 func TTLFromAnnotations(annotations map[string]string, resource string) endpoint.TTL {
 	ttlNotConfigured := endpoint.TTL(0)
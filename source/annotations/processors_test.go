@@ -244,6 +244,42 @@ func TestGetAliasFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestSRVRecordsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "srv-records annotation exists and is true",
+			annotations: map[string]string{SRVRecordsKey: "true"},
+			expected:    true,
+		},
+		{
+			name:        "srv-records annotation exists and is false",
+			annotations: map[string]string{SRVRecordsKey: "false"},
+			expected:    false,
+		},
+		{
+			name:        "srv-records annotation does not exist",
+			annotations: map[string]string{},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SRVRecordsFromAnnotations(tt.annotations))
+		})
+	}
+}
+
+func TestTopologyAwareFromAnnotations(t *testing.T) {
+	assert.True(t, TopologyAwareFromAnnotations(map[string]string{TopologyAwareKey: "true"}))
+	assert.False(t, TopologyAwareFromAnnotations(map[string]string{TopologyAwareKey: "false"}))
+	assert.False(t, TopologyAwareFromAnnotations(map[string]string{}))
+}
+
 func TestHostnamesFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
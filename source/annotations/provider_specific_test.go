@@ -121,6 +121,24 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "Adopt annotation",
+			annotations: map[string]string{
+				AdoptKey: "true",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: endpoint.ProviderSpecificAdopt, Value: "true"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Adopt annotation not true",
+			annotations: map[string]string{
+				AdoptKey: "yes",
+			},
+			expected:      endpoint.ProviderSpecific{},
+			setIdentifier: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -33,15 +33,17 @@ const (
 
 // connectorSource is an implementation of Source that provides endpoints by connecting
 // to a remote tcp server. The encoding/decoding is done using encoder/gob package.
+// The http(s) servers are served by streamingConnectorSource instead, which is the only one supporting events.
 //
 // +externaldns:source:name=connector
 // +externaldns:source:category=Special
-// +externaldns:source:description=Connects to a remote TCP server to receive DNS endpoints
-// +externaldns:source:resources=Remote TCP Server
+// +externaldns:source:description=Connects to a remote TCP or HTTP server to receive DNS endpoints
+// +externaldns:source:resources=Remote TCP or HTTP Server
 // +externaldns:source:filters=
 // +externaldns:source:namespace=
 // +externaldns:source:fqdn-template=false
 // +externaldns:source:provider-specific=false
+// +externaldns:source:events=false
 type connectorSource struct {
 	remoteServer string
 }
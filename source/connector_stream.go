@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/connector"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+const (
+	// connectorStreamIdleTimeout closes the streams without any message, heartbeats included, for this long.
+	connectorStreamIdleTimeout = 2 * time.Minute
+	// connectorStreamMinBackoff and connectorStreamMaxBackoff bound the delay between the reconnections.
+	connectorStreamMinBackoff = time.Second
+	connectorStreamMaxBackoff = 30 * time.Second
+)
+
+// streamingConnectorSource is an implementation of Source that keeps the endpoints pushed by a remote
+// server over a long-lived HTTP stream, see the connector package for the protocol. The endpoints
+// are kept when the stream is broken, until the snapshot of the next stream replaces them.
+type streamingConnectorSource struct {
+	serverURL   string
+	client      *http.Client
+	idleTimeout time.Duration
+
+	mu        sync.RWMutex
+	endpoints map[endpoint.EndpointKey]*endpoint.Endpoint
+	synced    bool
+	handlers  []func()
+}
+
+// NewStreamingConnectorSource creates a new streamingConnectorSource streaming the endpoints of the given URL
+// until the context is done. The TLS config is used for the https URLs.
+func NewStreamingConnectorSource(ctx context.Context, serverURL string, tlsConfig *tls.Config) (Source, error) {
+	request, err := http.NewRequest(http.MethodGet, serverURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connector server URL %q: %w", serverURL, err)
+	}
+	if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid connector server URL %q: the scheme must be http or https", serverURL)
+	}
+
+	cs := &streamingConnectorSource{
+		serverURL: serverURL,
+		client: &http.Client{
+			// no timeout, the streams are closed by the idle timeout
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				DialContext:         (&net.Dialer{Timeout: dialTimeout}).DialContext,
+				TLSClientConfig:     tlsConfig,
+				TLSHandshakeTimeout: dialTimeout,
+				ForceAttemptHTTP2:   true,
+			},
+		},
+		idleTimeout: connectorStreamIdleTimeout,
+		endpoints:   map[endpoint.EndpointKey]*endpoint.Endpoint{},
+	}
+	go cs.run(ctx)
+	return cs, nil
+}
+
+// Endpoints returns the endpoints of the last snapshot along with the changes received since.
+// It returns an error until the first snapshot is received, so that the records are not deleted.
+func (cs *streamingConnectorSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	if !cs.synced {
+		return nil, fmt.Errorf("no endpoints received yet from the connector server %s", cs.serverURL)
+	}
+	endpoints := make([]*endpoint.Endpoint, 0, len(cs.endpoints))
+	for _, ep := range cs.endpoints {
+		endpoints = append(endpoints, ep.DeepCopy())
+	}
+	return endpoint.MergeEndpoints(endpoints), nil
+}
+
+// AddEventHandler adds a handler called when the endpoints change.
+func (cs *streamingConnectorSource) AddEventHandler(_ context.Context, handler func()) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.handlers = append(cs.handlers, handler)
+}
+
+// run streams the endpoints until the context is done, reconnecting with an exponential backoff.
+func (cs *streamingConnectorSource) run(ctx context.Context) {
+	backoff := connectorStreamMinBackoff
+	for {
+		synced, err := cs.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if synced {
+			backoff = connectorStreamMinBackoff
+		}
+		log.Warnf("Connector stream of %s closed, reconnecting in %s: %v", cs.serverURL, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, connectorStreamMaxBackoff)
+	}
+}
+
+// stream applies the messages of a stream until it is closed, and returns whether its snapshot was received.
+func (cs *streamingConnectorSource) stream(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idle := time.AfterFunc(cs.idleTimeout, cancel)
+	defer idle.Stop()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, cs.serverURL, nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", connector.ContentType)
+	response, err := cs.client.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer extdnshttp.DrainAndClose(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	log.Infof("Connected to the connector server %s", cs.serverURL)
+
+	synced := false
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var message connector.Message
+		if err := decoder.Decode(&message); err != nil {
+			if ctx.Err() != nil && !idle.Stop() {
+				return synced, fmt.Errorf("no message for %s", cs.idleTimeout)
+			}
+			return synced, err
+		}
+		idle.Reset(cs.idleTimeout)
+
+		if message.Type == connector.MessageSnapshot {
+			synced = true
+		} else if !synced {
+			return false, fmt.Errorf("unexpected %q message before the snapshot", message.Type)
+		}
+		if cs.apply(message) {
+			cs.notify()
+		}
+	}
+}
+
+// apply applies the message to the endpoints, and returns whether they changed.
+func (cs *streamingConnectorSource) apply(message connector.Message) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	endpoints := slices.DeleteFunc(message.Endpoints, func(ep *endpoint.Endpoint) bool { return ep == nil })
+	for _, ep := range endpoints {
+		// the empty labels are omitted by the JSON encoding
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+	}
+	switch message.Type {
+	case connector.MessageSnapshot:
+		log.Debugf("Received a snapshot of %d endpoints from the connector server %s", len(endpoints), cs.serverURL)
+		cs.endpoints = make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(endpoints))
+		for _, ep := range endpoints {
+			cs.endpoints[ep.Key()] = ep
+		}
+		cs.synced = true
+	case connector.MessageUpsert:
+		for _, ep := range endpoints {
+			cs.endpoints[ep.Key()] = ep
+		}
+	case connector.MessageDelete:
+		for _, ep := range endpoints {
+			delete(cs.endpoints, ep.Key())
+		}
+	case connector.MessageHeartbeat:
+		return false
+	default:
+		log.Debugf("Ignoring the %q message of the connector server %s", message.Type, cs.serverURL)
+		return false
+	}
+	return true
+}
+
+func (cs *streamingConnectorSource) notify() {
+	cs.mu.RLock()
+	handlers := slices.Clone(cs.handlers)
+	cs.mu.RUnlock()
+	for _, handler := range handlers {
+		handler()
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/connector"
+)
+
+// startConnectorServer serves the connector server over HTTP/2 with TLS, and returns its URL along with the client TLS config.
+func startConnectorServer(t *testing.T, handler http.Handler) (*httptest.Server, *http.Transport) {
+	t.Helper()
+	httpServer := httptest.NewUnstartedServer(handler)
+	httpServer.EnableHTTP2 = true
+	httpServer.StartTLS()
+	t.Cleanup(httpServer.Close)
+	return httpServer, httpServer.Client().Transport.(*http.Transport)
+}
+
+func TestStreamingConnectorSource(t *testing.T) {
+	server := connector.NewServer()
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.com"),
+	})
+	httpServer, transport := startConnectorServer(t, server)
+
+	src, err := NewStreamingConnectorSource(t.Context(), httpServer.URL, transport.TLSClientConfig)
+	require.NoError(t, err)
+	var events atomic.Int32
+	src.AddEventHandler(t.Context(), func() { events.Add(1) })
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		endpoints, err := src.Endpoints(t.Context())
+		require.NoError(c, err)
+		testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+			endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.com"),
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return events.Load() == 1 }, 5*time.Second, 10*time.Millisecond, "the snapshot triggers an event")
+
+	server.Upsert(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.2"))
+	server.Delete(endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeCNAME))
+	assert.Eventually(t, func() bool { return events.Load() == 3 }, 5*time.Second, 10*time.Millisecond, "the changes trigger events")
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+	})
+
+	// the endpoints are kept while reconnecting, then replaced by the new snapshot
+	httpServer.CloseClientConnections()
+	server.Set([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "10.0.0.3"),
+	})
+	endpoints, err = src.Endpoints(t.Context())
+	require.NoError(t, err)
+	assert.NotEmpty(t, endpoints)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		endpoints, err := src.Endpoints(t.Context())
+		require.NoError(c, err)
+		require.Len(c, endpoints, 1)
+		assert.Equal(c, "c.example.com", endpoints[0].DNSName)
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestStreamingConnectorSourceNotSynced(t *testing.T) {
+	// the server never sends the snapshot
+	httpServer, transport := startConnectorServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(connector.Message{Type: connector.MessageUpsert, Endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		}})
+	}))
+
+	src, err := NewStreamingConnectorSource(t.Context(), httpServer.URL, transport.TLSClientConfig)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = src.Endpoints(t.Context())
+	assert.ErrorContains(t, err, "no endpoints received yet")
+}
+
+func TestStreamingConnectorSourceStream(t *testing.T) {
+	for _, tt := range []struct {
+		title      string
+		handler    http.HandlerFunc
+		wantSynced bool
+		wantErr    string
+	}{
+		{
+			title: "unexpected status",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+			},
+			wantErr: "unexpected status 403 Forbidden",
+		},
+		{
+			title: "change before the snapshot",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(connector.Message{Type: connector.MessageDelete})
+			},
+			wantErr: `unexpected "delete" message before the snapshot`,
+		},
+		{
+			title: "idle stream",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(connector.Message{Type: connector.MessageSnapshot})
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+			},
+			wantSynced: true,
+			wantErr:    "no message for 50ms",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			httpServer, transport := startConnectorServer(t, tt.handler)
+			cs := &streamingConnectorSource{
+				serverURL:   httpServer.URL,
+				client:      &http.Client{Transport: transport},
+				idleTimeout: 50 * time.Millisecond,
+				endpoints:   map[endpoint.EndpointKey]*endpoint.Endpoint{},
+			}
+
+			synced, err := cs.stream(t.Context())
+			assert.Equal(t, tt.wantSynced, synced)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStreamingConnectorSourceApply(t *testing.T) {
+	cs := &streamingConnectorSource{endpoints: map[endpoint.EndpointKey]*endpoint.Endpoint{}}
+
+	assert.True(t, cs.apply(connector.Message{Type: connector.MessageSnapshot, Endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		nil,
+	}}))
+	assert.True(t, cs.synced)
+	assert.Len(t, cs.endpoints, 1)
+
+	assert.False(t, cs.apply(connector.Message{Type: connector.MessageHeartbeat}))
+	assert.False(t, cs.apply(connector.Message{Type: "unknown"}))
+
+	assert.True(t, cs.apply(connector.Message{Type: connector.MessageUpsert, Endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+	}}))
+	assert.Len(t, cs.endpoints, 2)
+	assert.Equal(t, endpoint.Targets{"10.0.0.2"}, cs.endpoints[endpoint.EndpointKey{DNSName: "a.example.com", RecordType: endpoint.RecordTypeA}].Targets)
+
+	assert.True(t, cs.apply(connector.Message{Type: connector.MessageDelete, Endpoints: []*endpoint.Endpoint{
+		{DNSName: "a.example.com", RecordType: endpoint.RecordTypeAAAA},
+	}}))
+	assert.Len(t, cs.endpoints, 1)
+}
+
+func TestNewStreamingConnectorSourceInvalidURL(t *testing.T) {
+	for _, serverURL := range []string{"localhost:8080", "tcp://localhost:8080", "http://[::1"} {
+		_, err := NewStreamingConnectorSource(t.Context(), serverURL, nil)
+		assert.ErrorContains(t, err, "invalid connector server URL", serverURL)
+	}
+}
+
+func TestBuildConnectorSource(t *testing.T) {
+	src, err := buildConnectorSource(t.Context(), &Config{ConnectorServer: "localhost:8080"})
+	require.NoError(t, err)
+	assert.IsType(t, &connectorSource{}, src)
+
+	src, err = buildConnectorSource(t.Context(), &Config{ConnectorServer: "https://localhost:8443"})
+	require.NoError(t, err)
+	assert.IsType(t, &streamingConnectorSource{}, src)
+
+	_, err = buildConnectorSource(t.Context(), &Config{ConnectorServer: "https://localhost:8443", TLSClientCert: "tls.crt"})
+	assert.ErrorContains(t, err, "connector source TLS config")
+}
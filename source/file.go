@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/types"
+)
+
+// fileSource is an implementation of Source that reads the endpoints declared in the files of a directory:
+// lists of endpoints in YAML or JSON, and RFC 1035 zone files. The directory is checked for changes periodically.
+//
+// +externaldns:source:name=file
+// +externaldns:source:category=Special
+// +externaldns:source:description=Reads DNS endpoints from local YAML, JSON and zone files
+// +externaldns:source:resources=Local Files
+// +externaldns:source:filters=
+// +externaldns:source:namespace=
+// +externaldns:source:fqdn-template=false
+// +externaldns:source:provider-specific=true
+// +externaldns:source:events=true
+type fileSource struct {
+	dir      string
+	interval time.Duration
+
+	mu       sync.Mutex
+	handlers []func()
+}
+
+// fileParser parses the endpoints of a file.
+type fileParser func(path string, data []byte) ([]*endpoint.Endpoint, error)
+
+// NewFileSource creates a new fileSource reading the given directory, and checking it for changes
+// at the given interval until the context is done. The changes are not checked when the interval is zero.
+func NewFileSource(ctx context.Context, dir string, interval time.Duration) (Source, error) {
+	if dir == "" {
+		return nil, errors.New("the file source requires a directory, see --file-source-dir")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("file source directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("file source directory %q is not a directory", dir)
+	}
+
+	sc := &fileSource{dir: dir, interval: interval}
+	if interval > 0 {
+		fingerprint, err := sc.fingerprint()
+		if err != nil {
+			return nil, err
+		}
+		go sc.watch(ctx, fingerprint)
+	}
+	return sc, nil
+}
+
+// Endpoints returns the endpoints of the files. It returns an error when a file is invalid,
+// so that the records are not deleted while the file is being fixed.
+func (sc *fileSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	files, err := sc.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading endpoints file: %w", err)
+		}
+		fileEndpoints, err := parserFor(path)(path, data)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(sc.dir, path)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range fileEndpoints {
+			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("%s/%s", types.File, filepath.ToSlash(rel)))
+		}
+		endpoints = append(endpoints, fileEndpoints...)
+	}
+	return endpoint.MergeEndpoints(endpoints), nil
+}
+
+// AddEventHandler adds a handler called when the files change.
+func (sc *fileSource) AddEventHandler(_ context.Context, handler func()) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.handlers = append(sc.handlers, handler)
+}
+
+// watch calls the handlers when the fingerprint of the files changes, until the context is done.
+func (sc *fileSource) watch(ctx context.Context, fingerprint []byte) {
+	ticker := time.NewTicker(sc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current, err := sc.fingerprint()
+		if err != nil {
+			log.Warnf("Failed to check the files of %s for changes: %v", sc.dir, err)
+			continue
+		}
+		if bytes.Equal(current, fingerprint) {
+			continue
+		}
+		fingerprint = current
+		log.Infof("The files of %s changed", sc.dir)
+
+		sc.mu.Lock()
+		handlers := slices.Clone(sc.handlers)
+		sc.mu.Unlock()
+		for _, handler := range handlers {
+			handler()
+		}
+	}
+}
+
+// fingerprint returns a hash of the paths and contents of the files.
+func (sc *fileSource) fingerprint() ([]byte, error) {
+	files, err := sc.files()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading endpoints file: %w", err)
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", path, len(data))
+		hash.Write(data)
+	}
+	return hash.Sum(nil), nil
+}
+
+// files returns the supported files of the directory and its subdirectories, in lexical order.
+// The hidden files and directories are skipped, such as .git or the ..data directory of the ConfigMap volumes.
+func (sc *fileSource) files() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(sc.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == sc.dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || parserFor(path) == nil {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			// the symbolic links are followed to files only
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing the files of %s: %w", sc.dir, err)
+	}
+	return files, nil
+}
+
+// parserFor returns the parser of the file according to its extension, or nil when it is not supported.
+func parserFor(path string) fileParser {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseYAMLEndpoints
+	case ".json":
+		return parseJSONEndpoints
+	case ".zone":
+		return parseZoneFile
+	default:
+		return nil
+	}
+}
+
+// parseYAMLEndpoints parses a YAML list of endpoints, with the fields of the endpoints of the DNSEndpoint resources.
+func parseYAMLEndpoints(path string, data []byte) ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	if err := yaml.UnmarshalWithOptions(data, &endpoints, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("parsing endpoints file %q: %w", path, err)
+	}
+	return validateFileEndpoints(path, endpoints)
+}
+
+// parseJSONEndpoints parses a JSON array of endpoints, with the fields of the endpoints of the DNSEndpoint resources.
+func parseJSONEndpoints(path string, data []byte) ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("parsing endpoints file %q: %w", path, err)
+	}
+	return validateFileEndpoints(path, endpoints)
+}
+
+func validateFileEndpoints(path string, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	valid := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for i, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		if ep.DNSName == "" || ep.RecordType == "" {
+			return nil, fmt.Errorf("endpoint %d in %q has no dnsName or recordType", i, path)
+		}
+		valid = append(valid, ep)
+	}
+	return valid, nil
+}
+
+// parseZoneFile parses an RFC 1035 zone file. The origin is the name of the file without its extension,
+// such as example.com for example.com.zone, unless it is set by an $ORIGIN directive.
+// The SOA records and the NS records of their owner, the zone apex, are skipped as they belong to the DNS provider,
+// and so are the records of the types unsupported by ExternalDNS.
+func parseZoneFile(path string, data []byte) ([]*endpoint.Endpoint, error) {
+	origin := dns.Fqdn(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	parser := dns.NewZoneParser(bytes.NewReader(data), origin, path)
+
+	var records []dns.RR
+	apexes := map[string]bool{}
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			apexes[strings.ToLower(rr.Header().Name)] = true
+			continue
+		}
+		records = append(records, rr)
+	}
+	if err := parser.Err(); err != nil {
+		return nil, fmt.Errorf("parsing zone file %q: %w", path, err)
+	}
+
+	endpoints := make([]*endpoint.Endpoint, 0, len(records))
+	for _, rr := range records {
+		header := rr.Header()
+		if header.Rrtype == dns.TypeNS && apexes[strings.ToLower(header.Name)] {
+			continue
+		}
+		recordType := dns.TypeToString[header.Rrtype]
+		if header.Class != dns.ClassINET || !slices.Contains(endpoint.KnownRecordTypes, recordType) {
+			log.Debugf("Skipping the unsupported record %q of %s", rr.String(), path)
+			continue
+		}
+		target := strings.TrimPrefix(rr.String(), header.String())
+		if txt, ok := rr.(*dns.TXT); ok {
+			target = strings.Join(txt.Txt, "")
+		}
+		if ep := endpoint.NewEndpointWithTTL(header.Name, recordType, endpoint.TTL(header.Ttl), target); ep != nil {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
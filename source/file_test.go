@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func writeEndpointsFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+}
+
+func TestFileSourceEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	writeEndpointsFile(t, dir, "apps.yaml", `
+- dnsName: app.example.com
+  recordType: A
+  targets: [10.0.0.1, 10.0.0.2]
+  recordTTL: 300
+- dnsName: www.example.com
+  recordType: CNAME
+  targets: [app.example.com]
+  providerSpecific:
+    - name: alias
+      value: "true"
+`)
+	writeEndpointsFile(t, dir, "site-a/api.json", `[
+	{"dnsName": "api.example.com", "recordType": "AAAA", "targets": ["2001:db8::1"], "setIdentifier": "site-a"}
+]`)
+	writeEndpointsFile(t, dir, "example.org.zone", `
+$TTL 3600
+@       IN SOA  ns1.example.org. admin.example.org. 1 7200 3600 1209600 3600
+@       IN NS   ns1.example.org.
+@       IN MX   10 mail.example.org.
+mail    IN A    192.0.2.1
+mail    IN A    192.0.2.2
+edge    IN NS   ns.edge.example.org.
+_sip._udp 60 IN SRV 10 20 5060 mail.example.org.
+txt     IN TXT  "v=spf1" " -all"
+ftp     IN CNAME mail
+info    IN HINFO "x86" "linux"
+`)
+	// the unsupported and hidden files are skipped
+	writeEndpointsFile(t, dir, "README.md", "# records")
+	writeEndpointsFile(t, dir, ".git/config.yaml", "invalid: [")
+	writeEndpointsFile(t, dir, "..data/apps.yaml", "invalid: [")
+	// the symbolic links to files are followed
+	linked := filepath.Join(t.TempDir(), "linked.yaml")
+	require.NoError(t, os.WriteFile(linked, []byte("- {dnsName: linked.example.com, recordType: A, targets: [10.0.0.3]}\n"), 0o600))
+	require.NoError(t, os.Symlink(linked, filepath.Join(dir, "linked.yaml")))
+
+	src, err := NewFileSource(t.Context(), dir, 0)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "10.0.0.1", "10.0.0.2").
+			WithLabel(endpoint.ResourceLabelKey, "file/apps.yaml"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "app.example.com").
+			WithProviderSpecific("alias", "true").
+			WithLabel(endpoint.ResourceLabelKey, "file/apps.yaml"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1").
+			WithSetIdentifier("site-a").
+			WithLabel(endpoint.ResourceLabelKey, "file/site-a/api.json"),
+		endpoint.NewEndpoint("linked.example.com", endpoint.RecordTypeA, "10.0.0.3").
+			WithLabel(endpoint.ResourceLabelKey, "file/linked.yaml"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 3600, "10 mail.example.org").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+		endpoint.NewEndpointWithTTL("mail.example.org", endpoint.RecordTypeA, 3600, "192.0.2.1", "192.0.2.2").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+		endpoint.NewEndpointWithTTL("edge.example.org", endpoint.RecordTypeNS, 3600, "ns.edge.example.org").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+		endpoint.NewEndpointWithTTL("_sip._udp.example.org", endpoint.RecordTypeSRV, 60, "10 20 5060 mail.example.org.").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+		endpoint.NewEndpointWithTTL("txt.example.org", endpoint.RecordTypeTXT, 3600, "v=spf1 -all").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+		endpoint.NewEndpointWithTTL("ftp.example.org", endpoint.RecordTypeCNAME, 3600, "mail.example.org").
+			WithLabel(endpoint.ResourceLabelKey, "file/example.org.zone"),
+	})
+}
+
+func TestFileSourceInvalidFiles(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			title:    "invalid YAML",
+			name:     "apps.yaml",
+			contents: "- dnsName: [",
+			wantErr:  `parsing endpoints file`,
+		},
+		{
+			title:    "unknown field",
+			name:     "apps.yaml",
+			contents: "- dnsName: app.example.com\n  recordType: A\n  target: 10.0.0.1\n",
+			wantErr:  `parsing endpoints file`,
+		},
+		{
+			title:    "unknown JSON field",
+			name:     "apps.json",
+			contents: `[{"dnsName": "app.example.com", "type": "A"}]`,
+			wantErr:  `parsing endpoints file`,
+		},
+		{
+			title:    "endpoint without record type",
+			name:     "apps.yaml",
+			contents: "- dnsName: app.example.com\n  targets: [10.0.0.1]\n",
+			wantErr:  "endpoint 0",
+		},
+		{
+			title:    "invalid zone file",
+			name:     "example.com.zone",
+			contents: "www IN A not-an-ip\n",
+			wantErr:  "parsing zone file",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			dir := t.TempDir()
+			writeEndpointsFile(t, dir, tt.name, tt.contents)
+
+			src, err := NewFileSource(t.Context(), dir, 0)
+			require.NoError(t, err)
+
+			_, err = src.Endpoints(t.Context())
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewFileSourceInvalidDirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "apps.yaml")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	for _, dir := range []string{"", filepath.Join(t.TempDir(), "missing"), file} {
+		_, err := NewFileSource(t.Context(), dir, time.Second)
+		assert.Error(t, err, dir)
+	}
+}
+
+func TestFileSourceAddEventHandler(t *testing.T) {
+	dir := t.TempDir()
+	writeEndpointsFile(t, dir, "apps.yaml", "- {dnsName: app.example.com, recordType: A, targets: [10.0.0.1]}\n")
+
+	src, err := NewFileSource(t.Context(), dir, 10*time.Millisecond)
+	require.NoError(t, err)
+	var events atomic.Int32
+	src.AddEventHandler(t.Context(), func() { events.Add(1) })
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, events.Load(), "no event without changes")
+
+	writeEndpointsFile(t, dir, "apps.yaml", "- {dnsName: app.example.com, recordType: A, targets: [10.0.0.2]}\n")
+	assert.Eventually(t, func() bool { return events.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	writeEndpointsFile(t, dir, "zones/example.com.zone", "www 300 IN A 10.0.0.3\n")
+	assert.Eventually(t, func() bool { return events.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "apps.yaml")))
+	assert.Eventually(t, func() bool { return events.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "10.0.0.3").
+			WithLabel(endpoint.ResourceLabelKey, "file/zones/example.com.zone"),
+	})
+}
@@ -277,27 +277,28 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	return endpoint.MergeEndpoints(endpoints), nil
 }
 
-// extractHeadlessEndpoints extracts endpoints from a headless service using the "Endpoints" Kubernetes API resource
-func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
+// extractHeadlessEndpoints extracts endpoints from a headless service using the "Endpoints" Kubernetes API resource.
+// For topology-aware services, it also returns the zone of the targets.
+func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) ([]*endpoint.Endpoint, map[string]topologyZone) {
 	var endpoints []*endpoint.Endpoint
 
 	selector, err := annotations.ParseFilter(labels.Set(svc.Spec.Selector).AsSelectorPreValidated().String())
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 
 	serviceKey := cache.ObjectName{Namespace: svc.Namespace, Name: svc.Name}.String()
 	rawEndpointSlices, err := sc.endpointSlicesInformer.Informer().GetIndexer().ByIndex(informers.IndexWithSelectors, serviceKey)
 	if err != nil {
 		log.Errorf("Get EndpointSlices of service[%s] error:%v", svc.GetName(), err)
-		return nil
+		return nil, nil
 	}
 
 	endpointSlices := convertToEndpointSlices(rawEndpointSlices)
 	pods, err := sc.podInformer.Lister().Pods(svc.Namespace).List(selector)
 	if err != nil {
 		log.Errorf("List Pods of service[%s] error:%v", svc.GetName(), err)
-		return endpoints
+		return endpoints, nil
 	}
 
 	endpointsType := getEndpointsTypeFromAnnotations(svc.Annotations)
@@ -305,10 +306,15 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	publishNotReadyAddresses := svc.Spec.PublishNotReadyAddresses || sc.alwaysPublishNotReadyAddresses
 
 	targetsByHeadlessDomainAndType := sc.processHeadlessEndpointsFromSlices(
-		pods, endpointSlices, hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		pods, endpointSlices, hostname, endpointsType, publishPodIPs, publishNotReadyAddresses,
+		annotations.SRVRecordsFromAnnotations(svc.Annotations))
 	endpoints = buildHeadlessEndpoints(svc, targetsByHeadlessDomainAndType, ttl)
 
-	return endpoints
+	var zones map[string]topologyZone
+	if annotations.TopologyAwareFromAnnotations(svc.Annotations) {
+		zones = sc.headlessTargetZones(pods, endpointSlices, hostname, endpointsType)
+	}
+	return endpoints, zones
 }
 
 // Helper to convert raw objects to EndpointSlice
@@ -326,7 +332,8 @@ func convertToEndpointSlices(rawEndpointSlices []any) []*discoveryv1.EndpointSli
 }
 
 // processHeadlessEndpointsFromSlices processes EndpointSlices specifically for headless services
-// and returns deduped targets by domain/type.
+// and returns deduped targets by domain/type. With srvRecords, it also returns the SRV targets
+// of the named ports, one per pod with a hostname.
 // TODO: Consider refactoring with generics when available: https://github.com/kubernetes/kubernetes/issues/133544
 func (sc *serviceSource) processHeadlessEndpointsFromSlices(
 	pods []*v1.Pod,
@@ -335,6 +342,7 @@ func (sc *serviceSource) processHeadlessEndpointsFromSlices(
 	endpointsType string,
 	publishPodIPs bool,
 	publishNotReadyAddresses bool,
+	srvRecords bool,
 ) map[endpoint.EndpointKey]endpoint.Targets {
 	targetsByHeadlessDomainAndType := make(map[endpoint.EndpointKey]endpoint.Targets)
 	for _, endpointSlice := range endpointSlices {
@@ -369,6 +377,23 @@ func (sc *serviceSource) processHeadlessEndpointsFromSlices(
 					targetsByHeadlessDomainAndType[key] = append(targetsByHeadlessDomainAndType[key], target)
 				}
 			}
+			if srvRecords {
+				// the pods are reached directly on the ports of the endpoints, through their own name when they have one
+				host := headlessDomains[len(headlessDomains)-1]
+				for _, port := range endpointSlice.Ports {
+					if port.Name == nil || port.Port == nil {
+						continue
+					}
+					protocol := v1.ProtocolTCP
+					if port.Protocol != nil {
+						protocol = *port.Protocol
+					}
+					if name, target, ok := namedPortSRV(*port.Name, protocol, *port.Port, hostname, host); ok {
+						key := endpoint.EndpointKey{DNSName: name, RecordType: endpoint.RecordTypeSRV}
+						targetsByHeadlessDomainAndType[key] = append(targetsByHeadlessDomainAndType[key], target)
+					}
+				}
+			}
 		}
 	}
 	// Return a copy of the map to prevent external modifications
@@ -520,6 +545,8 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 	targets := annotations.TargetsFromTargetAnnotation(svc.Annotations)
 
 	endpoints := make([]*endpoint.Endpoint, 0)
+	// the zone of the targets of topology-aware services
+	var zones map[string]topologyZone
 
 	if len(targets) == 0 {
 		switch svc.Spec.Type {
@@ -531,18 +558,23 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 			}
 		case v1.ServiceTypeClusterIP:
 			if svc.Spec.ClusterIP == v1.ClusterIPNone {
-				endpoints = append(endpoints, sc.extractHeadlessEndpoints(svc, hostname, ttl)...)
+				var headlessEndpoints []*endpoint.Endpoint
+				headlessEndpoints, zones = sc.extractHeadlessEndpoints(svc, hostname, ttl)
+				endpoints = append(endpoints, headlessEndpoints...)
 			} else if useClusterIP || sc.publishInternal {
 				targets = extractServiceIps(svc)
 			}
 		case v1.ServiceTypeNodePort:
 			// add the nodeTargets and extract an SRV endpoint
-			var err error
-			targets, err = sc.extractNodePortTargets(svc)
+			nodes, err := sc.nodePortNodes(svc)
 			if err != nil {
 				log.Errorf("Unable to extract targets from service %s/%s error: %v", svc.Namespace, svc.Name, err)
 				return endpoints
 			}
+			targets = sc.extractNodePortTargets(svc, nodes)
+			if annotations.TopologyAwareFromAnnotations(svc.Annotations) {
+				zones = nodeTargetZones(nodes)
+			}
 			endpoints = append(endpoints, sc.extractNodePortEndpoints(svc, hostname, ttl)...)
 		case v1.ServiceTypeExternalName:
 			targets = extractServiceExternalName(svc)
@@ -554,8 +586,20 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 		}
 	}
 
+	if len(targets) > 0 && svc.Spec.Type != v1.ServiceTypeExternalName && annotations.SRVRecordsFromAnnotations(svc.Annotations) {
+		for _, en := range sc.extractNamedPortEndpoints(svc, hostname, ttl) {
+			en.ProviderSpecific = providerSpecific
+			en.SetIdentifier = setIdentifier
+			endpoints = append(endpoints, en)
+		}
+	}
+
 	endpoints = append(endpoints, endpoint.EndpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 
+	if zones != nil {
+		endpoints = splitByZone(endpoints, hostname, zones)
+	}
+
 	return endpoints
 }
 
@@ -687,24 +731,21 @@ func (sc *serviceSource) pods(svc *v1.Service) []*v1.Pod {
 	return pods
 }
 
-func (sc *serviceSource) extractNodePortTargets(svc *v1.Service) (endpoint.Targets, error) {
+// nodePortNodes returns the nodes the given NodePort service is reached through.
+func (sc *serviceSource) nodePortNodes(svc *v1.Service) ([]*v1.Node, error) {
+	if svc.Spec.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal {
+		return sc.nodesExternalTrafficPolicyTypeLocal(svc), nil
+	}
+	return sc.nodeInformer.Lister().List(labels.Everything())
+}
+
+func (sc *serviceSource) extractNodePortTargets(svc *v1.Service, nodes []*v1.Node) endpoint.Targets {
 	var (
 		internalIPs endpoint.Targets
 		externalIPs endpoint.Targets
 		ipv6IPs     endpoint.Targets
-		nodes       []*v1.Node
 	)
 
-	if svc.Spec.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal {
-		nodes = sc.nodesExternalTrafficPolicyTypeLocal(svc)
-	} else {
-		var err error
-		nodes, err = sc.nodeInformer.Lister().List(labels.Everything())
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	for _, node := range nodes {
 		if node.Spec.Unschedulable && sc.excludeUnschedulable {
 			log.Debugf("Skipping node %s - unschedulable", node.Name)
@@ -727,21 +768,21 @@ func (sc *serviceSource) extractNodePortTargets(svc *v1.Service) (endpoint.Targe
 	switch access {
 	case "public":
 		if sc.exposeInternalIPv6 {
-			return append(externalIPs, ipv6IPs...), nil
+			return append(externalIPs, ipv6IPs...)
 		}
-		return externalIPs, nil
+		return externalIPs
 	case "private":
-		return internalIPs, nil
+		return internalIPs
 	}
 
 	if len(externalIPs) > 0 {
 		if sc.exposeInternalIPv6 {
-			return append(externalIPs, ipv6IPs...), nil
+			return append(externalIPs, ipv6IPs...)
 		}
-		return externalIPs, nil
+		return externalIPs
 	}
 
-	return internalIPs, nil
+	return internalIPs
 }
 
 func (sc *serviceSource) extractNodePortEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
@@ -775,6 +816,49 @@ func (sc *serviceSource) extractNodePortEndpoints(svc *v1.Service, hostname stri
 	return endpoints
 }
 
+// extractNamedPortEndpoints returns an SRV endpoint for each named port of the service, like the cluster DNS does.
+// The ports of NodePort services are reached on their node port.
+func (sc *serviceSource) extractNamedPortEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+
+	for _, port := range svc.Spec.Ports {
+		number := port.Port
+		if svc.Spec.Type == v1.ServiceTypeNodePort {
+			number = port.NodePort
+		}
+		recordName, target, ok := namedPortSRV(port.Name, port.Protocol, number, hostname, hostname)
+		if !ok {
+			continue
+		}
+		ep := endpoint.NewEndpointWithTTL(recordName, endpoint.RecordTypeSRV, ttl, target)
+		if ep != nil {
+			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name))
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	return endpoints
+}
+
+// namedPortSRV returns the name and the target of the SRV record of a named port, following the RFC 2782
+// format _port._proto.name with a priority of 0 and a weight of 50. It returns false for the ports
+// without name or number.
+func namedPortSRV(portName string, protocol v1.Protocol, port int32, hostname, host string) (string, string, bool) {
+	if portName == "" || port <= 0 {
+		return "", "", false
+	}
+	target := fmt.Sprintf("0 50 %d %s", port, provider.EnsureTrailingDot(host))
+	if _, err := endpoint.NewSRVRecord(target); err != nil {
+		log.Warnf("Skipping the SRV record of port %q of %s: %v", portName, hostname, err)
+		return "", "", false
+	}
+	proto := strings.ToLower(string(protocol))
+	if proto == "" {
+		proto = "tcp"
+	}
+	return fmt.Sprintf("_%s._%s.%s", portName, proto, hostname), target, true
+}
+
 func (sc *serviceSource) AddEventHandler(_ context.Context, handler func()) {
 	log.Debug("Adding event handler for service")
 
@@ -5260,7 +5260,7 @@ func TestProcessEndpointSlices_PublishPodIPsPodNil(t *testing.T) {
 
 	result := sc.processHeadlessEndpointsFromSlices(
 		pods, []*discoveryv1.EndpointSlice{endpointSlice},
-		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses, false)
 	assert.Empty(t, result, "No targets should be added when pod is nil and publishPodIPs is true")
 }
 
@@ -5286,7 +5286,7 @@ func TestProcessEndpointSlices_PublishPodIPsUnsupportedAddressType(t *testing.T)
 
 	result := sc.processHeadlessEndpointsFromSlices(
 		pods, []*discoveryv1.EndpointSlice{endpointSlice},
-		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses, false)
 	assert.Empty(t, result, "No targets should be added for unsupported address type when publishPodIPs is true")
 }
 
@@ -5316,7 +5316,7 @@ func TestProcessEndpointSlices_PublishPodIPsFalse(t *testing.T) {
 
 	result := sc.processHeadlessEndpointsFromSlices(
 		pods, []*discoveryv1.EndpointSlice{endpointSlice},
-		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses, false)
 	assert.NotEmpty(t, result, "Targets should be added when publishPodIPs is false")
 }
 
@@ -5346,7 +5346,7 @@ func TestProcessEndpointSlices_NotReadyWithPublishNotReady(t *testing.T) {
 
 	result := sc.processHeadlessEndpointsFromSlices(
 		pods, []*discoveryv1.EndpointSlice{endpointSlice},
-		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses, false)
 	assert.NotEmpty(t, result, "Not ready endpoints should be processed when publishNotReadyAddresses is true")
 }
 
@@ -5613,7 +5613,7 @@ func TestProcessEndpointSlices_PodWithHostname(t *testing.T) {
 
 	result := sc.processHeadlessEndpointsFromSlices(
 		pods, []*discoveryv1.EndpointSlice{endpointSlice},
-		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses)
+		hostname, endpointsType, publishPodIPs, publishNotReadyAddresses, false)
 
 	assert.NotEmpty(t, result, "Should create targets for pod with hostname")
 
@@ -6052,3 +6052,136 @@ func findEndpointByType(endpoints []*endpoint.Endpoint, recordType string) *endp
 	}
 	return nil
 }
+
+func TestServiceSourceNamedPortSRVRecords(t *testing.T) {
+	for _, tc := range []struct {
+		title       string
+		serviceType v1.ServiceType
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:       "load balancer service publishes the named ports",
+			serviceType: v1.ServiceTypeLoadBalancer,
+			annotations: map[string]string{annotations.SRVRecordsKey: "true"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("sip.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_sip._udp.sip.example.org", endpoint.RecordTypeSRV, "0 50 5060 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_sips._tcp.sip.example.org", endpoint.RecordTypeSRV, "0 50 5061 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+			},
+		},
+		{
+			title:       "node port service publishes the node ports",
+			serviceType: v1.ServiceTypeNodePort,
+			annotations: map[string]string{annotations.SRVRecordsKey: "true"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("sip.example.org", endpoint.RecordTypeA, "54.10.11.1").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_sip._udp.sip.example.org", endpoint.RecordTypeSRV, "0 50 30060 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_sips._tcp.sip.example.org", endpoint.RecordTypeSRV, "0 50 30061 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_foo._tcp.sip.example.org", endpoint.RecordTypeSRV, "0 50 30061 sip.example.org.", "0 50 30080 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+				endpoint.NewEndpoint("_foo._udp.sip.example.org", endpoint.RecordTypeSRV, "0 50 30060 sip.example.org.").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+			},
+		},
+		{
+			title:       "annotation disabled",
+			serviceType: v1.ServiceTypeLoadBalancer,
+			annotations: map[string]string{annotations.SRVRecordsKey: "false"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("sip.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			svcAnnotations := map[string]string{annotations.HostnameKey: "sip.example.org"}
+			maps.Copy(svcAnnotations, tc.annotations)
+			kubernetes := fake.NewClientset(
+				&v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+					Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "54.10.11.1"}}},
+				},
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: svcAnnotations},
+					Spec: v1.ServiceSpec{
+						Type: tc.serviceType,
+						Ports: []v1.ServicePort{
+							{Name: "sip", Protocol: v1.ProtocolUDP, Port: 5060, NodePort: 30060},
+							{Name: "sips", Protocol: v1.ProtocolTCP, Port: 5061, NodePort: 30061},
+							// ports without name have no SRV record
+							{Protocol: v1.ProtocolTCP, Port: 8080, NodePort: 30080},
+						},
+					},
+					Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+				},
+			)
+
+			client, err := NewServiceSource(t.Context(), kubernetes, &Config{LabelFilter: labels.Everything()})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(t.Context())
+			require.NoError(t, err)
+			testutils.ValidateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestProcessEndpointSlices_SRVRecords(t *testing.T) {
+	sc := &serviceSource{}
+
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "slice1", Namespace: "default"},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "ldap-0"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(true)},
+				Addresses:  []string{"10.0.0.1"},
+			},
+			{
+				TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "ldap-1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(true)},
+				Addresses:  []string{"10.0.0.2"},
+			},
+			{
+				TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "ldap-2"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(true)},
+				Addresses:  []string{"10.0.0.3"},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: new("ldap"), Port: new(int32(389))},
+			{Name: new("metrics"), Protocol: new(v1.ProtocolTCP), Port: new(int32(9090))},
+			// ports without name have no SRV record
+			{Port: new(int32(8080))},
+		},
+	}
+	pods := []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ldap-0"}, Spec: v1.PodSpec{Hostname: "ldap-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ldap-1"}, Spec: v1.PodSpec{Hostname: "ldap-1"}},
+		// pods without hostname are reached through the name of the service
+		{ObjectMeta: metav1.ObjectMeta{Name: "ldap-2"}},
+	}
+
+	result := sc.processHeadlessEndpointsFromSlices(
+		pods, []*discoveryv1.EndpointSlice{endpointSlice},
+		"ldap.example.com", "", false, false, true)
+
+	assert.Equal(t, endpoint.Targets{
+		"0 50 389 ldap-0.ldap.example.com.",
+		"0 50 389 ldap-1.ldap.example.com.",
+		"0 50 389 ldap.example.com.",
+	}, result[endpoint.EndpointKey{DNSName: "_ldap._tcp.ldap.example.com", RecordType: endpoint.RecordTypeSRV}])
+	assert.Len(t, result[endpoint.EndpointKey{DNSName: "_metrics._tcp.ldap.example.com", RecordType: endpoint.RecordTypeSRV}], 3)
+	assert.Len(t, result, 5, "A records of the service and of the pods with hostname, and SRV records of the named ports")
+}
+
+func TestNamedPortSRV(t *testing.T) {
+	name, target, ok := namedPortSRV("xmpp-client", v1.ProtocolTCP, 5222, "chat.example.org", "chat.example.org")
+	require.True(t, ok)
+	assert.Equal(t, "_xmpp-client._tcp.chat.example.org", name)
+	assert.Equal(t, "0 50 5222 chat.example.org.", target)
+
+	_, _, ok = namedPortSRV("", v1.ProtocolTCP, 5222, "chat.example.org", "chat.example.org")
+	assert.False(t, ok, "ports without name")
+	_, _, ok = namedPortSRV("xmpp-client", v1.ProtocolTCP, 0, "chat.example.org", "chat.example.org")
+	assert.False(t, ok, "node ports not allocated yet")
+}
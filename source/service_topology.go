@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// providerSpecificGeoProximityRegion is the AWS provider-specific property routing
+// the queries to the closest record, see provider/aws. It is the routing property
+// of the records per zone, so they are only routed by the AWS provider.
+const providerSpecificGeoProximityRegion = "aws/geoproximity-region"
+
+// topologyZone is the topology zone of a target, along with its region when known.
+type topologyZone struct {
+	zone   string
+	region string
+}
+
+// nodeTopologyZone returns the topology zone of the node from its well-known labels.
+func nodeTopologyZone(node *v1.Node) topologyZone {
+	return topologyZone{
+		zone:   node.Labels[v1.LabelTopologyZone],
+		region: node.Labels[v1.LabelTopologyRegion],
+	}
+}
+
+// nodeTargetZones returns the topology zone of the addresses of the nodes.
+func nodeTargetZones(nodes []*v1.Node) map[string]topologyZone {
+	zones := make(map[string]topologyZone)
+	for _, node := range nodes {
+		zone := nodeTopologyZone(node)
+		if zone.zone == "" {
+			continue
+		}
+		for _, address := range node.Status.Addresses {
+			zones[address.Address] = zone
+		}
+	}
+	return zones
+}
+
+// headlessTargetZones returns the topology zone of the targets of the EndpointSlice endpoints.
+// The zone is taken from the endpoint, or from its node when the endpoint has none, and the region from its node.
+func (sc *serviceSource) headlessTargetZones(
+	pods []*v1.Pod,
+	endpointSlices []*discoveryv1.EndpointSlice,
+	hostname string,
+	endpointsType string,
+) map[string]topologyZone {
+	zones := make(map[string]topologyZone)
+	for _, endpointSlice := range endpointSlices {
+		for _, ep := range endpointSlice.Endpoints {
+			pod := findPodForEndpoint(ep, pods)
+			if pod == nil {
+				continue
+			}
+			var zone topologyZone
+			if ep.Zone != nil {
+				zone.zone = *ep.Zone
+			}
+			if node := sc.endpointNode(ep, pod); node != nil {
+				nodeZone := nodeTopologyZone(node)
+				if zone.zone == "" {
+					zone.zone = nodeZone.zone
+				}
+				zone.region = nodeZone.region
+			}
+			if zone.zone == "" {
+				continue
+			}
+			for _, target := range sc.getTargetsForDomain(pod, ep, endpointSlice, endpointsType, hostname) {
+				zones[target] = zone
+			}
+		}
+	}
+	return zones
+}
+
+// endpointNode returns the node of the endpoint, if known.
+func (sc *serviceSource) endpointNode(ep discoveryv1.Endpoint, pod *v1.Pod) *v1.Node {
+	if sc.nodeInformer == nil {
+		return nil
+	}
+	nodeName := pod.Spec.NodeName
+	if ep.NodeName != nil {
+		nodeName = *ep.NodeName
+	}
+	if nodeName == "" {
+		return nil
+	}
+	node, err := sc.nodeInformer.Lister().Get(nodeName)
+	if err != nil {
+		log.Debugf("Node %s of pod %s/%s not found: %v", nodeName, pod.Namespace, pod.Name, err)
+		return nil
+	}
+	return node
+}
+
+// splitByZone splits the A and AAAA endpoints of the hostname into an endpoint per topology zone of their targets.
+// The endpoints are identified by their zone and hinted with its region, for the AWS provider to route the queries
+// to the closest record. The targets without zone are skipped. The endpoints are kept as they are when none
+// of their targets has a zone, or when a zone has no region and no geoproximity location is set by the annotations:
+// the records of such a zone could not be routed.
+func splitByZone(endpoints []*endpoint.Endpoint, hostname string, zones map[string]topologyZone) []*endpoint.Endpoint {
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.DNSName != hostname || (ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA) {
+			result = append(result, ep)
+			continue
+		}
+
+		targetsByZone := make(map[string]endpoint.Targets)
+		regions := make(map[string]string)
+		var skipped endpoint.Targets
+		for _, target := range ep.Targets {
+			zone, ok := zones[target]
+			if !ok {
+				skipped = append(skipped, target)
+				continue
+			}
+			targetsByZone[zone.zone] = append(targetsByZone[zone.zone], target)
+			if zone.region != "" {
+				regions[zone.zone] = zone.region
+			}
+		}
+		if len(targetsByZone) == 0 {
+			log.Debugf("Keeping the endpoint %s %s as none of its targets has a topology zone", ep.DNSName, ep.RecordType)
+			result = append(result, ep)
+			continue
+		}
+		if missing := zonesWithoutRegion(targetsByZone, regions); len(missing) > 0 && !hasGeoProximity(ep) {
+			log.Warnf("Keeping the endpoint %s %s as the zones %v of its targets have no region", ep.DNSName, ep.RecordType, missing)
+			result = append(result, ep)
+			continue
+		}
+		if len(skipped) > 0 {
+			log.Warnf("Skipping the targets %v of the endpoint %s %s without topology zone", skipped, ep.DNSName, ep.RecordType)
+		}
+
+		for _, zone := range slices.Sorted(maps.Keys(targetsByZone)) {
+			zoned := endpoint.NewEndpointWithTTL(ep.DNSName, ep.RecordType, ep.RecordTTL, targetsByZone[zone]...)
+			if zoned == nil {
+				continue
+			}
+			maps.Copy(zoned.Labels, ep.Labels)
+			zoned.ProviderSpecific = slices.Clone(ep.ProviderSpecific)
+			zoned.SetIdentifier = zone
+			if ep.SetIdentifier != "" {
+				zoned.SetIdentifier = ep.SetIdentifier + "-" + zone
+			}
+			if region, ok := regions[zone]; ok && !hasGeoProximity(zoned) {
+				zoned.WithProviderSpecific(providerSpecificGeoProximityRegion, region)
+			}
+			result = append(result, zoned)
+		}
+	}
+	return result
+}
+
+// zonesWithoutRegion returns the zones whose region is unknown, in order.
+func zonesWithoutRegion(targetsByZone map[string]endpoint.Targets, regions map[string]string) []string {
+	var missing []string
+	for _, zone := range slices.Sorted(maps.Keys(targetsByZone)) {
+		if _, ok := regions[zone]; !ok {
+			missing = append(missing, zone)
+		}
+	}
+	return missing
+}
+
+// hasGeoProximity returns whether a geoproximity location is already set on the endpoint, such as from annotations.
+func hasGeoProximity(ep *endpoint.Endpoint) bool {
+	return slices.ContainsFunc(ep.ProviderSpecific, func(p endpoint.ProviderSpecificProperty) bool {
+		return strings.HasPrefix(p.Name, "aws/geoproximity-")
+	})
+}
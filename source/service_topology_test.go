@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func newZoneNode(name, zone, region, address string) *v1.Node {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: address}}},
+	}
+	if zone != "" {
+		node.Labels[v1.LabelTopologyZone] = zone
+	}
+	if region != "" {
+		node.Labels[v1.LabelTopologyRegion] = region
+	}
+	return node
+}
+
+func zonedEndpoint(dnsName, recordType, zone, region string, targets ...string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(dnsName, recordType, targets...).
+		WithSetIdentifier(zone).
+		WithLabel(endpoint.ResourceLabelKey, "service/default/foo")
+	if region != "" {
+		ep.WithProviderSpecific(providerSpecificGeoProximityRegion, region)
+	}
+	return ep
+}
+
+func TestServiceSourceTopologyAwareNodePort(t *testing.T) {
+	kubernetes := fake.NewClientset(
+		newZoneNode("node-a1", "eu-west-1a", "eu-west-1", "54.10.11.1"),
+		newZoneNode("node-a2", "eu-west-1a", "eu-west-1", "54.10.11.2"),
+		newZoneNode("node-b", "us-east-1b", "us-east-1", "54.10.11.3"),
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: map[string]string{
+				annotations.HostnameKey:      "foo.example.org",
+				annotations.TopologyAwareKey: "true",
+			}},
+			Spec: v1.ServiceSpec{
+				Type:  v1.ServiceTypeNodePort,
+				Ports: []v1.ServicePort{{Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080}},
+			},
+		},
+	)
+
+	client, err := NewServiceSource(t.Context(), kubernetes, &Config{LabelFilter: labels.Everything()})
+	require.NoError(t, err)
+	endpoints, err := client.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		zonedEndpoint("foo.example.org", endpoint.RecordTypeA, "eu-west-1a", "eu-west-1", "54.10.11.1", "54.10.11.2"),
+		zonedEndpoint("foo.example.org", endpoint.RecordTypeA, "us-east-1b", "us-east-1", "54.10.11.3"),
+		// the SRV record of the node port is not split
+		endpoint.NewEndpoint("_foo._tcp.foo.example.org", endpoint.RecordTypeSRV, "0 50 30080 foo.example.org.").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+	})
+}
+
+// The records of the nodes without region cannot be routed, so they are not split.
+func TestServiceSourceTopologyAwareNodePortWithoutRegion(t *testing.T) {
+	kubernetes := fake.NewClientset(
+		newZoneNode("node-a", "zone-a", "", "54.10.11.1"),
+		newZoneNode("node-b", "zone-b", "", "54.10.11.2"),
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: map[string]string{
+				annotations.HostnameKey:      "foo.example.org",
+				annotations.TopologyAwareKey: "true",
+			}},
+			Spec: v1.ServiceSpec{
+				Type:  v1.ServiceTypeNodePort,
+				Ports: []v1.ServicePort{{Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080}},
+			},
+		},
+	)
+
+	client, err := NewServiceSource(t.Context(), kubernetes, &Config{LabelFilter: labels.Everything()})
+	require.NoError(t, err)
+	endpoints, err := client.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "54.10.11.1", "54.10.11.2").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+		endpoint.NewEndpoint("_foo._tcp.foo.example.org", endpoint.RecordTypeSRV, "0 50 30080 foo.example.org.").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+	})
+}
+
+func TestServiceSourceTopologyAwareHeadless(t *testing.T) {
+	ready := true
+	kubernetes := fake.NewClientset(
+		newZoneNode("node-a", "eu-west-1a", "eu-west-1", "54.10.11.1"),
+		newZoneNode("node-b", "eu-west-1b", "eu-west-1", "54.10.11.2"),
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: map[string]string{
+				annotations.HostnameKey:      "foo.example.org",
+				annotations.TopologyAwareKey: "true",
+			}},
+			Spec: v1.ServiceSpec{
+				Type:      v1.ServiceTypeClusterIP,
+				ClusterIP: v1.ClusterIPNone,
+				Selector:  map[string]string{"app": "foo"},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-1", Labels: map[string]string{"app": "foo"}},
+			Spec:       v1.PodSpec{NodeName: "node-a"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-2", Labels: map[string]string{"app": "foo"}},
+			Spec:       v1.PodSpec{NodeName: "node-b"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-3", Labels: map[string]string{"app": "foo"}},
+			Spec:       v1.PodSpec{NodeName: "node-b"},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "foo",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "foo-1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+					Zone:       new("eu-west-1a"),
+				},
+				{
+					// the zone is taken from the node
+					Addresses:  []string{"10.0.0.2"},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "foo-2"},
+					Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+				},
+				{
+					Addresses:  []string{"10.0.0.3"},
+					TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: "foo-3"},
+					Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+					Zone:       new("eu-west-1b"),
+					NodeName:   new("node-b"),
+				},
+			},
+		},
+	)
+
+	client, err := NewServiceSource(t.Context(), kubernetes, &Config{LabelFilter: labels.Everything()})
+	require.NoError(t, err)
+	endpoints, err := client.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		zonedEndpoint("foo.example.org", endpoint.RecordTypeA, "eu-west-1a", "eu-west-1", "10.0.0.1"),
+		zonedEndpoint("foo.example.org", endpoint.RecordTypeA, "eu-west-1b", "eu-west-1", "10.0.0.2", "10.0.0.3"),
+	})
+}
+
+func TestSplitByZone(t *testing.T) {
+	zones := map[string]topologyZone{
+		"10.0.0.1": {zone: "zone-a", region: "region-1"},
+		"10.0.0.2": {zone: "zone-b", region: "region-2"},
+		"10.0.0.3": {zone: "zone-a", region: "region-1"},
+		"10.0.0.5": {zone: "zone-c"},
+	}
+
+	t.Run("targets are grouped by zone", func(t *testing.T) {
+		endpoints := []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+				WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+				WithProviderSpecific("aws/evaluate-target-health", "true"),
+			// other names and types are kept
+			endpoint.NewEndpoint("_foo._tcp.foo.example.org", endpoint.RecordTypeSRV, "0 50 30080 foo.example.org."),
+			endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "10.0.0.1"),
+		}
+
+		result := splitByZone(endpoints, "foo.example.org", zones)
+
+		require.Len(t, result, 4)
+		a, b := result[0], result[1]
+		assert.Equal(t, endpoints[1:], result[2:])
+		assert.Equal(t, "zone-a", a.SetIdentifier)
+		assert.Equal(t, endpoint.Targets{"10.0.0.1", "10.0.0.3"}, a.Targets)
+		assert.Equal(t, endpoint.TTL(300), a.RecordTTL)
+		assert.Equal(t, "service/default/foo", a.Labels[endpoint.ResourceLabelKey])
+		assert.Equal(t, endpoint.ProviderSpecific{
+			{Name: "aws/evaluate-target-health", Value: "true"},
+			{Name: providerSpecificGeoProximityRegion, Value: "region-1"},
+		}, a.ProviderSpecific)
+		assert.Equal(t, "zone-b", b.SetIdentifier)
+		assert.Equal(t, endpoint.Targets{"10.0.0.2"}, b.Targets)
+		assert.Equal(t, endpoint.ProviderSpecific{
+			{Name: "aws/evaluate-target-health", Value: "true"},
+			{Name: providerSpecificGeoProximityRegion, Value: "region-2"},
+		}, b.ProviderSpecific)
+		assert.Len(t, endpoints[0].ProviderSpecific, 1, "the endpoint is not changed")
+	})
+
+	t.Run("the set identifier is prefixed and the geoproximity annotations are kept", func(t *testing.T) {
+		endpoints := []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.4").
+				WithSetIdentifier("cluster-1").
+				WithProviderSpecific("aws/geoproximity-coordinates", "51.5,-0.1"),
+		}
+
+		result := splitByZone(endpoints, "foo.example.org", zones)
+
+		require.Len(t, result, 1, "the targets without zone are skipped")
+		assert.Equal(t, "cluster-1-zone-a", result[0].SetIdentifier)
+		assert.Equal(t, endpoint.Targets{"10.0.0.1"}, result[0].Targets)
+		assert.Equal(t, endpoint.ProviderSpecific{{Name: "aws/geoproximity-coordinates", Value: "51.5,-0.1"}}, result[0].ProviderSpecific)
+	})
+
+	t.Run("endpoints with a zone without region are kept", func(t *testing.T) {
+		endpoints := []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.5")}
+		assert.Equal(t, endpoints, splitByZone(endpoints, "foo.example.org", zones))
+	})
+
+	t.Run("zones without region are split with the geoproximity annotations", func(t *testing.T) {
+		endpoints := []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.0.0.5").
+				WithProviderSpecific("aws/geoproximity-coordinates", "51.5,-0.1"),
+		}
+
+		result := splitByZone(endpoints, "foo.example.org", zones)
+
+		require.Len(t, result, 1)
+		assert.Equal(t, "zone-c", result[0].SetIdentifier)
+		assert.Equal(t, endpoint.ProviderSpecific{{Name: "aws/geoproximity-coordinates", Value: "51.5,-0.1"}}, result[0].ProviderSpecific)
+	})
+
+	t.Run("endpoints without zone are kept", func(t *testing.T) {
+		endpoints := []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.0.0.4")}
+		assert.Equal(t, endpoints, splitByZone(endpoints, "foo.example.org", zones))
+	})
+}
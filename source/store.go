@@ -18,8 +18,10 @@ package source
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +36,7 @@ import (
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	kubeclient "sigs.k8s.io/external-dns/pkg/client"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
 	"sigs.k8s.io/external-dns/source/annotations"
 	"sigs.k8s.io/external-dns/source/template"
 	"sigs.k8s.io/external-dns/source/types"
@@ -79,8 +82,13 @@ type Config struct {
 	PublishHostIP                  bool
 	AlwaysPublishNotReadyAddresses bool
 	ConnectorServer                string
+	TLSCA                          string
+	TLSClientCert                  string
+	TLSClientCertKey               string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
+	FileSourceDir                  string
+	FileSourceInterval             time.Duration
 	KubeConfig                     string
 	APIServerURL                   string
 	ServiceTypeFilter              []string
@@ -103,6 +111,7 @@ type Config struct {
 	NAT64Networks                  []string
 	MinTTL                         time.Duration
 	UnstructuredResources          []string
+	UnstructuredMappingConfig      string
 	PreferAlias                    bool
 	PTRSupported                   bool
 	CreatePTR                      bool
@@ -155,8 +164,13 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 		Provider:                       cfg.Provider,
 		AlwaysPublishNotReadyAddresses: cfg.AlwaysPublishNotReadyAddresses,
 		ConnectorServer:                cfg.ConnectorSourceServer,
+		TLSCA:                          cfg.TLSCA,
+		TLSClientCert:                  cfg.TLSClientCert,
+		TLSClientCertKey:               cfg.TLSClientCertKey,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
+		FileSourceDir:                  cfg.FileSourceDir,
+		FileSourceInterval:             cfg.FileSourceInterval,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
@@ -179,6 +193,7 @@ func NewSourceConfig(cfg *externaldns.Config, opts ...OverrideConfigOption) (*Co
 		NAT64Networks:                  cfg.NAT64Networks,
 		MinTTL:                         cfg.MinTTL,
 		UnstructuredResources:          cfg.UnstructuredResources,
+		UnstructuredMappingConfig:      cfg.UnstructuredMappingConfig,
 		TemplateEngine:                 tmpls,
 		PreferAlias:                    cfg.PreferAlias,
 		PTRSupported:                   cfg.IsPTRSupported(),
@@ -409,6 +424,7 @@ func ByNames(ctx context.Context, cfg *Config, p ClientGenerator) ([]Source, err
 // - "f5-*": F5 resources (virtualserver, transportserver)
 // - "fake": Fake source for testing
 // - "connector": Connector source for external systems
+// - "file": File source for local YAML, JSON and zone files
 //
 // Design Note: Gateway API sources use a different pattern (direct constructor calls)
 // because they have simpler initialization requirements.
@@ -449,7 +465,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 	case types.Fake:
 		return NewFakeSource(cfg)
 	case types.Connector:
-		return NewConnectorSource(cfg.ConnectorServer)
+		return buildConnectorSource(ctx, cfg)
 	case types.CRD:
 		return buildCRDSource(ctx, p, cfg)
 	case types.SkipperRouteGroup:
@@ -460,6 +476,8 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		return buildF5VirtualServerSource(ctx, p, cfg)
 	case types.F5TransportServer:
 		return buildF5TransportServerSource(ctx, p, cfg)
+	case types.File:
+		return NewFileSource(ctx, cfg.FileSourceDir, cfg.FileSourceInterval)
 	case types.Unstructured:
 		return buildUnstructuredSource(ctx, p, cfg)
 	}
@@ -582,6 +600,19 @@ func buildContourHTTPProxySource(ctx context.Context, p ClientGenerator, cfg *Co
 	return NewContourHTTPProxySource(ctx, dynamicClient, cfg)
 }
 
+// buildConnectorSource creates the streaming connector source for the http(s) URLs,
+// and the legacy TCP connector source for the host:port addresses.
+func buildConnectorSource(ctx context.Context, cfg *Config) (Source, error) {
+	if !strings.HasPrefix(cfg.ConnectorServer, "http://") && !strings.HasPrefix(cfg.ConnectorServer, "https://") {
+		return NewConnectorSource(cfg.ConnectorServer)
+	}
+	tlsConfig, err := tlsutils.NewTLSConfig(cfg.TLSClientCert, cfg.TLSClientCertKey, cfg.TLSCA, "", false, tls.VersionTLS12)
+	if err != nil {
+		return nil, fmt.Errorf("connector source TLS config: %w", err)
+	}
+	return NewStreamingConnectorSource(ctx, cfg.ConnectorServer, tlsConfig)
+}
+
 // buildGlooProxySource creates a Gloo source for exposing Gloo proxies as DNS records.
 // Requires both dynamic and standard Kubernetes clients.
 // Note: Does not accept context parameter in constructor (legacy design).
@@ -127,6 +127,18 @@ func (suite *ByNamesTestSuite) TestOnlyFake() {
 	suite.Nil(mockClientGenerator.KubeClientValue, "client should not be created")
 }
 
+func (suite *ByNamesTestSuite) TestOnlyFile() {
+	mockClientGenerator := new(testutils.MockClientGenerator)
+
+	sources, err := ByNames(context.TODO(), &Config{
+		sources:       []string{types.File},
+		FileSourceDir: suite.T().TempDir(),
+	}, mockClientGenerator)
+	suite.NoError(err, "should not generate errors")
+	suite.Len(sources, 1, "should generate file source")
+	suite.Nil(mockClientGenerator.KubeClientValue, "client should not be created")
+}
+
 func (suite *ByNamesTestSuite) TestSourceNotFound() {
 	mockClientGenerator := new(testutils.MockClientGenerator)
 	mockClientGenerator.On("KubeClient").Return(fakeKube.NewClientset(), nil)
@@ -43,4 +43,5 @@ const (
 	F5VirtualServer     Type = "f5-virtualserver"
 	F5TransportServer   Type = "f5-transportserver"
 	Unstructured        Type = "unstructured"
+	File                Type = "file"
 )
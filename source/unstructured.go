@@ -19,8 +19,11 @@ package source
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -49,10 +52,12 @@ import (
 // +externaldns:source:namespace=all,single
 // +externaldns:source:fqdn-template=true
 // +externaldns:source:provider-specific=false
-// +externaldns:source:events=false
+// +externaldns:source:events=true
 type unstructuredSource struct {
 	templateEngine template.Engine
 	informers      []kubeinformers.GenericInformer
+	// mappings are the field mappings of the informers whose resource has one
+	mappings map[kubeinformers.GenericInformer]*unstructuredMapping
 }
 
 // NewUnstructuredFQDNSource creates a new unstructuredSource.
@@ -62,7 +67,23 @@ func NewUnstructuredFQDNSource(
 	kubeClient kubernetes.Interface,
 	cfg *Config,
 ) (Source, error) {
-	gvrs, err := discoverResources(kubeClient, cfg.UnstructuredResources)
+	resources := cfg.UnstructuredResources
+	var mappings map[string]*unstructuredMapping
+	if cfg.UnstructuredMappingConfig != "" {
+		var err error
+		mappings, err = loadUnstructuredMappings(cfg.UnstructuredMappingConfig)
+		if err != nil {
+			return nil, err
+		}
+		// the resources with a mapping are watched even when not given with --unstructured-resource
+		for _, resource := range slices.Sorted(maps.Keys(mappings)) {
+			if !slices.Contains(resources, resource) {
+				resources = append(slices.Clip(resources), resource)
+			}
+		}
+	}
+
+	gvrs, err := discoverResources(kubeClient, resources)
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +98,12 @@ func NewUnstructuredFQDNSource(
 
 	// Create informers for each resource
 	resourceInformers := make([]kubeinformers.GenericInformer, 0, len(gvrs))
-	for _, gvr := range gvrs {
+	informerMappings := make(map[kubeinformers.GenericInformer]*unstructuredMapping)
+	for i, gvr := range gvrs {
 		informer := informerFactory.ForResource(gvr)
+		if mapping, ok := mappings[resources[i]]; ok {
+			informerMappings[informer] = mapping
+		}
 
 		// Add indexers for efficient lookups by namespace and labels (must be before AddEventHandler)
 		informers.MustAddIndexers(informer.Informer(), informers.IndexerWithOptions[*unstructured.Unstructured](
@@ -103,6 +128,7 @@ func NewUnstructuredFQDNSource(
 	return &unstructuredSource{
 		templateEngine: cfg.TemplateEngine,
 		informers:      resourceInformers,
+		mappings:       informerMappings,
 	}, nil
 }
 
@@ -137,19 +163,28 @@ func (us *unstructuredSource) endpointsFromInformer(informer kubeinformers.Gener
 		}
 
 		el := newUnstructuredWrapper(obj)
-
-		hosts := annotations.HostnamesFromAnnotations(el.GetAnnotations())
-		addrs := annotations.TargetsFromTargetAnnotation(el.GetAnnotations())
-		annotationEdps := endpoint.EndpointsForHostsAndTargets(hosts, addrs)
+		resource := fmt.Sprintf("%s/%s", strings.ToLower(el.GetKind()), el.GetName())
+
+		var annotationEdps []*endpoint.Endpoint
+		var ttl endpoint.TTL
+		if mapping := us.mappings[informer]; mapping != nil {
+			if !mapping.isReady(obj) {
+				log.Debugf("Skipping %s/%s of %s, it is not ready", el.GetNamespace(), el.GetName(), mapping.resource)
+				continue
+			}
+			annotationEdps, ttl = mapping.endpoints(obj, resource)
+		} else {
+			hosts := annotations.HostnamesFromAnnotations(el.GetAnnotations())
+			addrs := annotations.TargetsFromTargetAnnotation(el.GetAnnotations())
+			annotationEdps = endpoint.EndpointsForHostsAndTargets(hosts, addrs)
+			ttl = annotations.TTLFromAnnotations(el.GetAnnotations(), resource)
+		}
 
 		edps, err := us.templateEngine.ApplyTemplates(annotationEdps, el)
 		if err != nil {
 			return nil, err
 		}
 
-		ttl := annotations.TTLFromAnnotations(el.GetAnnotations(),
-			fmt.Sprintf("%s/%s", strings.ToLower(el.GetKind()), el.GetName()))
-
 		for _, ep := range edps {
 			ep.
 				WithRefObject(events.NewObjectReference(el, types.Unstructured)).
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// unstructuredMappingSpec maps the fields of a resource to DNS records. The fields are kubectl
+// JSONPath templates, such as "{.status.url}"; the text outside of the braces is kept as it is.
+type unstructuredMappingSpec struct {
+	// Resource is the resource in resource.version.group format, such as domainmappings.v1beta1.serving.knative.dev
+	Resource   string `yaml:"resource"`
+	Hostnames  string `yaml:"hostnames"`
+	Targets    string `yaml:"targets"`
+	TTL        string `yaml:"ttl"`
+	RecordType string `yaml:"recordType"`
+	// Ready selects the resources to create records for, it must evaluate to "True"
+	Ready string `yaml:"ready"`
+}
+
+// unstructuredMappingsConfig is the format of the file given by --unstructured-mapping-config.
+type unstructuredMappingsConfig struct {
+	Mappings []unstructuredMappingSpec `yaml:"mappings"`
+}
+
+// unstructuredMapping is an unstructuredMappingSpec with its JSONPath templates validated.
+// The templates are parsed again for each evaluation: a JSONPath keeps the state of the ranges it evaluates,
+// so it can neither be reused nor shared, such as by the sources of several controllers.
+type unstructuredMapping struct {
+	resource   string
+	hostnames  mappingTemplate
+	targets    mappingTemplate
+	ttl        mappingTemplate
+	recordType mappingTemplate
+	ready      mappingTemplate
+}
+
+// mappingTemplate is a JSONPath template of a mapped field, empty when the field is unset.
+type mappingTemplate struct {
+	name string
+	text string
+}
+
+// parse returns a new JSONPath of the template.
+func (t mappingTemplate) parse() (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(t.name).AllowMissingKeys(true)
+	if err := jp.Parse(t.text); err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", t.name, t.text, err)
+	}
+	return jp, nil
+}
+
+// loadUnstructuredMappings reads the field mappings of the given file, keyed by resource.
+func loadUnstructuredMappings(path string) (map[string]*unstructuredMapping, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading unstructured mapping config file %q: %w", path, err)
+	}
+
+	cfg := unstructuredMappingsConfig{}
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing unstructured mapping config file %q: %w", path, err)
+	}
+
+	mappings := make(map[string]*unstructuredMapping, len(cfg.Mappings))
+	for i, spec := range cfg.Mappings {
+		if spec.Resource == "" {
+			return nil, fmt.Errorf("mapping %d in %q has no resource", i, path)
+		}
+		if _, ok := mappings[spec.Resource]; ok {
+			return nil, fmt.Errorf("resource %q has more than one mapping in %q", spec.Resource, path)
+		}
+		mapping, err := newUnstructuredMapping(spec)
+		if err != nil {
+			return nil, fmt.Errorf("mapping of %q in %q: %w", spec.Resource, path, err)
+		}
+		mappings[spec.Resource] = mapping
+	}
+	return mappings, nil
+}
+
+func newUnstructuredMapping(spec unstructuredMappingSpec) (*unstructuredMapping, error) {
+	m := &unstructuredMapping{
+		resource:   spec.Resource,
+		hostnames:  mappingTemplate{name: "hostnames", text: spec.Hostnames},
+		targets:    mappingTemplate{name: "targets", text: spec.Targets},
+		ttl:        mappingTemplate{name: "ttl", text: spec.TTL},
+		recordType: mappingTemplate{name: "recordType", text: spec.RecordType},
+		ready:      mappingTemplate{name: "ready", text: spec.Ready},
+	}
+	for _, t := range []mappingTemplate{m.hostnames, m.targets, m.ttl, m.recordType, m.ready} {
+		if t.text == "" {
+			continue
+		}
+		if _, err := t.parse(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// isReady returns whether the object is ready, that is whether the ready template evaluates to "True".
+// The objects are ready when the mapping has no ready template.
+func (m *unstructuredMapping) isReady(obj *unstructured.Unstructured) bool {
+	if m.ready.text == "" {
+		return true
+	}
+	return strings.EqualFold(m.execute(m.ready, obj), "true")
+}
+
+// endpoints returns the endpoints of the object from its mapped fields, along with their TTL.
+// The hostname and target annotations take precedence over the mapped fields: the hostnames of the annotation are
+// added to the mapped hostnames, and the targets of the annotation replace the mapped targets.
+func (m *unstructuredMapping) endpoints(obj *unstructured.Unstructured, resource string) ([]*endpoint.Endpoint, endpoint.TTL) {
+	hosts := append(annotations.HostnamesFromAnnotations(obj.GetAnnotations()), m.values(m.hostnames, obj)...)
+	targets := annotations.TargetsFromTargetAnnotation(obj.GetAnnotations())
+	if len(targets) == 0 {
+		targets = m.values(m.targets, obj)
+	}
+
+	var endpoints []*endpoint.Endpoint
+	if recordType := strings.ToUpper(m.execute(m.recordType, obj)); recordType != "" {
+		for _, host := range hosts {
+			if ep := endpoint.NewEndpoint(host, recordType, targets...); ep != nil && len(targets) > 0 {
+				endpoints = append(endpoints, ep)
+			}
+		}
+	} else {
+		endpoints = endpoint.EndpointsForHostsAndTargets(hosts, targets)
+	}
+
+	ttl := annotations.TTLFromAnnotations(obj.GetAnnotations(), resource)
+	if ttlValue := m.execute(m.ttl, obj); ttl == 0 && ttlValue != "" {
+		ttl = annotations.TTLFromAnnotations(map[string]string{annotations.TtlKey: ttlValue}, resource)
+	}
+	return endpoints, ttl
+}
+
+// values returns the whitespace-separated values of the template. The URLs are reduced to their host,
+// such as https://app.example.com/ to app.example.com.
+func (m *unstructuredMapping) values(t mappingTemplate, obj *unstructured.Unstructured) []string {
+	fields := strings.Fields(m.execute(t, obj))
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if strings.Contains(field, "://") {
+			u, err := url.Parse(field)
+			if err != nil || u.Hostname() == "" {
+				log.Debugf("Skipping the value %q of %s %s/%s, it is not a valid URL", field, m.resource, obj.GetNamespace(), obj.GetName())
+				continue
+			}
+			field = u.Hostname()
+		}
+		values = append(values, field)
+	}
+	return values
+}
+
+// execute returns the output of the template for the object, or an empty string when the template is unset
+// or fails, such as when it ranges over a missing field.
+func (m *unstructuredMapping) execute(t mappingTemplate, obj *unstructured.Unstructured) string {
+	if t.text == "" {
+		return ""
+	}
+	jp, err := t.parse()
+	if err != nil {
+		// the templates are validated when loaded
+		log.Debugf("Failed to parse a mapped field of %s: %v", m.resource, err)
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, obj.Object); err != nil {
+		log.Debugf("Failed to evaluate a mapped field of %s %s/%s: %v", m.resource, obj.GetNamespace(), obj.GetName(), err)
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
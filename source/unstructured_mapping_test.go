@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+	templatetest "sigs.k8s.io/external-dns/source/template/testutil"
+)
+
+func writeMappingConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mappings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func newDomainMapping(name string, ready string, annots map[string]any) *unstructured.Unstructured {
+	metadata := map[string]any{"name": name, "namespace": "default"}
+	if annots != nil {
+		metadata["annotations"] = annots
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "serving.knative.dev/v1beta1",
+		"kind":       "DomainMapping",
+		"metadata":   metadata,
+		"status": map[string]any{
+			"url": "https://" + name + "/",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": ready},
+			},
+		},
+	}}
+}
+
+func TestUnstructuredSourceMappings(t *testing.T) {
+	const domainMappings = "domainmappings.v1beta1.serving.knative.dev"
+	const claims = "postgresinstances.v1alpha1.database.example.org"
+
+	config := writeMappingConfig(t, `
+mappings:
+  - resource: domainmappings.v1beta1.serving.knative.dev
+    hostnames: "{.status.url}"
+    targets: kourier.example.com
+    ready: '{.status.conditions[?(@.type=="Ready")].status}'
+  - resource: postgresinstances.v1alpha1.database.example.org
+    hostnames: "{.metadata.name}.db.example.com {.spec.aliases[*]}"
+    targets: "{.status.addresses[*].ip}"
+    ttl: "{.spec.dnsTTL}"
+    recordType: "{.spec.recordType}"
+`)
+
+	objects := []*unstructured.Unstructured{
+		newDomainMapping("app.example.com", "True", nil),
+		newDomainMapping("pending.example.com", "Unknown", nil),
+		// the annotations take precedence over the mapping
+		newDomainMapping("annotated.example.com", "True", map[string]any{
+			annotations.HostnameKey: "www.example.com",
+			annotations.TargetKey:   "10.0.0.1",
+			annotations.TtlKey:      "60",
+		}),
+		{Object: map[string]any{
+			"apiVersion": "database.example.org/v1alpha1",
+			"kind":       "PostgresInstance",
+			"metadata":   map[string]any{"name": "orders", "namespace": "shop"},
+			"spec": map[string]any{
+				"aliases":    []any{"orders.example.com"},
+				"dnsTTL":     "5m",
+				"recordType": "a",
+			},
+			"status": map[string]any{
+				"addresses": []any{
+					map[string]any{"ip": "10.1.0.1"},
+					map[string]any{"ip": "10.1.0.2"},
+				},
+			},
+		}},
+		// no target, so no records
+		{Object: map[string]any{
+			"apiVersion": "database.example.org/v1alpha1",
+			"kind":       "PostgresInstance",
+			"metadata":   map[string]any{"name": "creating", "namespace": "shop"},
+		}},
+	}
+	kubeClient, dynamicClient := setupUnstructuredTestClients(t, []string{domainMappings, claims}, objects)
+
+	src, err := NewUnstructuredFQDNSource(t.Context(), dynamicClient, kubeClient, &Config{
+		LabelFilter: labels.Everything(),
+		// the resources with a mapping are watched without being listed
+		UnstructuredResources:     []string{domainMappings},
+		UnstructuredMappingConfig: config,
+	})
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeCNAME, "kourier.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "domainmapping/default/app.example.com"),
+		endpoint.NewEndpointWithTTL("annotated.example.com", endpoint.RecordTypeA, 60, "10.0.0.1").
+			WithLabel(endpoint.ResourceLabelKey, "domainmapping/default/annotated.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "10.0.0.1").
+			WithLabel(endpoint.ResourceLabelKey, "domainmapping/default/annotated.example.com"),
+		endpoint.NewEndpointWithTTL("orders.db.example.com", endpoint.RecordTypeA, 300, "10.1.0.1", "10.1.0.2").
+			WithLabel(endpoint.ResourceLabelKey, "postgresinstance/shop/orders"),
+		endpoint.NewEndpointWithTTL("orders.example.com", endpoint.RecordTypeA, 300, "10.1.0.1", "10.1.0.2").
+			WithLabel(endpoint.ResourceLabelKey, "postgresinstance/shop/orders"),
+	})
+}
+
+func TestUnstructuredSourceMappingsWithTemplates(t *testing.T) {
+	const domainMappings = "domainmappings.v1beta1.serving.knative.dev"
+	config := writeMappingConfig(t, `
+mappings:
+  - resource: domainmappings.v1beta1.serving.knative.dev
+    hostnames: "{.metadata.name}"
+    targets: kourier.example.com
+    ready: '{.status.conditions[?(@.type=="Ready")].status}'
+`)
+	objects := []*unstructured.Unstructured{
+		newDomainMapping("app.example.com", "True", nil),
+		newDomainMapping("pending.example.com", "False", nil),
+	}
+	kubeClient, dynamicClient := setupUnstructuredTestClients(t, []string{domainMappings}, objects)
+
+	src, err := NewUnstructuredFQDNSource(t.Context(), dynamicClient, kubeClient, &Config{
+		LabelFilter:               labels.Everything(),
+		UnstructuredMappingConfig: config,
+		TemplateEngine:            templatetest.MustEngine(t, "{{.Name}}.internal.example.com", "kourier.example.com", "", true),
+	})
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+
+	// the objects which are not ready have no records, even from the templates
+	testutils.ValidateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeCNAME, "kourier.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "domainmapping/default/app.example.com"),
+		endpoint.NewEndpoint("app.example.com.internal.example.com", endpoint.RecordTypeCNAME, "kourier.example.com").
+			WithLabel(endpoint.ResourceLabelKey, "domainmapping/default/app.example.com"),
+	})
+}
+
+// The mappings are evaluated concurrently when the source is shared by several controllers,
+// which the race detector checks: the evaluation of a range updates the state of its JSONPath.
+func TestUnstructuredSourceMappingsConcurrentEndpoints(t *testing.T) {
+	const domainMappings = "domainmappings.v1beta1.serving.knative.dev"
+	config := writeMappingConfig(t, `
+mappings:
+  - resource: domainmappings.v1beta1.serving.knative.dev
+    hostnames: "{.status.url}"
+    targets: kourier.example.com
+    ready: '{range .status.conditions[?(@.type=="Ready")]}{.status}{end}'
+`)
+	objects := []*unstructured.Unstructured{
+		newDomainMapping("app.example.com", "True", nil),
+		newDomainMapping("api.example.com", "True", nil),
+		newDomainMapping("pending.example.com", "False", nil),
+	}
+	kubeClient, dynamicClient := setupUnstructuredTestClients(t, []string{domainMappings}, objects)
+
+	src, err := NewUnstructuredFQDNSource(t.Context(), dynamicClient, kubeClient, &Config{
+		LabelFilter:               labels.Everything(),
+		UnstructuredMappingConfig: config,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 20 {
+				endpoints, err := src.Endpoints(t.Context())
+				assert.NoError(t, err)
+				assert.Len(t, endpoints, 2)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestLoadUnstructuredMappings(t *testing.T) {
+	mappings, err := loadUnstructuredMappings(writeMappingConfig(t, `
+mappings:
+  - resource: configmaps.v1
+    hostnames: "{.data.hostname}"
+`))
+	require.NoError(t, err)
+	require.Contains(t, mappings, "configmaps.v1")
+	mapping := mappings["configmaps.v1"]
+	assert.Equal(t, "{.data.hostname}", mapping.hostnames.text)
+	assert.Empty(t, mapping.targets.text)
+	assert.Empty(t, mapping.ready.text)
+	assert.True(t, mapping.isReady(&unstructured.Unstructured{}), "the objects are ready without ready expression")
+
+	for _, tt := range []struct {
+		title    string
+		contents string
+		wantErr  string
+	}{
+		{
+			title:    "invalid YAML",
+			contents: "mappings: [",
+			wantErr:  "parsing unstructured mapping config file",
+		},
+		{
+			title:    "mapping without resource",
+			contents: "mappings:\n  - hostnames: '{.metadata.name}'\n",
+			wantErr:  "mapping 0",
+		},
+		{
+			title:    "resource mapped twice",
+			contents: "mappings:\n  - resource: configmaps.v1\n  - resource: configmaps.v1\n",
+			wantErr:  `resource "configmaps.v1" has more than one mapping`,
+		},
+		{
+			title:    "invalid JSONPath",
+			contents: "mappings:\n  - resource: configmaps.v1\n    targets: '{.data.target'\n",
+			wantErr:  "invalid targets",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			_, err := loadUnstructuredMappings(writeMappingConfig(t, tt.contents))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err = loadUnstructuredMappings(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "reading unstructured mapping config file")
+}
+
+func TestUnstructuredMappingValues(t *testing.T) {
+	mapping, err := newUnstructuredMapping(unstructuredMappingSpec{
+		Resource: "routes.v1.example.com",
+		Targets:  "{.status.urls[*]}",
+	})
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"urls": []any{"https://a.example.com/path", "http://b.example.com:8080", "http://", "c.example.com"},
+		},
+	}}
+	assert.Equal(t, []string{"a.example.com", "b.example.com", "c.example.com"}, mapping.values(mapping.targets, obj))
+	assert.Empty(t, mapping.values(mapping.targets, &unstructured.Unstructured{Object: map[string]any{}}))
+}
@@ -19,7 +19,9 @@ package source
 import (
 	"context"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -651,7 +653,21 @@ func TestAddEventHandler_Unstructured(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	src.AddEventHandler(t.Context(), func() {})
+	var calls atomic.Int32
+	src.AddEventHandler(t.Context(), func() { calls.Add(1) })
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, time.Second, 10*time.Millisecond,
+		"the handler is called for the objects of the informer")
+
+	calls.Store(0)
+	_, err = dynamicClient.Resource(schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}).
+		Namespace("default").Create(t.Context(), &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachineInstance",
+		"metadata":   map[string]any{"name": "other-vm", "namespace": "default"},
+	}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, time.Second, 10*time.Millisecond,
+		"the handler is called when an object is added")
 }
 
 func TestNewUnstructuredFQDNSource_Errors(t *testing.T) {